@@ -17,6 +17,12 @@ const (
 
 	// EnvRelatedImageTempoGatewayOpa contains the name of the environment variable where the tempoGatewayOpa image location is stored.
 	EnvRelatedImageTempoGatewayOpa = "RELATED_IMAGE_TEMPO_GATEWAY_OPA"
+
+	// EnvRelatedImageMemcached contains the name of the environment variable where the memcached image location is stored.
+	EnvRelatedImageMemcached = "RELATED_IMAGE_MEMCACHED"
+
+	// EnvRelatedImageMemcachedExporter contains the name of the environment variable where the memcachedExporter image location is stored.
+	EnvRelatedImageMemcachedExporter = "RELATED_IMAGE_MEMCACHED_EXPORTER"
 )
 
 // ImagesSpec defines the image for each container.
@@ -40,6 +46,16 @@ type ImagesSpec struct {
 	//
 	// +optional
 	TempoGatewayOpa string `json:"tempoGatewayOpa,omitempty"`
+
+	// Memcached defines the memcached container image of the managed cache.
+	//
+	// +optional
+	Memcached string `json:"memcached,omitempty"`
+
+	// MemcachedExporter defines the Prometheus exporter container image of the managed cache.
+	//
+	// +optional
+	MemcachedExporter string `json:"memcachedExporter,omitempty"`
 }
 
 // BuiltInCertManagement is the configuration for the built-in facility to generate and rotate
@@ -190,6 +206,13 @@ type FeatureGates struct {
 	// GrafanaOperator defines whether the Grafana Operator CRD exists in the cluster.
 	// This CRD is part of grafana-operator.
 	GrafanaOperator bool `json:"grafanaOperator,omitempty"`
+
+	// StorageCheck enables a preflight check of the object storage of each TempoStack.
+	// The operator lists the bucket and writes a marker object with the credentials of the storage secret,
+	// and reports the result in the StorageReachable condition of the TempoStack.
+	// The operator requires network access to the object storage, therefore the check is disabled by default.
+	// The check runs in the background and does not delay the reconciliation of the TempoStack.
+	StorageCheck bool `json:"storageCheck,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -20,10 +20,12 @@ func (c *ProjectConfig) Validate() error {
 
 	// Validate container images if set
 	for envName, envValue := range map[string]string{
-		EnvRelatedImageTempo:           c.DefaultImages.Tempo,
-		EnvRelatedImageTempoQuery:      c.DefaultImages.TempoQuery,
-		EnvRelatedImageTempoGateway:    c.DefaultImages.TempoGateway,
-		EnvRelatedImageTempoGatewayOpa: c.DefaultImages.TempoGatewayOpa,
+		EnvRelatedImageTempo:             c.DefaultImages.Tempo,
+		EnvRelatedImageTempoQuery:        c.DefaultImages.TempoQuery,
+		EnvRelatedImageTempoGateway:      c.DefaultImages.TempoGateway,
+		EnvRelatedImageTempoGatewayOpa:   c.DefaultImages.TempoGatewayOpa,
+		EnvRelatedImageMemcached:         c.DefaultImages.Memcached,
+		EnvRelatedImageMemcachedExporter: c.DefaultImages.MemcachedExporter,
 	} {
 		if envValue != "" {
 			_, err := dockerparser.Parse(envValue)
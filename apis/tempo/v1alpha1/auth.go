@@ -79,6 +79,38 @@ type AuthorizationSpec struct {
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Static Role Bindings"
 	RoleBindings []RoleBindingsSpec `json:"roleBindings"`
+	// OPA defines an external OpenPolicyAgent endpoint, which authorizes the requests of all tenants in static mode.
+	// OPA is mutually exclusive with Roles and RoleBindings.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="OPA"
+	OPA *OPASpec `json:"opa,omitempty"`
+}
+
+// OPASpec defines an external OpenPolicyAgent endpoint for the tempo Gateway component.
+type OPASpec struct {
+	// URL of the OPA query endpoint, for example https://opa.example.com/v1/data/tempostack/allow.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^https?://`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="URL"
+	URL string `json:"url"`
+	// CA is the name of a ConfigMap containing a `ca.crt` key with a CA certificate, which verifies the OPA endpoint
+	// in addition to the system certificates.
+	// It needs to be in the same namespace as the TempoStack custom resource.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:io.kubernetes:ConfigMap",displayName="CA ConfigMap Name"
+	CA string `json:"caName,omitempty"`
+	// WithAccessToken forwards the access token of the authenticated subject to the OPA endpoint.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch",displayName="Forward Access Token"
+	WithAccessToken bool `json:"withAccessToken,omitempty"`
 }
 
 // PermissionType is a Tempo Gateway RBAC permission.
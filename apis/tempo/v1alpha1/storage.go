@@ -1,41 +1,77 @@
 package v1alpha1
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
+	"strconv"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// storageLocationInUseMessage does not name the other instance, because it can be in a namespace the user has no access to.
+const storageLocationInUseMessage = "the bucket is already used by another TempoStack or TempoMonolithic with an overlapping prefix, " +
+	"the prefixes of instances sharing a bucket must not overlap"
+
+// storageLocation references the object storage of a TempoStack or TempoMonolithic instance.
+type storageLocation struct {
+	kind    string
+	key     types.NamespacedName
+	storage ObjectStorageSpec
+}
+
 // ValidateStorageSecret validates the object storage secret required for tempo.
 func ValidateStorageSecret(tempo TempoStack, storageSecret corev1.Secret) field.ErrorList {
 	path := field.NewPath("spec").Child("storage").Child("secret")
+	return ValidateObjectStorageSecret(path, tempo.Spec.Storage, storageSecret)
+}
 
+// ValidateObjectStorageSecret validates an object storage secret of the given type and credential mode.
+func ValidateObjectStorageSecret(path *field.Path, storage ObjectStorageSpec, storageSecret corev1.Secret) field.ErrorList {
+	secretSpec := storage.Secret
 	if storageSecret.Data == nil {
-		return field.ErrorList{field.Invalid(path, tempo.Spec.Storage.Secret, "storage secret is empty")}
+		return field.ErrorList{field.Invalid(path, secretSpec, "storage secret is empty")}
 	}
 
 	var allErrs field.ErrorList
 
-	switch tempo.Spec.Storage.Secret.Type {
+	switch secretSpec.Type {
 	case ObjectStorageSecretAzure:
-		allErrs = append(allErrs, validateAzureSecret(tempo, path, storageSecret)...)
+		if storage.CredentialMode == CredentialModeToken {
+			allErrs = append(allErrs, validateAzureTokenSecret(secretSpec, path, storageSecret)...)
+		} else {
+			allErrs = append(allErrs, validateAzureSecret(secretSpec, path, storageSecret)...)
+		}
+		allErrs = append(allErrs, validateAzureEndpointScheme(storage, path, storageSecret)...)
 	case ObjectStorageSecretGCS:
-		allErrs = append(allErrs, validateGCSSecret(tempo, path, storageSecret)...)
+		if storage.CredentialMode == CredentialModeToken {
+			allErrs = append(allErrs, validateGCSTokenSecret(secretSpec, path, storageSecret)...)
+		} else {
+			allErrs = append(allErrs, validateGCSSecret(secretSpec, path, storageSecret)...)
+		}
+		allErrs = append(allErrs, validateGCSEndpointScheme(storage, path, storageSecret)...)
 	case ObjectStorageSecretS3:
-		allErrs = append(allErrs, validateS3Secret(tempo, path, storageSecret)...)
+		if storage.CredentialMode == CredentialModeToken {
+			allErrs = append(allErrs, validateS3TokenSecret(secretSpec, path, storageSecret)...)
+		} else {
+			allErrs = append(allErrs, validateS3Secret(secretSpec, path, storageSecret)...)
+		}
 	case "":
 		allErrs = append(allErrs, field.Invalid(
 			path,
-			tempo.Spec.Storage.Secret,
+			secretSpec,
 			"storage secret must specify the type",
 		))
 	default:
 		allErrs = append(allErrs, field.Invalid(
 			path,
-			tempo.Spec.Storage.Secret,
-			fmt.Sprintf("%s is not an allowed storage secret type", tempo.Spec.Storage.Secret.Type),
+			secretSpec,
+			fmt.Sprintf("%s is not an allowed storage secret type", secretSpec.Type),
 		))
 	}
 
@@ -52,13 +88,134 @@ func ValidateStorageCAConfigMap(caConfigMap corev1.ConfigMap) field.ErrorList {
 	return nil
 }
 
-func ensureNotEmpty(tempo TempoStack, path *field.Path, storageSecret corev1.Secret, fields []string) field.ErrorList {
+// ObjectStoragePrefix returns the path prefix of the objects in the bucket or container,
+// i.e. the prefix of the storage spec or the "prefix" field of the storage secret.
+func ObjectStoragePrefix(storage ObjectStorageSpec, storageSecret corev1.Secret) string {
+	prefix := storage.Prefix
+	if prefix == "" {
+		prefix = string(storageSecret.Data["prefix"])
+	}
+	return strings.Trim(prefix, "/")
+}
+
+// objectStorageBucket returns an identifier of the bucket or container referenced by the storage secret.
+func objectStorageBucket(storage ObjectStorageSpec, storageSecret corev1.Secret) string {
+	switch storage.Secret.Type {
+	case ObjectStorageSecretAzure:
+		bucket := fmt.Sprintf("%s/%s", storageSecret.Data["account_name"], storageSecret.Data["container"])
+		return withEndpoint(string(storageSecret.Data["endpoint"])+string(storageSecret.Data["endpoint_suffix"]), bucket)
+	case ObjectStorageSecretGCS:
+		return withEndpoint(string(storageSecret.Data["endpoint"]), string(storageSecret.Data["bucketname"]))
+	case ObjectStorageSecretS3:
+		endpoint := string(storageSecret.Data["endpoint"])
+		if endpoint == "" {
+			endpoint = string(storageSecret.Data["region"])
+		}
+		return withEndpoint(endpoint, string(storageSecret.Data["bucket"]))
+	default:
+		return ""
+	}
+}
+
+// withEndpoint prepends the endpoint without scheme to a bucket identifier, if the endpoint is set.
+func withEndpoint(endpoint string, bucket string) string {
+	endpoint = strings.TrimPrefix(endpoint, "https://")
+	endpoint = strings.TrimPrefix(endpoint, "http://")
+	endpoint = strings.TrimSuffix(endpoint, "/")
+	if endpoint == "" {
+		return bucket
+	}
+	return fmt.Sprintf("%s/%s", endpoint, bucket)
+}
+
+// storageLocationInUse checks if another TempoStack or TempoMonolithic uses the same bucket with an overlapping prefix.
+func storageLocationInUse(ctx context.Context, c client.Client, location storageLocation) (bool, error) {
+	storageSecret := &corev1.Secret{}
+	err := c.Get(ctx, types.NamespacedName{Namespace: location.key.Namespace, Name: location.storage.Secret.Name}, storageSecret)
+	if err != nil {
+		// The storage secret is validated separately.
+		return false, nil
+	}
+	bucket := objectStorageBucket(location.storage, *storageSecret)
+	prefix := ObjectStoragePrefix(location.storage, *storageSecret)
+
+	others, err := storageLocations(ctx, c)
+	if err != nil {
+		return false, err
+	}
+
+	for _, other := range others {
+		if other.kind == location.kind && other.key == location.key {
+			continue
+		}
+		if other.storage.Secret.Type != location.storage.Secret.Type {
+			continue
+		}
+
+		otherSecret := &corev1.Secret{}
+		err := c.Get(ctx, types.NamespacedName{Namespace: other.key.Namespace, Name: other.storage.Secret.Name}, otherSecret)
+		if err != nil {
+			continue
+		}
+		if objectStorageBucket(other.storage, *otherSecret) != bucket {
+			continue
+		}
+		if objectStoragePrefixesOverlap(prefix, ObjectStoragePrefix(other.storage, *otherSecret)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// storageLocations returns the object storage of all TempoStack and TempoMonolithic instances.
+func storageLocations(ctx context.Context, c client.Client) ([]storageLocation, error) {
+	tempostacks := &TempoStackList{}
+	if err := c.List(ctx, tempostacks); err != nil {
+		return nil, fmt.Errorf("could not list TempoStacks: %w", err)
+	}
+	monolithics := &TempoMonolithicList{}
+	if err := c.List(ctx, monolithics); err != nil {
+		return nil, fmt.Errorf("could not list TempoMonolithics: %w", err)
+	}
+
+	var locations []storageLocation
+	for _, tempo := range tempostacks.Items {
+		locations = append(locations, storageLocation{
+			kind:    "TempoStack",
+			key:     types.NamespacedName{Namespace: tempo.Namespace, Name: tempo.Name},
+			storage: tempo.Spec.Storage,
+		})
+	}
+	for _, tempo := range monolithics.Items {
+		storage, ok := MonolithicObjectStorage(tempo.Spec.Storage.Traces)
+		if !ok {
+			continue
+		}
+		locations = append(locations, storageLocation{
+			kind:    "TempoMonolithic",
+			key:     types.NamespacedName{Namespace: tempo.Namespace, Name: tempo.Name},
+			storage: storage,
+		})
+	}
+	return locations, nil
+}
+
+// objectStoragePrefixesOverlap checks if the objects of two prefixes in the same bucket overlap.
+// An empty prefix overlaps with all prefixes, because Tempo lists all objects of the bucket.
+func objectStoragePrefixesOverlap(a, b string) bool {
+	if a == "" || b == "" {
+		return true
+	}
+	return strings.HasPrefix(a+"/", b+"/") || strings.HasPrefix(b+"/", a+"/")
+}
+
+func ensureNotEmpty(secretSpec ObjectStorageSecretSpec, path *field.Path, storageSecret corev1.Secret, fields []string) field.ErrorList {
 	var allErrs field.ErrorList
 	for _, key := range fields {
 		if storageSecret.Data[key] == nil || len(storageSecret.Data[key]) == 0 {
 			allErrs = append(allErrs, field.Invalid(
 				path,
-				tempo.Spec.Storage.Secret,
+				secretSpec,
 				fmt.Sprintf("storage secret must contain \"%s\" field", key),
 			))
 		}
@@ -66,7 +223,7 @@ func ensureNotEmpty(tempo TempoStack, path *field.Path, storageSecret corev1.Sec
 	return allErrs
 }
 
-func validateAzureSecret(tempo TempoStack, path *field.Path, storageSecret corev1.Secret) field.ErrorList {
+func validateAzureSecret(secretSpec ObjectStorageSecretSpec, path *field.Path, storageSecret corev1.Secret) field.ErrorList {
 	var allErrs field.ErrorList
 	secretFields := []string{
 		"container",
@@ -74,22 +231,173 @@ func validateAzureSecret(tempo TempoStack, path *field.Path, storageSecret corev
 		"account_key",
 	}
 
-	allErrs = append(allErrs, ensureNotEmpty(tempo, path, storageSecret, secretFields)...)
+	allErrs = append(allErrs, ensureNotEmpty(secretSpec, path, storageSecret, secretFields)...)
+	allErrs = append(allErrs, validateAzureEndpoint(secretSpec, path, storageSecret)...)
 	return allErrs
 }
 
-func validateGCSSecret(tempo TempoStack, path *field.Path, storageSecret corev1.Secret) field.ErrorList {
+// validateAzureTokenSecret validates an Azure storage secret used with Azure Workload Identity.
+func validateAzureTokenSecret(secretSpec ObjectStorageSecretSpec, path *field.Path, storageSecret corev1.Secret) field.ErrorList {
+	var allErrs field.ErrorList
+	secretFields := []string{
+		"container",
+		"account_name",
+		"client_id",
+		"tenant_id",
+		"subscription_id",
+	}
+
+	allErrs = append(allErrs, ensureNotEmpty(secretSpec, path, storageSecret, secretFields)...)
+	allErrs = append(allErrs, validateAzureEndpoint(secretSpec, path, storageSecret)...)
+	return allErrs
+}
+
+// validateAzureEndpoint validates the optional custom endpoint of an Azure storage secret,
+// e.g. of a sovereign cloud or the Azurite emulator.
+func validateAzureEndpoint(secretSpec ObjectStorageSecretSpec, path *field.Path, storageSecret corev1.Secret) field.ErrorList {
+	if _, ok := storageSecret.Data["endpoint_suffix"]; ok {
+		if _, ok := storageSecret.Data["endpoint"]; ok {
+			return field.ErrorList{field.Invalid(
+				path,
+				secretSpec,
+				"storage secret must not contain both the \"endpoint\" and the \"endpoint_suffix\" field",
+			)}
+		}
+		return nil
+	}
+	return validateEndpoint(secretSpec, path, storageSecret)
+}
+
+// AzureEndpointSuffixUsesHTTPS returns true if Tempo accesses an Azure endpoint suffix over HTTPS.
+// Tempo builds the URL of the storage account itself: it uses HTTPS for endpoint suffixes of the Azure
+// clouds (starting with "blob.core", the default is "blob.core.windows.net") and plain HTTP for
+// all other endpoint suffixes (e.g. Azurite).
+func AzureEndpointSuffixUsesHTTPS(endpointSuffix string) bool {
+	return endpointSuffix == "" || strings.HasPrefix(endpointSuffix, "blob.core")
+}
+
+// validateAzureEndpointScheme validates that the scheme of a custom Azure endpoint is the scheme used by Tempo,
+// and that the CA of the object storage is only configured for endpoints accessed over HTTPS.
+// Tempo does not accept the scheme of the endpoint, a mismatch would silently downgrade the connection to plain HTTP.
+func validateAzureEndpointScheme(storage ObjectStorageSpec, path *field.Path, storageSecret corev1.Secret) field.ErrorList {
+	endpointSuffix := string(storageSecret.Data["endpoint_suffix"])
+	if endpoint, ok := storageSecret.Data["endpoint"]; ok {
+		u, err := url.Parse(string(endpoint))
+		if err != nil {
+			// reported by validateEndpoint
+			return nil
+		}
+		endpointSuffix = u.Host + u.Path
+		if AzureEndpointSuffixUsesHTTPS(endpointSuffix) != (u.Scheme == "https") {
+			return field.ErrorList{field.Invalid(
+				path,
+				storage.Secret,
+				"\"endpoint\" field of storage secret must start with https:// if the host starts with \"blob.core\" and with http:// otherwise, because Tempo uses plain HTTP for all other endpoints",
+			)}
+		}
+	}
+
+	if storage.TLS.CA != "" && !AzureEndpointSuffixUsesHTTPS(endpointSuffix) {
+		return field.ErrorList{field.Invalid(
+			path,
+			storage.Secret,
+			"a CA is configured, but Tempo accesses Azure endpoints whose host does not start with \"blob.core\" over plain HTTP",
+		)}
+	}
+	return nil
+}
+
+// validateGCSEndpointScheme validates that the CA of the object storage is only configured
+// for GCS endpoints accessed over HTTPS.
+func validateGCSEndpointScheme(storage ObjectStorageSpec, path *field.Path, storageSecret corev1.Secret) field.ErrorList {
+	if storage.TLS.CA != "" && strings.HasPrefix(string(storageSecret.Data["endpoint"]), "http://") {
+		return field.ErrorList{field.Invalid(
+			path,
+			storage.Secret,
+			"a CA is configured, but the \"endpoint\" field of storage secret uses plain HTTP",
+		)}
+	}
+	return nil
+}
+
+func validateGCSSecret(secretSpec ObjectStorageSecretSpec, path *field.Path, storageSecret corev1.Secret) field.ErrorList {
 	var allErrs field.ErrorList
 	secretFields := []string{
 		"bucketname",
 		"key.json",
 	}
 
-	allErrs = append(allErrs, ensureNotEmpty(tempo, path, storageSecret, secretFields)...)
+	allErrs = append(allErrs, ensureNotEmpty(secretSpec, path, storageSecret, secretFields)...)
+	allErrs = append(allErrs, validateEndpoint(secretSpec, path, storageSecret)...)
 	return allErrs
 }
 
-func validateS3Secret(tempo TempoStack, path *field.Path, storageSecret corev1.Secret) field.ErrorList {
+// validateGCSTokenSecret validates a GCS storage secret used with GCP Workload Identity Federation.
+// The "key.json" field is optional, if it is missing GKE Workload Identity is used.
+func validateGCSTokenSecret(secretSpec ObjectStorageSecretSpec, path *field.Path, storageSecret corev1.Secret) field.ErrorList {
+	var allErrs field.ErrorList
+	secretFields := []string{
+		"bucketname",
+	}
+
+	allErrs = append(allErrs, ensureNotEmpty(secretSpec, path, storageSecret, secretFields)...)
+	allErrs = append(allErrs, validateEndpoint(secretSpec, path, storageSecret)...)
+	if credentialConfig, ok := storageSecret.Data["key.json"]; ok {
+		allErrs = append(allErrs, validateGCSCredentialConfig(secretSpec, path, credentialConfig)...)
+	}
+	return allErrs
+}
+
+// gcsCredentialConfig is the subset of a GCP workload identity federation credential configuration
+// (external account) validated by the operator.
+type gcsCredentialConfig struct {
+	Type             string `json:"type"`
+	Audience         string `json:"audience"`
+	CredentialSource struct {
+		File string `json:"file"`
+	} `json:"credential_source"`
+}
+
+// GCSCredentialSourceFile is the path of the projected service account token, which must be referenced
+// by the credential source of a GCP workload identity federation credential configuration.
+const GCSCredentialSourceFile = "/var/run/secrets/storage/serviceaccount/token" // nolint #nosec
+
+func validateGCSCredentialConfig(secretSpec ObjectStorageSecretSpec, path *field.Path, credentialConfig []byte) field.ErrorList {
+	cfg := gcsCredentialConfig{}
+	if err := json.Unmarshal(credentialConfig, &cfg); err != nil {
+		return field.ErrorList{field.Invalid(
+			path,
+			secretSpec,
+			"\"key.json\" field of storage secret must be a valid JSON document",
+		)}
+	}
+
+	var allErrs field.ErrorList
+	if cfg.Type != "external_account" {
+		allErrs = append(allErrs, field.Invalid(
+			path,
+			secretSpec,
+			"\"key.json\" field of storage secret must be a credential configuration of type \"external_account\"",
+		))
+	}
+	if cfg.Audience == "" {
+		allErrs = append(allErrs, field.Invalid(
+			path,
+			secretSpec,
+			"\"key.json\" field of storage secret must specify the audience of the workload identity provider",
+		))
+	}
+	if cfg.CredentialSource.File != GCSCredentialSourceFile {
+		allErrs = append(allErrs, field.Invalid(
+			path,
+			secretSpec,
+			fmt.Sprintf("\"key.json\" field of storage secret must use the credential source file %s", GCSCredentialSourceFile),
+		))
+	}
+	return allErrs
+}
+
+func validateS3Secret(secretSpec ObjectStorageSecretSpec, path *field.Path, storageSecret corev1.Secret) field.ErrorList {
 	var allErrs field.ErrorList
 	secretFields := []string{
 		"endpoint",
@@ -98,20 +406,98 @@ func validateS3Secret(tempo TempoStack, path *field.Path, storageSecret corev1.S
 		"access_key_secret",
 	}
 
-	allErrs = append(allErrs, ensureNotEmpty(tempo, path, storageSecret, secretFields)...)
+	allErrs = append(allErrs, ensureNotEmpty(secretSpec, path, storageSecret, secretFields)...)
+	allErrs = append(allErrs, validateEndpoint(secretSpec, path, storageSecret)...)
+	allErrs = append(allErrs, validateS3Options(secretSpec, path, storageSecret)...)
+	return allErrs
+}
+
+// validateS3TokenSecret validates a S3 storage secret used with short-lived credentials (AWS STS).
+// The endpoint is optional, it defaults to the regional AWS S3 endpoint.
+func validateS3TokenSecret(secretSpec ObjectStorageSecretSpec, path *field.Path, storageSecret corev1.Secret) field.ErrorList {
+	var allErrs field.ErrorList
+	secretFields := []string{
+		"bucket",
+		"region",
+		"role_arn",
+	}
+
+	allErrs = append(allErrs, ensureNotEmpty(secretSpec, path, storageSecret, secretFields)...)
+	allErrs = append(allErrs, validateEndpoint(secretSpec, path, storageSecret)...)
+	allErrs = append(allErrs, validateS3Options(secretSpec, path, storageSecret)...)
+	return allErrs
+}
+
+// validateS3Options validates the optional addressing fields of a S3 storage secret.
+func validateS3Options(secretSpec ObjectStorageSecretSpec, path *field.Path, storageSecret corev1.Secret) field.ErrorList {
+	var allErrs field.ErrorList
+	invalid := func(msg string) {
+		allErrs = append(allErrs, field.Invalid(path, secretSpec, msg))
+	}
+
+	// The S3 backend of Tempo 2.3 has no server-side encryption settings (sse), therefore the objects are
+	// encrypted with the default encryption of the bucket. The fields are rejected instead of silently ignored.
+	for _, key := range []string{"sse_type", "kms_key_id", "kms_encryption_context"} {
+		if _, ok := storageSecret.Data[key]; ok {
+			invalid(fmt.Sprintf("\"%s\" field of storage secret is not supported, because Tempo 2.3 has no server-side encryption settings for S3 (configure the default encryption of the bucket instead)", key))
+		}
+	}
 
+	if forcePathStyle, ok := storageSecret.Data["forcepathstyle"]; ok {
+		if _, err := strconv.ParseBool(string(forcePathStyle)); err != nil {
+			invalid("\"forcepathstyle\" field of storage secret must be a boolean")
+		}
+	}
+	return allErrs
+}
+
+// validateEndpoint validates the optional "endpoint" field of a storage secret.
+func validateEndpoint(secretSpec ObjectStorageSecretSpec, path *field.Path, storageSecret corev1.Secret) field.ErrorList {
 	if endpoint, ok := storageSecret.Data["endpoint"]; ok {
 		u, err := url.ParseRequestURI(string(endpoint))
 
 		// ParseRequestURI also accepts absolute paths, therefore we need to check if the URL scheme is set
 		if err != nil || u.Scheme == "" {
-			allErrs = append(allErrs, field.Invalid(
+			return field.ErrorList{field.Invalid(
 				path,
-				tempo.Spec.Storage.Secret,
+				secretSpec,
 				"\"endpoint\" field of storage secret must be a valid URL",
-			))
+			)}
 		}
 	}
+	return nil
+}
 
-	return allErrs
+// MonolithicObjectStorage returns the object storage configuration of a TempoMonolithic traces storage spec.
+// The second return value is false if the traces are not stored in object storage.
+func MonolithicObjectStorage(spec MonolithicTracesStorageSpec) (ObjectStorageSpec, bool) {
+	switch spec.Backend {
+	case MonolithicTracesStorageBackendS3:
+		if spec.S3 == nil {
+			return ObjectStorageSpec{}, false
+		}
+		return ObjectStorageSpec{
+			TLS:    spec.S3.TLS,
+			Secret: ObjectStorageSecretSpec{Type: ObjectStorageSecretS3, Name: spec.S3.Secret},
+			Prefix: spec.S3.Prefix,
+		}, true
+	case MonolithicTracesStorageBackendAzure:
+		if spec.Azure == nil {
+			return ObjectStorageSpec{}, false
+		}
+		return ObjectStorageSpec{
+			Secret: ObjectStorageSecretSpec{Type: ObjectStorageSecretAzure, Name: spec.Azure.Secret},
+			Prefix: spec.Azure.Prefix,
+		}, true
+	case MonolithicTracesStorageBackendGCS:
+		if spec.GCS == nil {
+			return ObjectStorageSpec{}, false
+		}
+		return ObjectStorageSpec{
+			Secret: ObjectStorageSecretSpec{Type: ObjectStorageSecretGCS, Name: spec.GCS.Secret},
+			Prefix: spec.GCS.Prefix,
+		}, true
+	default:
+		return ObjectStorageSpec{}, false
+	}
 }
@@ -0,0 +1,295 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TempoMonolithicSpec defines the desired state of TempoMonolithic.
+type TempoMonolithicSpec struct {
+	// ManagementState defines if the CR should be managed by the operator or not.
+	// Default is managed.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=Managed
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:Managed","urn:alm:descriptor:com.tectonic.ui:select:Unmanaged"},displayName="Management State"
+	ManagementState ManagementStateType `json:"managementState,omitempty"`
+
+	// Storage defines the storage configuration.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Storage"
+	Storage MonolithicStorageSpec `json:"storage,omitempty"`
+
+	// Ingestion defines the trace ingestion configuration.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Ingestion"
+	Ingestion *MonolithicIngestionSpec `json:"ingestion,omitempty"`
+
+	// JaegerUI defines the Jaeger UI configuration.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Jaeger UI"
+	JaegerUI *MonolithicJaegerUISpec `json:"jaegerui,omitempty"`
+
+	// Observability defines the observability configuration of the Tempo deployment.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Observability"
+	Observability *MonolithicObservabilitySpec `json:"observability,omitempty"`
+
+	// Resources defines the compute resource requirements of the Tempo container.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resources",xDescriptors="urn:alm:descriptor:com.tectonic.ui:resourceRequirements"
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// LogLevel defines the log level of the Tempo and tempo-query containers.
+	// Defaults to info.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Log Level",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:debug","urn:alm:descriptor:com.tectonic.ui:select:info","urn:alm:descriptor:com.tectonic.ui:select:warn","urn:alm:descriptor:com.tectonic.ui:select:error"}
+	LogLevel LogLevel `json:"logLevel,omitempty"`
+
+	// LogFormat defines the log format of Tempo.
+	// Defaults to logfmt.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Log Format",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:logfmt","urn:alm:descriptor:com.tectonic.ui:select:json"}
+	LogFormat LogFormat `json:"logFormat,omitempty"`
+}
+
+// MonolithicStorageSpec defines the storage for the Tempo deployment.
+type MonolithicStorageSpec struct {
+	// Traces defines the backend storage configuration for traces.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Traces"
+	Traces MonolithicTracesStorageSpec `json:"traces,omitempty"`
+}
+
+// MonolithicTracesStorageBackend defines the backend storage for traces.
+//
+// +kubebuilder:validation:Enum=memory;pv;s3;azure;gcs
+type MonolithicTracesStorageBackend string
+
+const (
+	// MonolithicTracesStorageBackendMemory defines storing traces in a tmpfs (in-memory filesystem).
+	MonolithicTracesStorageBackendMemory MonolithicTracesStorageBackend = "memory"
+	// MonolithicTracesStorageBackendPV defines storing traces in a Persistent Volume.
+	MonolithicTracesStorageBackendPV MonolithicTracesStorageBackend = "pv"
+	// MonolithicTracesStorageBackendS3 defines storing traces in Amazon S3 or a S3-compatible object storage.
+	MonolithicTracesStorageBackendS3 MonolithicTracesStorageBackend = "s3"
+	// MonolithicTracesStorageBackendAzure defines storing traces in Azure Storage.
+	MonolithicTracesStorageBackendAzure MonolithicTracesStorageBackend = "azure"
+	// MonolithicTracesStorageBackendGCS defines storing traces in Google Cloud Storage.
+	MonolithicTracesStorageBackendGCS MonolithicTracesStorageBackend = "gcs"
+)
+
+// MonolithicTracesStorageSpec defines the traces storage for the Tempo deployment.
+type MonolithicTracesStorageSpec struct {
+	// Backend defines the backend for storing traces.
+	// Default: memory.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=memory
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:memory","urn:alm:descriptor:com.tectonic.ui:select:pv","urn:alm:descriptor:com.tectonic.ui:select:s3","urn:alm:descriptor:com.tectonic.ui:select:azure","urn:alm:descriptor:com.tectonic.ui:select:gcs"},displayName="Storage Backend"
+	Backend MonolithicTracesStorageBackend `json:"backend,omitempty"`
+
+	// Size defines the size of the volume where traces are stored.
+	// For in-memory storage, this defines the size of the tmpfs volume.
+	// For persistent volume storage, this defines the size of the persistent volume.
+	// For object storage, this defines the size of the persistent volume containing the Write-Ahead Log (WAL) of Tempo.
+	// Default: 2Gi for memory, 10Gi for all other backends.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Size",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Size *resource.Quantity `json:"size,omitempty"`
+
+	// S3 defines the configuration for Amazon S3.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Amazon S3"
+	S3 *MonolithicTracesStorageS3Spec `json:"s3,omitempty"`
+
+	// Azure defines the configuration for Azure Storage.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Azure Storage"
+	Azure *MonolithicTracesObjectStorageSpec `json:"azure,omitempty"`
+
+	// GCS defines the configuration for Google Cloud Storage.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Google Cloud Storage"
+	GCS *MonolithicTracesObjectStorageSpec `json:"gcs,omitempty"`
+}
+
+// MonolithicTracesObjectStorageSpec defines object storage configuration.
+type MonolithicTracesObjectStorageSpec struct {
+	// Secret is the name of a Secret containing credentials for accessing object storage.
+	// It needs to be in the same namespace as the TempoMonolithic custom resource.
+	// The Secret has the same format as the storage secret of a TempoStack.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:io.kubernetes:Secret",displayName="Storage Secret"
+	Secret string `json:"secret"`
+
+	// Prefix is the path prefix of the objects written to the bucket or container.
+	// If not set, the "prefix" field of the storage secret is used.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Prefix"
+	Prefix string `json:"prefix,omitempty"`
+}
+
+// MonolithicTracesStorageS3Spec defines the Amazon S3 configuration.
+type MonolithicTracesStorageS3Spec struct {
+	MonolithicTracesObjectStorageSpec `json:",inline"`
+
+	// TLS configuration for reaching the object storage endpoint.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="TLS Config"
+	TLS ObjectStorageTLSSpec `json:"tls,omitempty"`
+}
+
+// MonolithicIngestionSpec defines the ingestion settings.
+type MonolithicIngestionSpec struct {
+	// OTLP defines the ingestion configuration for the OTLP protocol.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="OTLP"
+	OTLP *MonolithicIngestionOTLPSpec `json:"otlp,omitempty"`
+
+	// TLS defines the TLS configuration of the ingestion endpoints.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="TLS"
+	TLS ReceiversTLSSpec `json:"tls,omitempty"`
+}
+
+// MonolithicIngestionOTLPSpec defines the settings for OTLP ingestion.
+type MonolithicIngestionOTLPSpec struct {
+	// GRPC defines the OTLP/gRPC configuration.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="gRPC"
+	GRPC *MonolithicIngestionOTLPProtocolSpec `json:"grpc,omitempty"`
+
+	// HTTP defines the OTLP/HTTP configuration.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="HTTP"
+	HTTP *MonolithicIngestionOTLPProtocolSpec `json:"http,omitempty"`
+}
+
+// MonolithicIngestionOTLPProtocolSpec defines the settings for an OTLP ingestion protocol.
+type MonolithicIngestionOTLPProtocolSpec struct {
+	// Enabled defines if the protocol is enabled.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch",displayName="Enabled"
+	Enabled bool `json:"enabled"`
+}
+
+// MonolithicJaegerUISpec defines the settings for the Jaeger UI.
+type MonolithicJaegerUISpec struct {
+	// Enabled defines if the Jaeger UI should be enabled.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch",displayName="Enabled"
+	Enabled bool `json:"enabled"`
+
+	// Ingress defines the ingress configuration for the Jaeger UI.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Jaeger UI Ingress Settings"
+	Ingress IngressSpec `json:"ingress,omitempty"`
+}
+
+// MonolithicObservabilitySpec defines the observability configuration of the Tempo deployment.
+type MonolithicObservabilitySpec struct {
+	// Metrics defines the metrics configuration of the Tempo deployment.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Metrics Config"
+	Metrics MonolithicObservabilityMetricsSpec `json:"metrics,omitempty"`
+}
+
+// MonolithicObservabilityMetricsSpec defines the metrics configuration of the Tempo deployment.
+type MonolithicObservabilityMetricsSpec struct {
+	// CreateServiceMonitors specifies if a ServiceMonitor should be created for the Tempo deployment.
+	// The prometheusOperator feature gate must be enabled.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch",displayName="Create ServiceMonitors"
+	CreateServiceMonitors bool `json:"createServiceMonitors,omitempty"`
+}
+
+// TempoMonolithicStatus defines the observed state of TempoMonolithic.
+type TempoMonolithicStatus struct {
+	// Conditions of the Tempo deployment health.
+	//
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:io.kubernetes.conditions"
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+//+kubebuilder:printcolumn:name="Storage",type="string",JSONPath=".spec.storage.traces.backend",description="Storage Backend"
+//+kubebuilder:printcolumn:name="Management",type="string",JSONPath=".spec.managementState",description="Management State"
+
+// TempoMonolithic is the spec for Tempo deployments running all components in a single pod.
+//
+// +operator-sdk:csv:customresourcedefinitions:displayName="TempoMonolithic",resources={{ConfigMap,v1},{Service,v1},{StatefulSet,v1},{Ingress,v1},{Route,v1}}
+type TempoMonolithic struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TempoMonolithicSpec   `json:"spec,omitempty"`
+	Status TempoMonolithicStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TempoMonolithicList contains a list of TempoMonolithic.
+type TempoMonolithicList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TempoMonolithic `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TempoMonolithic{}, &TempoMonolithicList{})
+}
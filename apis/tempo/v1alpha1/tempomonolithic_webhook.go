@@ -0,0 +1,289 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/grafana/tempo-operator/apis/config/v1alpha1"
+)
+
+var (
+	twoGBQuantity = resource.MustParse("2Gi")
+)
+
+// SetupWebhookWithManager initializes the webhook.
+func (r *TempoMonolithic) SetupWebhookWithManager(mgr ctrl.Manager, ctrlConfig v1alpha1.ProjectConfig) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		WithDefaulter(&monolithicDefaulter{}).
+		WithValidator(&monolithicValidator{client: mgr.GetClient(), ctrlConfig: ctrlConfig}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-tempo-grafana-com-v1alpha1-tempomonolithic,mutating=true,failurePolicy=fail,sideEffects=None,groups=tempo.grafana.com,resources=tempomonolithics,verbs=create;update,versions=v1alpha1,name=mtempomonolithic.tempo.grafana.com,admissionReviewVersions=v1
+
+type monolithicDefaulter struct{}
+
+// Default applies default values to a TempoMonolithic object.
+func (d *monolithicDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	r, ok := obj.(*TempoMonolithic)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected a TempoMonolithic object but got %T", obj))
+	}
+
+	log := ctrl.LoggerFrom(ctx).WithName("tempomonolithic-webhook")
+	log.V(1).Info("running defaulter webhook", "name", r.Name)
+
+	if r.Labels == nil {
+		r.Labels = map[string]string{}
+	}
+	if r.Labels["app.kubernetes.io/managed-by"] == "" {
+		r.Labels["app.kubernetes.io/managed-by"] = "tempo-operator"
+	}
+
+	if r.Spec.ManagementState == "" {
+		r.Spec.ManagementState = ManagementStateManaged
+	}
+
+	if r.Spec.Storage.Traces.Backend == "" {
+		r.Spec.Storage.Traces.Backend = MonolithicTracesStorageBackendMemory
+	}
+
+	if r.Spec.Storage.Traces.Size == nil {
+		if r.Spec.Storage.Traces.Backend == MonolithicTracesStorageBackendMemory {
+			r.Spec.Storage.Traces.Size = ptr.To(twoGBQuantity.DeepCopy())
+		} else {
+			r.Spec.Storage.Traces.Size = ptr.To(tenGBQuantity.DeepCopy())
+		}
+	}
+
+	// Enable OTLP/gRPC and OTLP/HTTP ingestion by default.
+	if r.Spec.Ingestion == nil {
+		r.Spec.Ingestion = &MonolithicIngestionSpec{}
+	}
+	if r.Spec.Ingestion.OTLP == nil {
+		r.Spec.Ingestion.OTLP = &MonolithicIngestionOTLPSpec{}
+	}
+	if r.Spec.Ingestion.OTLP.GRPC == nil {
+		r.Spec.Ingestion.OTLP.GRPC = &MonolithicIngestionOTLPProtocolSpec{Enabled: true}
+	}
+	if r.Spec.Ingestion.OTLP.HTTP == nil {
+		r.Spec.Ingestion.OTLP.HTTP = &MonolithicIngestionOTLPProtocolSpec{Enabled: true}
+	}
+
+	// Terminate TLS of the Jaeger UI Route on the Edge by default
+	if r.Spec.JaegerUI != nil && r.Spec.JaegerUI.Ingress.Type == IngressTypeRoute && r.Spec.JaegerUI.Ingress.Route.Termination == "" {
+		r.Spec.JaegerUI.Ingress.Route.Termination = defaultUITLSTermination
+	}
+
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-tempo-grafana-com-v1alpha1-tempomonolithic,mutating=false,failurePolicy=fail,sideEffects=None,groups=tempo.grafana.com,resources=tempomonolithics,verbs=create;update,versions=v1alpha1,name=vtempomonolithic.tempo.grafana.com,admissionReviewVersions=v1
+
+type monolithicValidator struct {
+	client     client.Client
+	ctrlConfig v1alpha1.ProjectConfig
+}
+
+func (v *monolithicValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return v.validate(ctx, nil, obj)
+}
+
+func (v *monolithicValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	return v.validate(ctx, oldObj, newObj)
+}
+
+func (v *monolithicValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *monolithicValidator) validateName(tempo TempoMonolithic) field.ErrorList {
+	// The name is used as a label value for app.kubernetes.io/instance
+	if len(tempo.Name) > maxLabelLength {
+		return field.ErrorList{
+			field.Invalid(
+				field.NewPath("metadata").Child("name"),
+				tempo.Name,
+				fmt.Sprintf("must be no more than %d characters", maxLabelLength),
+			)}
+	}
+	return nil
+}
+
+func (v *monolithicValidator) validateStorage(ctx context.Context, tempo TempoMonolithic) (admission.Warnings, field.ErrorList) {
+	traces := tempo.Spec.Storage.Traces
+	backend := string(traces.Backend)
+	path := field.NewPath("spec").Child("storage").Child("traces")
+
+	if traces.Size != nil && traces.Size.Cmp(zeroQuantity) <= 0 {
+		return nil, field.ErrorList{field.Invalid(path.Child("size"), traces.Size.String(), "size must be greater than zero")}
+	}
+
+	//exhaustive:ignore
+	switch traces.Backend {
+	case MonolithicTracesStorageBackendMemory, MonolithicTracesStorageBackendPV:
+		return nil, nil
+	}
+
+	objectStorage, ok := MonolithicObjectStorage(traces)
+	if !ok {
+		return nil, field.ErrorList{field.Required(path.Child(backend), fmt.Sprintf("please configure .spec.storage.traces.%s", backend))}
+	}
+
+	secretPath := path.Child(backend).Child("secret")
+	storageSecret := &corev1.Secret{}
+	err := v.client.Get(ctx, types.NamespacedName{Namespace: tempo.Namespace, Name: objectStorage.Secret.Name}, storageSecret)
+	if err != nil {
+		// Do not fail the validation here, the user can create the storage secret later.
+		// The operator will remain in a ConfigurationError status condition until the storage secret is set.
+		return admission.Warnings{fmt.Sprintf("Secret '%s' does not exist", objectStorage.Secret.Name)}, field.ErrorList{}
+	}
+
+	errs := ValidateObjectStorageSecret(secretPath, objectStorage, *storageSecret)
+	if objectStorage.TLS.CA != "" {
+		caConfigMap := &corev1.ConfigMap{}
+		err := v.client.Get(ctx, types.NamespacedName{Namespace: tempo.Namespace, Name: objectStorage.TLS.CA}, caConfigMap)
+		if err != nil {
+			return admission.Warnings{fmt.Sprintf("ConfigMap '%s' does not exist", objectStorage.TLS.CA)}, errs
+		}
+		errs = append(errs, ValidateStorageCAConfigMap(*caConfigMap)...)
+	}
+	return admission.Warnings{}, errs
+}
+
+// validateStorageLocation rejects a TempoMonolithic which would write to the same bucket and prefix as another TempoStack or TempoMonolithic.
+// The error references the prefix if it is set in the CR, because the bucket is only set in the storage secret.
+func (v *monolithicValidator) validateStorageLocation(ctx context.Context, tempo TempoMonolithic) field.ErrorList {
+	objectStorage, ok := MonolithicObjectStorage(tempo.Spec.Storage.Traces)
+	if !ok {
+		return nil
+	}
+
+	inUse, err := storageLocationInUse(ctx, v.client, storageLocation{
+		kind:    "TempoMonolithic",
+		key:     types.NamespacedName{Namespace: tempo.Namespace, Name: tempo.Name},
+		storage: objectStorage,
+	})
+	path := field.NewPath("spec").Child("storage").Child("traces").Child(string(tempo.Spec.Storage.Traces.Backend))
+	if err != nil {
+		return field.ErrorList{field.InternalError(path, err)}
+	}
+	if inUse && objectStorage.Prefix != "" {
+		return field.ErrorList{field.Invalid(path.Child("prefix"), objectStorage.Prefix, storageLocationInUseMessage)}
+	}
+	if inUse {
+		return field.ErrorList{field.Invalid(path.Child("secret"), objectStorage.Secret.Name, storageLocationInUseMessage)}
+	}
+	return nil
+}
+
+func (v *monolithicValidator) validateIngestion(tempo TempoMonolithic) field.ErrorList {
+	if tempo.Spec.Ingestion == nil {
+		return nil
+	}
+
+	spec := tempo.Spec.Ingestion.TLS
+	if spec.Enabled && spec.Cert == "" {
+		return field.ErrorList{
+			field.Invalid(
+				field.NewPath("spec").Child("ingestion").Child("tls").Child("certName"),
+				spec.Cert,
+				"need to specify cert secret name",
+			)}
+	}
+	return nil
+}
+
+func (v *monolithicValidator) validateJaegerUI(tempo TempoMonolithic) field.ErrorList {
+	if tempo.Spec.JaegerUI == nil {
+		return nil
+	}
+
+	path := field.NewPath("spec").Child("jaegerui").Child("ingress").Child("type")
+	if tempo.Spec.JaegerUI.Ingress.Type != IngressTypeNone && !tempo.Spec.JaegerUI.Enabled {
+		return field.ErrorList{field.Invalid(
+			path,
+			tempo.Spec.JaegerUI.Ingress.Type,
+			"Ingress cannot be enabled if the Jaeger UI is disabled",
+		)}
+	}
+
+	if tempo.Spec.JaegerUI.Ingress.Type == IngressTypeRoute && !v.ctrlConfig.Gates.OpenShift.OpenShiftRoute {
+		return field.ErrorList{field.Invalid(
+			path,
+			tempo.Spec.JaegerUI.Ingress.Type,
+			"Please enable the featureGates.openshift.openshiftRoute feature gate to use Routes",
+		)}
+	}
+
+	return nil
+}
+
+func (v *monolithicValidator) validateObservability(tempo TempoMonolithic) field.ErrorList {
+	if tempo.Spec.Observability == nil {
+		return nil
+	}
+
+	metricsBase := field.NewPath("spec").Child("observability").Child("metrics")
+	if tempo.Spec.Observability.Metrics.CreateServiceMonitors && !v.ctrlConfig.Gates.PrometheusOperator {
+		return field.ErrorList{
+			field.Invalid(metricsBase.Child("createServiceMonitors"), tempo.Spec.Observability.Metrics.CreateServiceMonitors,
+				"the prometheusOperator feature gate must be enabled to create a ServiceMonitor for Tempo",
+			)}
+	}
+
+	return nil
+}
+
+func (v *monolithicValidator) validate(ctx context.Context, oldObj, obj runtime.Object) (admission.Warnings, error) {
+	tempo, ok := obj.(*TempoMonolithic)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a TempoMonolithic object but got %T", obj))
+	}
+
+	log := ctrl.LoggerFrom(ctx).WithName("tempomonolithic-webhook")
+	log.V(1).Info("running validating webhook", "name", tempo.Name)
+
+	allErrors := field.ErrorList{}
+	allErrors = append(allErrors, v.validateName(*tempo)...)
+
+	warnings, errors := v.validateStorage(ctx, *tempo)
+	allErrors = append(allErrors, errors...)
+
+	if oldObj == nil {
+		allErrors = append(allErrors, v.validateStorageLocation(ctx, *tempo)...)
+	} else {
+		oldTempo, ok := oldObj.(*TempoMonolithic)
+		if !ok {
+			return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a TempoMonolithic object but got %T", oldObj))
+		}
+
+		// Listing all instances is expensive, therefore the storage location is only validated if it changed.
+		oldStorage, _ := MonolithicObjectStorage(oldTempo.Spec.Storage.Traces)
+		newStorage, _ := MonolithicObjectStorage(tempo.Spec.Storage.Traces)
+		if oldStorage.Secret != newStorage.Secret || oldStorage.Prefix != newStorage.Prefix {
+			allErrors = append(allErrors, v.validateStorageLocation(ctx, *tempo)...)
+		}
+	}
+
+	allErrors = append(allErrors, v.validateIngestion(*tempo)...)
+	allErrors = append(allErrors, v.validateJaegerUI(*tempo)...)
+	allErrors = append(allErrors, v.validateObservability(*tempo)...)
+
+	if len(allErrors) == 0 {
+		return warnings, nil
+	}
+	return warnings, apierrors.NewInvalid(tempo.GroupVersionKind().GroupKind(), tempo.Name, allErrors)
+}
@@ -0,0 +1,450 @@
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/grafana/tempo-operator/apis/config/v1alpha1"
+)
+
+func TestMonolithicDefault(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *TempoMonolithic
+		expected *TempoMonolithic
+	}{
+		{
+			name: "empty spec, set memory backend and enable OTLP",
+			input: &TempoMonolithic{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "sample",
+					Namespace: "default",
+				},
+			},
+			expected: &TempoMonolithic{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "sample",
+					Namespace: "default",
+					Labels: map[string]string{
+						"app.kubernetes.io/managed-by": "tempo-operator",
+					},
+				},
+				Spec: TempoMonolithicSpec{
+					ManagementState: ManagementStateManaged,
+					Storage: MonolithicStorageSpec{
+						Traces: MonolithicTracesStorageSpec{
+							Backend: MonolithicTracesStorageBackendMemory,
+							Size:    ptr.To(resource.MustParse("2Gi")),
+						},
+					},
+					Ingestion: &MonolithicIngestionSpec{
+						OTLP: &MonolithicIngestionOTLPSpec{
+							GRPC: &MonolithicIngestionOTLPProtocolSpec{
+								Enabled: true,
+							},
+							HTTP: &MonolithicIngestionOTLPProtocolSpec{
+								Enabled: true,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "pv backend, do not enable disabled OTLP/HTTP, set route termination",
+			input: &TempoMonolithic{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "sample",
+					Namespace: "default",
+				},
+				Spec: TempoMonolithicSpec{
+					Storage: MonolithicStorageSpec{
+						Traces: MonolithicTracesStorageSpec{
+							Backend: MonolithicTracesStorageBackendPV,
+						},
+					},
+					Ingestion: &MonolithicIngestionSpec{
+						OTLP: &MonolithicIngestionOTLPSpec{
+							HTTP: &MonolithicIngestionOTLPProtocolSpec{
+								Enabled: false,
+							},
+						},
+					},
+					JaegerUI: &MonolithicJaegerUISpec{
+						Enabled: true,
+						Ingress: IngressSpec{
+							Type: IngressTypeRoute,
+						},
+					},
+				},
+			},
+			expected: &TempoMonolithic{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "sample",
+					Namespace: "default",
+					Labels: map[string]string{
+						"app.kubernetes.io/managed-by": "tempo-operator",
+					},
+				},
+				Spec: TempoMonolithicSpec{
+					ManagementState: ManagementStateManaged,
+					Storage: MonolithicStorageSpec{
+						Traces: MonolithicTracesStorageSpec{
+							Backend: MonolithicTracesStorageBackendPV,
+							Size:    ptr.To(resource.MustParse("10Gi")),
+						},
+					},
+					Ingestion: &MonolithicIngestionSpec{
+						OTLP: &MonolithicIngestionOTLPSpec{
+							GRPC: &MonolithicIngestionOTLPProtocolSpec{
+								Enabled: true,
+							},
+							HTTP: &MonolithicIngestionOTLPProtocolSpec{
+								Enabled: false,
+							},
+						},
+					},
+					JaegerUI: &MonolithicJaegerUISpec{
+						Enabled: true,
+						Ingress: IngressSpec{
+							Type: IngressTypeRoute,
+							Route: RouteSpec{
+								Termination: TLSRouteTerminationTypeEdge,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defaulter := &monolithicDefaulter{}
+			err := defaulter.Default(context.Background(), test.input)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, test.input)
+		})
+	}
+}
+
+func TestMonolithicValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctrlConfig v1alpha1.ProjectConfig
+		input      TempoMonolithic
+		warnings   admission.Warnings
+		errors     field.ErrorList
+	}{
+		{
+			name: "valid memory backend",
+			input: TempoMonolithic{
+				Spec: TempoMonolithicSpec{
+					Storage: MonolithicStorageSpec{
+						Traces: MonolithicTracesStorageSpec{
+							Backend: MonolithicTracesStorageBackendMemory,
+							Size:    ptr.To(resource.MustParse("1Gi")),
+						},
+					},
+				},
+			},
+			errors: field.ErrorList{},
+		},
+		{
+			name: "invalid storage size",
+			input: TempoMonolithic{
+				Spec: TempoMonolithicSpec{
+					Storage: MonolithicStorageSpec{
+						Traces: MonolithicTracesStorageSpec{
+							Backend: MonolithicTracesStorageBackendPV,
+							Size:    ptr.To(resource.MustParse("0")),
+						},
+					},
+				},
+			},
+			errors: field.ErrorList{
+				field.Invalid(field.NewPath("spec").Child("storage").Child("traces").Child("size"), "0", "size must be greater than zero"),
+			},
+		},
+		{
+			name: "missing S3 configuration",
+			input: TempoMonolithic{
+				Spec: TempoMonolithicSpec{
+					Storage: MonolithicStorageSpec{
+						Traces: MonolithicTracesStorageSpec{
+							Backend: MonolithicTracesStorageBackendS3,
+						},
+					},
+				},
+			},
+			errors: field.ErrorList{
+				field.Required(field.NewPath("spec").Child("storage").Child("traces").Child("s3"), "please configure .spec.storage.traces.s3"),
+			},
+		},
+		{
+			name: "missing storage secret",
+			input: TempoMonolithic{
+				Spec: TempoMonolithicSpec{
+					Storage: MonolithicStorageSpec{
+						Traces: MonolithicTracesStorageSpec{
+							Backend: MonolithicTracesStorageBackendS3,
+							S3: &MonolithicTracesStorageS3Spec{
+								MonolithicTracesObjectStorageSpec: MonolithicTracesObjectStorageSpec{
+									Secret: "storage-secret",
+								},
+							},
+						},
+					},
+				},
+			},
+			warnings: admission.Warnings{"Secret 'storage-secret' does not exist"},
+			errors:   field.ErrorList{},
+		},
+		{
+			name: "receiver TLS without certificate",
+			input: TempoMonolithic{
+				Spec: TempoMonolithicSpec{
+					Ingestion: &MonolithicIngestionSpec{
+						TLS: ReceiversTLSSpec{
+							Enabled: true,
+						},
+					},
+				},
+			},
+			errors: field.ErrorList{
+				field.Invalid(field.NewPath("spec").Child("ingestion").Child("tls").Child("certName"), "", "need to specify cert secret name"),
+			},
+		},
+		{
+			name: "ingress enabled but Jaeger UI disabled",
+			input: TempoMonolithic{
+				Spec: TempoMonolithicSpec{
+					JaegerUI: &MonolithicJaegerUISpec{
+						Enabled: false,
+						Ingress: IngressSpec{
+							Type: IngressTypeIngress,
+						},
+					},
+				},
+			},
+			errors: field.ErrorList{
+				field.Invalid(field.NewPath("spec").Child("jaegerui").Child("ingress").Child("type"), IngressTypeIngress, "Ingress cannot be enabled if the Jaeger UI is disabled"),
+			},
+		},
+		{
+			name: "route without feature gate",
+			input: TempoMonolithic{
+				Spec: TempoMonolithicSpec{
+					JaegerUI: &MonolithicJaegerUISpec{
+						Enabled: true,
+						Ingress: IngressSpec{
+							Type: IngressTypeRoute,
+						},
+					},
+				},
+			},
+			errors: field.ErrorList{
+				field.Invalid(field.NewPath("spec").Child("jaegerui").Child("ingress").Child("type"), IngressTypeRoute, "Please enable the featureGates.openshift.openshiftRoute feature gate to use Routes"),
+			},
+		},
+		{
+			name: "route with feature gate",
+			ctrlConfig: v1alpha1.ProjectConfig{
+				Gates: v1alpha1.FeatureGates{
+					OpenShift: v1alpha1.OpenShiftFeatureGates{
+						OpenShiftRoute: true,
+					},
+				},
+			},
+			input: TempoMonolithic{
+				Spec: TempoMonolithicSpec{
+					JaegerUI: &MonolithicJaegerUISpec{
+						Enabled: true,
+						Ingress: IngressSpec{
+							Type: IngressTypeRoute,
+						},
+					},
+				},
+			},
+			errors: field.ErrorList{},
+		},
+		{
+			name: "ServiceMonitors without feature gate",
+			input: TempoMonolithic{
+				Spec: TempoMonolithicSpec{
+					Observability: &MonolithicObservabilitySpec{
+						Metrics: MonolithicObservabilityMetricsSpec{
+							CreateServiceMonitors: true,
+						},
+					},
+				},
+			},
+			errors: field.ErrorList{
+				field.Invalid(field.NewPath("spec").Child("observability").Child("metrics").Child("createServiceMonitors"), true, "the prometheusOperator feature gate must be enabled to create a ServiceMonitor for Tempo"),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// The mutating webhook always runs before the validating webhook
+			err := (&monolithicDefaulter{}).Default(context.Background(), &test.input)
+			require.NoError(t, err)
+
+			v := &monolithicValidator{client: &k8sFake{}, ctrlConfig: test.ctrlConfig}
+
+			warnings, errs := v.validateStorage(context.Background(), test.input)
+			errs = append(errs, v.validateIngestion(test.input)...)
+			errs = append(errs, v.validateJaegerUI(test.input)...)
+			errs = append(errs, v.validateObservability(test.input)...)
+			if test.warnings == nil {
+				assert.Empty(t, warnings)
+			} else {
+				assert.Equal(t, test.warnings, warnings)
+			}
+			assert.ElementsMatch(t, test.errors, errs)
+		})
+	}
+}
+
+func TestMonolithicValidateStorageLocation(t *testing.T) {
+	newMonolithic := func(namespace, secret string) TempoMonolithic {
+		return TempoMonolithic{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "sample"},
+			Spec: TempoMonolithicSpec{
+				Storage: MonolithicStorageSpec{
+					Traces: MonolithicTracesStorageSpec{
+						Backend: MonolithicTracesStorageBackendS3,
+						S3: &MonolithicTracesStorageS3Spec{
+							MonolithicTracesObjectStorageSpec: MonolithicTracesObjectStorageSpec{Secret: secret},
+						},
+					},
+				},
+			},
+		}
+	}
+	newSecret := func(namespace, name, bucket, prefix string) corev1.Secret {
+		return corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Data: map[string][]byte{
+				"endpoint": []byte("http://minio:9000"),
+				"bucket":   []byte(bucket),
+				"prefix":   []byte(prefix),
+			},
+		}
+	}
+
+	existing := newMonolithic("team-a", "storage")
+	existingStack := TempoStack{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-b", Name: "sample"},
+		Spec: TempoStackSpec{
+			Storage: ObjectStorageSpec{
+				Secret: ObjectStorageSecretSpec{Type: ObjectStorageSecretS3, Name: "storage"},
+			},
+		},
+	}
+	secrets := []corev1.Secret{
+		newSecret("team-a", "storage", "tempo-a", "team-a"),
+		newSecret("team-b", "storage", "tempo-b", ""),
+		newSecret("team-c", "same-prefix", "tempo-a", "team-a"),
+		newSecret("team-c", "other-prefix", "tempo-a", "team-c"),
+		newSecret("team-c", "tempostack-bucket", "tempo-b", "team-c"),
+	}
+	path := field.NewPath("spec", "storage", "traces", "s3", "secret")
+
+	tests := []struct {
+		name     string
+		input    TempoMonolithic
+		expected field.ErrorList
+	}{
+		{
+			name:  "in-memory storage",
+			input: TempoMonolithic{Spec: TempoMonolithicSpec{Storage: MonolithicStorageSpec{Traces: MonolithicTracesStorageSpec{Backend: MonolithicTracesStorageBackendMemory}}}},
+		},
+		{
+			name:  "shared bucket with other prefix",
+			input: newMonolithic("team-c", "other-prefix"),
+		},
+		{
+			name:  "update of existing instance",
+			input: existing,
+		},
+		{
+			name:     "shared bucket with same prefix",
+			input:    newMonolithic("team-c", "same-prefix"),
+			expected: field.ErrorList{field.Invalid(path, "same-prefix", storageLocationInUseMessage)},
+		},
+		{
+			name:     "bucket of TempoStack without prefix",
+			input:    newMonolithic("team-c", "tempostack-bucket"),
+			expected: field.ErrorList{field.Invalid(path, "tempostack-bucket", storageLocationInUseMessage)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			v := &monolithicValidator{
+				client: &storageLocationFake{
+					tempostacks: []TempoStack{existingStack},
+					monolithics: []TempoMonolithic{existing},
+					secrets:     secrets,
+				},
+			}
+			errs := v.validateStorageLocation(context.Background(), test.input)
+			assert.Equal(t, test.expected, errs)
+		})
+	}
+}
+
+func TestMonolithicValidateStorageLocationUpdate(t *testing.T) {
+	newMonolithic := func(namespace, prefix string) *TempoMonolithic {
+		return &TempoMonolithic{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "sample"},
+			Spec: TempoMonolithicSpec{
+				Storage: MonolithicStorageSpec{
+					Traces: MonolithicTracesStorageSpec{
+						Backend: MonolithicTracesStorageBackendS3,
+						S3: &MonolithicTracesStorageS3Spec{
+							MonolithicTracesObjectStorageSpec: MonolithicTracesObjectStorageSpec{Secret: "storage", Prefix: prefix},
+						},
+					},
+				},
+			},
+		}
+	}
+	newSecret := func(namespace string) corev1.Secret {
+		return corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "storage"},
+			Data: map[string][]byte{
+				"endpoint":          []byte("http://minio:9000"),
+				"bucket":            []byte("tempo"),
+				"access_key_id":     []byte("id"),
+				"access_key_secret": []byte("secret"),
+			},
+		}
+	}
+
+	v := &monolithicValidator{
+		client: &storageLocationFake{
+			monolithics: []TempoMonolithic{*newMonolithic("team-a", "team-a"), *newMonolithic("team-b", "team-b")},
+			secrets:     []corev1.Secret{newSecret("team-a"), newSecret("team-b")},
+		},
+	}
+
+	_, err := v.validate(context.Background(), newMonolithic("team-b", "team-b"), newMonolithic("team-b", "team-b/traces"))
+	assert.NoError(t, err)
+
+	// only the prefix changes, to the prefix of another instance in the same bucket
+	_, err = v.validate(context.Background(), newMonolithic("team-b", "team-b"), newMonolithic("team-b", "team-a"))
+	assert.ErrorContains(t, err, storageLocationInUseMessage)
+	assert.ErrorContains(t, err, "spec.storage.traces.s3.prefix")
+}
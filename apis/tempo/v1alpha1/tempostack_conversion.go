@@ -0,0 +1,199 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1beta1"
+)
+
+// maxSearchBytesPerTraceAnnotation keeps the MaxSearchBytesPerTrace query limits,
+// which do not exist in v1beta1, when a TempoStack is converted to v1beta1.
+const maxSearchBytesPerTraceAnnotation = "tempo.grafana.com/v1alpha1-max-search-bytes-per-trace"
+
+// ConvertTo converts this TempoStack to the Hub version (v1beta1).
+//
+// Both versions share the same schema, except for:
+//   - the component settings of the distributor, gateway, query-frontend, metrics-generator and
+//     managed cache are inlined in v1beta1, instead of being nested under the "component" key
+//   - the tracing config fields are camelCase in v1beta1 (samplingFraction, jaegerAgentEndpoint)
+//   - the resources of the managed cache are part of its inlined component settings in v1beta1
+//   - the deprecated MaxSearchBytesPerTrace query limit and TempoQueryVersion status field are removed in v1beta1
+//
+// The common fields are converted by serializing the object, the differences are converted explicitly.
+// The MaxSearchBytesPerTrace query limits are kept in the maxSearchBytesPerTraceAnnotation of the
+// v1beta1 object, so that converting the object back to v1alpha1 restores them.
+func (src *TempoStack) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1beta1.TempoStack)
+	if !ok {
+		return fmt.Errorf("expected a v1beta1.TempoStack but got %T", dstRaw)
+	}
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = v1beta1.TempoStackSpec{}
+	dst.Status = v1beta1.TempoStackStatus{}
+	if err := convertJSON(src.Spec, &dst.Spec); err != nil {
+		return fmt.Errorf("failed to convert TempoStack spec: %w", err)
+	}
+	if err := convertJSON(src.Status, &dst.Status); err != nil {
+		return fmt.Errorf("failed to convert TempoStack status: %w", err)
+	}
+
+	template := src.Spec.Template
+	components := []struct {
+		src TempoComponentSpec
+		dst *v1beta1.TempoComponentSpec
+	}{
+		{template.Distributor.TempoComponentSpec, &dst.Spec.Template.Distributor.TempoComponentSpec},
+		{template.Gateway.TempoComponentSpec, &dst.Spec.Template.Gateway.TempoComponentSpec},
+		{template.QueryFrontend.TempoComponentSpec, &dst.Spec.Template.QueryFrontend.TempoComponentSpec},
+		{template.MetricsGenerator.TempoComponentSpec, &dst.Spec.Template.MetricsGenerator.TempoComponentSpec},
+		{src.Spec.Cache.Managed.TempoComponentSpec, &dst.Spec.Cache.Managed.TempoComponentSpec},
+	}
+	for _, component := range components {
+		if err := convertJSON(component.src, component.dst); err != nil {
+			return fmt.Errorf("failed to convert component spec: %w", err)
+		}
+	}
+	dst.Spec.Cache.Managed.Resources = nil
+	if !equality.Semantic.DeepEqual(src.Spec.Cache.Managed.Resources, corev1.ResourceRequirements{}) {
+		dst.Spec.Cache.Managed.Resources = src.Spec.Cache.Managed.Resources.DeepCopy()
+	}
+
+	dst.Spec.Observability.Tracing.SamplingFraction = src.Spec.Observability.Tracing.SamplingFraction
+	dst.Spec.Observability.Tracing.JaegerAgentEndpoint = src.Spec.Observability.Tracing.JaegerAgentEndpoint
+
+	if err := saveMaxSearchBytesPerTrace(src.Spec.LimitSpec, &dst.ObjectMeta); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (dst *TempoStack) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1beta1.TempoStack)
+	if !ok {
+		return fmt.Errorf("expected a v1beta1.TempoStack but got %T", srcRaw)
+	}
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = TempoStackSpec{}
+	dst.Status = TempoStackStatus{}
+	if err := convertJSON(src.Spec, &dst.Spec); err != nil {
+		return fmt.Errorf("failed to convert TempoStack spec: %w", err)
+	}
+	if err := convertJSON(src.Status, &dst.Status); err != nil {
+		return fmt.Errorf("failed to convert TempoStack status: %w", err)
+	}
+
+	template := src.Spec.Template
+	components := []struct {
+		src v1beta1.TempoComponentSpec
+		dst *TempoComponentSpec
+	}{
+		{template.Distributor.TempoComponentSpec, &dst.Spec.Template.Distributor.TempoComponentSpec},
+		{template.Gateway.TempoComponentSpec, &dst.Spec.Template.Gateway.TempoComponentSpec},
+		{template.QueryFrontend.TempoComponentSpec, &dst.Spec.Template.QueryFrontend.TempoComponentSpec},
+		{template.MetricsGenerator.TempoComponentSpec, &dst.Spec.Template.MetricsGenerator.TempoComponentSpec},
+		{src.Spec.Cache.Managed.TempoComponentSpec, &dst.Spec.Cache.Managed.TempoComponentSpec},
+	}
+	for _, component := range components {
+		if err := convertJSON(component.src, component.dst); err != nil {
+			return fmt.Errorf("failed to convert component spec: %w", err)
+		}
+	}
+	dst.Spec.Cache.Managed.TempoComponentSpec.Resources = nil
+	dst.Spec.Cache.Managed.Resources = corev1.ResourceRequirements{}
+	if src.Spec.Cache.Managed.Resources != nil {
+		dst.Spec.Cache.Managed.Resources = *src.Spec.Cache.Managed.Resources.DeepCopy()
+	}
+
+	dst.Spec.Observability.Tracing.SamplingFraction = src.Spec.Observability.Tracing.SamplingFraction
+	dst.Spec.Observability.Tracing.JaegerAgentEndpoint = src.Spec.Observability.Tracing.JaegerAgentEndpoint
+
+	if err := restoreMaxSearchBytesPerTrace(&dst.ObjectMeta, &dst.Spec.LimitSpec); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// maxSearchBytesPerTraceLimits contains the MaxSearchBytesPerTrace query limits,
+// which are stored in the maxSearchBytesPerTraceAnnotation of a v1beta1 TempoStack.
+type maxSearchBytesPerTraceLimits struct {
+	Global    *int            `json:"global,omitempty"`
+	PerTenant map[string]*int `json:"perTenant,omitempty"`
+}
+
+// saveMaxSearchBytesPerTrace stores the MaxSearchBytesPerTrace query limits in an annotation.
+func saveMaxSearchBytesPerTrace(limits LimitSpec, meta *metav1.ObjectMeta) error {
+	saved := maxSearchBytesPerTraceLimits{
+		Global: limits.Global.Query.MaxSearchBytesPerTrace,
+	}
+	for tenant, tenantLimits := range limits.PerTenant {
+		if tenantLimits.Query.MaxSearchBytesPerTrace == nil {
+			continue
+		}
+		if saved.PerTenant == nil {
+			saved.PerTenant = map[string]*int{}
+		}
+		saved.PerTenant[tenant] = tenantLimits.Query.MaxSearchBytesPerTrace
+	}
+	if saved.Global == nil && saved.PerTenant == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(saved)
+	if err != nil {
+		return fmt.Errorf("failed to serialize MaxSearchBytesPerTrace query limits: %w", err)
+	}
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	meta.Annotations[maxSearchBytesPerTraceAnnotation] = string(data)
+	return nil
+}
+
+// restoreMaxSearchBytesPerTrace restores the MaxSearchBytesPerTrace query limits
+// from the annotation and removes the annotation.
+func restoreMaxSearchBytesPerTrace(meta *metav1.ObjectMeta, limits *LimitSpec) error {
+	data, ok := meta.Annotations[maxSearchBytesPerTraceAnnotation]
+	if !ok {
+		return nil
+	}
+	delete(meta.Annotations, maxSearchBytesPerTraceAnnotation)
+	if len(meta.Annotations) == 0 {
+		meta.Annotations = nil
+	}
+
+	saved := maxSearchBytesPerTraceLimits{}
+	if err := json.Unmarshal([]byte(data), &saved); err != nil {
+		return fmt.Errorf("failed to parse annotation %s: %w", maxSearchBytesPerTraceAnnotation, err)
+	}
+	limits.Global.Query.MaxSearchBytesPerTrace = saved.Global
+	for tenant, value := range saved.PerTenant {
+		if limits.PerTenant == nil {
+			limits.PerTenant = map[string]RateLimitSpec{}
+		}
+		tenantLimits := limits.PerTenant[tenant]
+		tenantLimits.Query.MaxSearchBytesPerTrace = value
+		limits.PerTenant[tenant] = tenantLimits
+	}
+	return nil
+}
+
+// convertJSON converts between the API versions of a type by serializing it.
+// Fields which are not present in the destination type are dropped.
+func convertJSON(src interface{}, dst interface{}) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dst)
+}
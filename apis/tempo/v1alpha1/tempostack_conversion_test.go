@@ -0,0 +1,311 @@
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	"github.com/grafana/tempo-operator/apis/config/v1alpha1"
+	"github.com/grafana/tempo-operator/apis/tempo/v1beta1"
+)
+
+func conversionTempoStack() *TempoStack {
+	return &TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "test",
+			Namespace:       "ns",
+			Labels:          map[string]string{"a": "b"},
+			ResourceVersion: "42",
+		},
+		Spec: TempoStackSpec{
+			ManagementState:  ManagementStateManaged,
+			StorageClassName: ptr.To("standard"),
+			StorageSize:      resource.MustParse("1Gi"),
+			Images: v1alpha1.ImagesSpec{
+				Tempo: "tempo:custom",
+			},
+			Storage: ObjectStorageSpec{
+				Secret: ObjectStorageSecretSpec{
+					Name: "storage",
+					Type: ObjectStorageSecretS3,
+				},
+				TLS: ObjectStorageTLSSpec{
+					CA: "ca",
+				},
+				CredentialMode: CredentialModeToken,
+			},
+			Retention: RetentionSpec{
+				Global: RetentionConfig{
+					Traces: metav1.Duration{Duration: 48 * time.Hour},
+				},
+				PerTenant: map[string]RetentionConfig{
+					"tenant": {Traces: metav1.Duration{Duration: time.Hour}},
+				},
+			},
+			LimitSpec: LimitSpec{
+				Global: RateLimitSpec{
+					Ingestion: IngestionLimitSpec{
+						IngestionBurstSizeBytes: ptr.To(100),
+					},
+					Query: QueryLimit{
+						MaxBytesPerTagValues: ptr.To(200),
+					},
+				},
+			},
+			ReplicationFactor: 3,
+			Observability: ObservabilitySpec{
+				Tracing: TracingConfigSpec{
+					SamplingFraction:    "0.5",
+					JaegerAgentEndpoint: "agent:6831",
+				},
+			},
+			Template: TempoTemplateSpec{
+				Distributor: TempoDistributorSpec{
+					TempoComponentSpec: TempoComponentSpec{
+						Replicas:     ptr.To(int32(2)),
+						NodeSelector: map[string]string{"zone": "a"},
+						Tolerations:  []corev1.Toleration{{Key: "key", Operator: corev1.TolerationOpExists}},
+						Autoscaling: AutoscalingSpec{
+							Enabled:                 true,
+							MinReplicas:             ptr.To(int32(2)),
+							MaxReplicas:             5,
+							TargetCPUUtilization:    ptr.To(int32(80)),
+							TargetMemoryUtilization: ptr.To(int32(70)),
+						},
+					},
+					TLS: ReceiversTLSSpec{
+						Enabled: true,
+						Cert:    "cert",
+					},
+				},
+				Ingester: TempoIngesterSpec{
+					TempoComponentSpec: TempoComponentSpec{
+						Replicas: ptr.To(int32(3)),
+						PodDisruptionBudget: PodDisruptionBudgetSpec{
+							MaxUnavailable: ptr.To(intstr.FromInt32(1)),
+						},
+					},
+				},
+				Querier: TempoComponentSpec{
+					Replicas: ptr.To(int32(1)),
+				},
+				QueryFrontend: TempoQueryFrontendSpec{
+					TempoComponentSpec: TempoComponentSpec{
+						Replicas: ptr.To(int32(4)),
+					},
+					JaegerQuery: JaegerQuerySpec{
+						Enabled: true,
+					},
+				},
+				Gateway: TempoGatewaySpec{
+					TempoComponentSpec: TempoComponentSpec{
+						NodeSelector: map[string]string{"gateway": "true"},
+						PodDisruptionBudget: PodDisruptionBudgetSpec{
+							MinAvailable: ptr.To(intstr.FromString("50%")),
+						},
+					},
+					Enabled: true,
+				},
+				MetricsGenerator: TempoMetricsGeneratorSpec{
+					TempoComponentSpec: TempoComponentSpec{
+						Replicas: ptr.To(int32(5)),
+					},
+					Enabled:    true,
+					Processors: []MetricsGeneratorProcessor{MetricsGeneratorProcessorServiceGraphs},
+				},
+			},
+			Cache: CacheSpec{
+				Backend: CacheBackendMemcached,
+				Managed: ManagedCacheSpec{
+					TempoComponentSpec: TempoComponentSpec{
+						Replicas: ptr.To(int32(6)),
+					},
+					Enabled: true,
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{
+							corev1.ResourceMemory: resource.MustParse("1200Mi"),
+						},
+					},
+				},
+			},
+			ExtraConfig: &ExtraConfigSpec{
+				Tempo: apiextensionsv1.JSON{Raw: []byte(`{"ingester":{"max_block_duration":"30m"}}`)},
+			},
+		},
+		Status: TempoStackStatus{
+			OperatorVersion: "0.7.0",
+			TempoVersion:    "2.3.1",
+			Conditions: []metav1.Condition{
+				{
+					Type:   string(ConditionReady),
+					Status: metav1.ConditionTrue,
+					Reason: string(ReasonReady),
+				},
+			},
+		},
+	}
+}
+
+func TestConvertToHub(t *testing.T) {
+	src := conversionTempoStack()
+	src.Spec.LimitSpec.Global.Query.MaxSearchBytesPerTrace = ptr.To(0)
+	src.Status.TempoQueryVersion = "1.5.0"
+
+	dst := &v1beta1.TempoStack{}
+	require.NoError(t, src.ConvertTo(dst))
+
+	assert.Equal(t, map[string]string{"a": "b"}, dst.Labels)
+	assert.Equal(t, "42", dst.ResourceVersion)
+	assert.JSONEq(t, `{"global":0}`, dst.Annotations[maxSearchBytesPerTraceAnnotation])
+	assert.Nil(t, src.Annotations, "the source object must not be modified")
+	assert.Equal(t, "standard", *dst.Spec.StorageClassName)
+	assert.Equal(t, v1beta1.CredentialModeToken, dst.Spec.Storage.CredentialMode)
+	assert.Equal(t, v1beta1.TracingConfigSpec{
+		SamplingFraction:    "0.5",
+		JaegerAgentEndpoint: "agent:6831",
+	}, dst.Spec.Observability.Tracing)
+	assert.Equal(t, ptr.To(200), dst.Spec.LimitSpec.Global.Query.MaxBytesPerTagValues)
+
+	distributor := dst.Spec.Template.Distributor
+	assert.Equal(t, ptr.To(int32(2)), distributor.Replicas)
+	assert.Equal(t, map[string]string{"zone": "a"}, distributor.NodeSelector)
+	assert.Equal(t, int32(5), distributor.Autoscaling.MaxReplicas)
+	assert.Equal(t, "cert", distributor.TLS.Cert)
+
+	assert.Equal(t, ptr.To(int32(3)), dst.Spec.Template.Ingester.Replicas)
+	assert.Equal(t, ptr.To(int32(4)), dst.Spec.Template.QueryFrontend.Replicas)
+	assert.True(t, dst.Spec.Template.QueryFrontend.JaegerQuery.Enabled)
+	assert.Equal(t, ptr.To(intstr.FromString("50%")), dst.Spec.Template.Gateway.PodDisruptionBudget.MinAvailable)
+	assert.Equal(t, ptr.To(int32(5)), dst.Spec.Template.MetricsGenerator.Replicas)
+	assert.Equal(t, ptr.To(int32(6)), dst.Spec.Cache.Managed.Replicas)
+	assert.Equal(t, resource.MustParse("1200Mi"), dst.Spec.Cache.Managed.Resources.Limits[corev1.ResourceMemory])
+	assert.JSONEq(t, `{"ingester":{"max_block_duration":"30m"}}`, string(dst.Spec.ExtraConfig.Tempo.Raw))
+
+	assert.Equal(t, "0.7.0", dst.Status.OperatorVersion)
+	assert.Len(t, dst.Status.Conditions, 1)
+}
+
+func TestConvertFromHub(t *testing.T) {
+	src := &v1beta1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "ns",
+		},
+		Spec: v1beta1.TempoStackSpec{
+			Observability: v1beta1.ObservabilitySpec{
+				Tracing: v1beta1.TracingConfigSpec{
+					SamplingFraction:    "1",
+					JaegerAgentEndpoint: "localhost:6831",
+				},
+			},
+			Template: v1beta1.TempoTemplateSpec{
+				Distributor: v1beta1.TempoDistributorSpec{
+					TempoComponentSpec: v1beta1.TempoComponentSpec{
+						Replicas: ptr.To(int32(2)),
+					},
+				},
+				Gateway: v1beta1.TempoGatewaySpec{
+					TempoComponentSpec: v1beta1.TempoComponentSpec{
+						Replicas: ptr.To(int32(3)),
+					},
+					Enabled: true,
+				},
+			},
+			Cache: v1beta1.CacheSpec{
+				Managed: v1beta1.ManagedCacheSpec{
+					TempoComponentSpec: v1beta1.TempoComponentSpec{
+						Replicas: ptr.To(int32(4)),
+						Resources: &corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("100m"),
+							},
+						},
+					},
+					Enabled: true,
+				},
+			},
+		},
+	}
+
+	dst := &TempoStack{}
+	require.NoError(t, dst.ConvertFrom(src))
+
+	assert.Equal(t, src.ObjectMeta, dst.ObjectMeta)
+	assert.Equal(t, TracingConfigSpec{
+		SamplingFraction:    "1",
+		JaegerAgentEndpoint: "localhost:6831",
+	}, dst.Spec.Observability.Tracing)
+	assert.Equal(t, ptr.To(int32(2)), dst.Spec.Template.Distributor.Replicas)
+	assert.Equal(t, ptr.To(int32(3)), dst.Spec.Template.Gateway.Replicas)
+	assert.True(t, dst.Spec.Template.Gateway.Enabled)
+	assert.Nil(t, dst.Spec.Template.QueryFrontend.Replicas)
+	assert.Equal(t, ptr.To(int32(4)), dst.Spec.Cache.Managed.Replicas)
+	assert.Equal(t, resource.MustParse("100m"), dst.Spec.Cache.Managed.Resources.Requests[corev1.ResourceCPU])
+	assert.Nil(t, dst.Spec.Cache.Managed.TempoComponentSpec.Resources)
+	assert.True(t, dst.Spec.Cache.Managed.Enabled)
+}
+
+func TestConversionRoundTrip(t *testing.T) {
+	t.Run("v1alpha1 to v1beta1 and back", func(t *testing.T) {
+		original := conversionTempoStack()
+
+		hub := &v1beta1.TempoStack{}
+		require.NoError(t, original.DeepCopy().ConvertTo(hub))
+		converted := &TempoStack{}
+		require.NoError(t, converted.ConvertFrom(hub))
+
+		assert.Equal(t, original, converted)
+	})
+
+	t.Run("v1beta1 to v1alpha1 and back", func(t *testing.T) {
+		spoke := conversionTempoStack()
+		original := &v1beta1.TempoStack{}
+		require.NoError(t, spoke.ConvertTo(original))
+
+		converted := &TempoStack{}
+		require.NoError(t, converted.ConvertFrom(original.DeepCopy()))
+		hub := &v1beta1.TempoStack{}
+		require.NoError(t, converted.ConvertTo(hub))
+
+		assert.Equal(t, original, hub)
+	})
+
+	t.Run("MaxSearchBytesPerTrace query limits are preserved", func(t *testing.T) {
+		original := conversionTempoStack()
+		original.Annotations = map[string]string{"c": "d"}
+		original.Spec.LimitSpec.Global.Query.MaxSearchBytesPerTrace = ptr.To(1000)
+		original.Spec.LimitSpec.PerTenant = map[string]RateLimitSpec{
+			"tenant":  {Query: QueryLimit{MaxSearchBytesPerTrace: ptr.To(0)}},
+			"tenant2": {Query: QueryLimit{MaxBytesPerTagValues: ptr.To(10)}},
+		}
+
+		hub := &v1beta1.TempoStack{}
+		require.NoError(t, original.DeepCopy().ConvertTo(hub))
+		assert.JSONEq(t, `{"global":1000,"perTenant":{"tenant":0}}`, hub.Annotations[maxSearchBytesPerTraceAnnotation])
+		converted := &TempoStack{}
+		require.NoError(t, converted.ConvertFrom(hub))
+
+		assert.Equal(t, original, converted)
+		assert.Contains(t, hub.Annotations, maxSearchBytesPerTraceAnnotation, "the source object must not be modified")
+	})
+
+	t.Run("TempoQueryVersion status field is dropped", func(t *testing.T) {
+		original := conversionTempoStack()
+		original.Status.TempoQueryVersion = "1.5.0"
+
+		hub := &v1beta1.TempoStack{}
+		require.NoError(t, original.ConvertTo(hub))
+		converted := &TempoStack{}
+		require.NoError(t, converted.ConvertFrom(hub))
+
+		assert.Empty(t, converted.Status.TempoQueryVersion)
+	})
+}
@@ -1,9 +1,12 @@
 package v1alpha1
 
 import (
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/grafana/tempo-operator/apis/config/v1alpha1"
 )
@@ -23,6 +26,65 @@ const (
 	ManagementStateUnmanaged ManagementStateType = "Unmanaged"
 )
 
+// VolumeRetentionPolicy defines if the PVCs of removed ingesters are retained or deleted.
+//
+// +kubebuilder:validation:Enum=Retain;Delete
+type VolumeRetentionPolicy string
+
+const (
+	// VolumeRetentionPolicyRetain keeps the PVCs of removed ingesters.
+	VolumeRetentionPolicyRetain VolumeRetentionPolicy = "Retain"
+	// VolumeRetentionPolicyDelete deletes the PVCs of removed ingesters.
+	VolumeRetentionPolicyDelete VolumeRetentionPolicy = "Delete"
+)
+
+// LogLevel defines the log level of a component.
+//
+// +kubebuilder:validation:Enum=debug;info;warn;error
+type LogLevel string
+
+const (
+	// LogLevelDebug logs debug, info, warn and error messages.
+	LogLevelDebug LogLevel = "debug"
+	// LogLevelInfo logs info, warn and error messages.
+	LogLevelInfo LogLevel = "info"
+	// LogLevelWarn logs warn and error messages.
+	LogLevelWarn LogLevel = "warn"
+	// LogLevelError logs error messages.
+	LogLevelError LogLevel = "error"
+)
+
+// LogFormat defines the log format of the components.
+//
+// +kubebuilder:validation:Enum=logfmt;json
+type LogFormat string
+
+const (
+	// LogFormatLogfmt logs in the logfmt format.
+	LogFormatLogfmt LogFormat = "logfmt"
+	// LogFormatJSON logs in the JSON format.
+	LogFormatJSON LogFormat = "json"
+)
+
+// MemberListSecurity defines the isolation and encryption of the memberlist gossip ring.
+//
+// +kubebuilder:validation:Enum=Legacy;LabelVerificationDisabled;ClusterLabelUnverified;ClusterLabel;Secure
+type MemberListSecurity string
+
+const (
+	// MemberListSecurityLegacy configures memberlist without cluster label and TLS, as operator versions before 0.7.0.
+	MemberListSecurityLegacy MemberListSecurity = "Legacy"
+	// MemberListSecurityLabelVerificationDisabled accepts gossip messages with any cluster label, without setting a cluster label.
+	MemberListSecurityLabelVerificationDisabled MemberListSecurity = "LabelVerificationDisabled"
+	// MemberListSecurityClusterLabelUnverified sets the cluster label and accepts gossip messages with any cluster label.
+	MemberListSecurityClusterLabelUnverified MemberListSecurity = "ClusterLabelUnverified"
+	// MemberListSecurityClusterLabel sets the cluster label and rejects gossip messages with a different cluster label.
+	MemberListSecurityClusterLabel MemberListSecurity = "ClusterLabel"
+	// MemberListSecuritySecure sets and verifies the cluster label, and encrypts the gossip traffic
+	// if the grpcEncryption feature gate is enabled.
+	MemberListSecuritySecure MemberListSecurity = "Secure"
+)
+
 // TempoStackSpec defines the desired state of TempoStack.
 type TempoStackSpec struct {
 	// ManagementState defines if the CR should be managed by the operator or not.
@@ -52,11 +114,21 @@ type TempoStackSpec struct {
 	Resources Resources `json:"resources,omitempty"`
 
 	// StorageSize for PVCs used by ingester. Defaults to 10Gi.
+	// The size can be increased if the StorageClass of the PVCs allows volume expansion, it cannot be decreased.
 	//
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Storage size for PVCs"
 	StorageSize resource.Quantity `json:"storageSize,omitempty"`
 
+	// VolumeRetentionPolicy defines if the PVCs of ingesters, which are removed by a scale-down, are retained or deleted.
+	// The PVCs are deleted after the ingesters flushed their data to the object storage and left the ring.
+	// Defaults to Retain.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Volume Retention Policy",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:Retain","urn:alm:descriptor:com.tectonic.ui:select:Delete"}
+	VolumeRetentionPolicy VolumeRetentionPolicy `json:"volumeRetentionPolicy,omitempty"`
+
 	// Images defines the image for each container.
 	//
 	// +optional
@@ -122,6 +194,127 @@ type TempoStackSpec struct {
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Observability"
 	Observability ObservabilitySpec `json:"observability,omitempty"`
+
+	// LogFormat defines the log format of the Tempo components and the gateway.
+	// Defaults to logfmt.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Log Format",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:logfmt","urn:alm:descriptor:com.tectonic.ui:select:json"}
+	LogFormat LogFormat `json:"logFormat,omitempty"`
+
+	// Cache defines the caching layer used by the querier and query-frontend.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Cache"
+	Cache CacheSpec `json:"cache,omitempty"`
+
+	// ExtraConfig defines any extra (overlay) configuration for components.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Extra Configurations"
+	ExtraConfig *ExtraConfigSpec `json:"extraConfig,omitempty"`
+}
+
+// ExtraConfigSpec defines extra configurations for Tempo and tempo-query.
+// The extra configuration is deep-merged over the configuration generated by the operator,
+// i.e. maps are merged recursively and all other values (including lists) replace the generated values.
+// Overriding settings managed by the operator can result in a non-functional deployment.
+type ExtraConfigSpec struct {
+	// Tempo defines any extra Tempo configuration, which will be merged with the operator's generated Tempo configuration (tempo.yaml).
+	// The values are used literally, environment variable references like ${VAR} are not expanded.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Tempo Extra Configurations"
+	Tempo apiextensionsv1.JSON `json:"tempo,omitempty"`
+
+	// TempoQuery defines any extra tempo-query configuration, which will be merged with the operator's generated
+	// tempo-query configuration (tempo-query.yaml).
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Tempo Query Extra Configurations"
+	TempoQuery apiextensionsv1.JSON `json:"tempoQuery,omitempty"`
+}
+
+// CacheSpec defines the caching layer used by Tempo.
+type CacheSpec struct {
+	// Backend defines the cache backend.
+	// The cache is disabled if no backend is set.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Backend",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:memcached","urn:alm:descriptor:com.tectonic.ui:select:redis"}
+	Backend CacheBackendType `json:"backend,omitempty"`
+
+	// Endpoint defines the address of an external cache.
+	// For memcached, this is a comma separated list of addresses, e.g. dns+memcached.svc:11211.
+	// For redis, this is the host:port of the redis server.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Endpoint"
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Managed defines a memcached instance deployed and managed by the operator.
+	// Only supported by the memcached backend.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Managed memcached"
+	Managed ManagedCacheSpec `json:"managed,omitempty"`
+}
+
+// CacheBackendType defines the cache backend.
+//
+// +kubebuilder:validation:Enum=memcached;redis
+type CacheBackendType string
+
+const (
+	// CacheBackendMemcached uses memcached as cache backend.
+	CacheBackendMemcached CacheBackendType = "memcached"
+	// CacheBackendRedis uses redis as cache backend.
+	CacheBackendRedis CacheBackendType = "redis"
+)
+
+// ManagedCacheSpec defines a memcached instance managed by the operator.
+type ManagedCacheSpec struct {
+	// TempoComponentSpec is embedded to extend this definition with further options.
+	//
+	// Currently there is no way to inline this field.
+	// See: https://github.com/golang/go/issues/6213
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	TempoComponentSpec `json:"component,omitempty"`
+
+	// Enabled defines if the operator should deploy memcached.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enabled",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled bool `json:"enabled"`
+
+	// MemoryLimitMB defines the memory (in megabytes) memcached uses for items.
+	// Defaults to 1024.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=64
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Memory Limit (MB)",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	MemoryLimitMB int `json:"memoryLimitMB,omitempty"`
+
+	// Resources defines the compute resources of the memcached container.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resources",xDescriptors="urn:alm:descriptor:com.tectonic.ui:resourceRequirements"
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
 }
 
 // ObservabilitySpec defines how telemetry data gets handled.
@@ -240,6 +433,20 @@ type ComponentStatus struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:com.tectonic.ui:podStatuses",displayName="Query Frontend",order=4
 	QueryFrontend PodStatusMap `json:"queryFrontend"`
 
+	// MetricsGenerator is a map to the per pod status of the metrics-generator deployment
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:com.tectonic.ui:podStatuses",displayName="Metrics Generator",order=6
+	MetricsGenerator PodStatusMap `json:"metricsGenerator,omitempty"`
+
+	// Memcached is a map to the per pod status of the managed memcached statefulset
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:com.tectonic.ui:podStatuses",displayName="Memcached",order=7
+	Memcached PodStatusMap `json:"memcached,omitempty"`
+
 	// Gateway is a map to the per pod status of the query frontend deployment
 	//
 	// +optional
@@ -289,6 +496,9 @@ const (
 	ConditionPending ConditionStatus = "Pending"
 	// ConditionConfigurationError defines that there is a configuration error.
 	ConditionConfigurationError ConditionStatus = "ConfigurationError"
+	// ConditionStorageReachable defines whether the object storage is reachable with the configured credentials.
+	// In contrast to the other conditions, it is set independently of the state of the components.
+	ConditionStorageReachable ConditionStatus = "StorageReachable"
 )
 
 // AllStatusConditions lists all possible status conditions.
@@ -314,8 +524,25 @@ const (
 	ReasonMissingGatewayTenantSecret ConditionReason = "ReasonMissingGatewayTenantSecret"
 	// ReasonInvalidTenantsConfiguration when the tenant configuration provided is invalid.
 	ReasonInvalidTenantsConfiguration ConditionReason = "InvalidTenantsConfiguration"
+	// ReasonInvalidMetricsGeneratorConfig defines that the metrics-generator configuration is invalid (missing or incomplete remote write secret).
+	ReasonInvalidMetricsGeneratorConfig ConditionReason = "InvalidMetricsGeneratorConfig"
 	// ReasonFailedReconciliation when the operator failed to reconcile.
 	ReasonFailedReconciliation ConditionReason = "FailedReconciliation"
+	// ReasonVolumeExpansionInProgress when the persistent volumes of the ingesters are being expanded.
+	ReasonVolumeExpansionInProgress ConditionReason = "VolumeExpansionInProgress"
+	// ReasonVolumeExpansionFailed when the persistent volumes of the ingesters cannot be expanded.
+	ReasonVolumeExpansionFailed ConditionReason = "VolumeExpansionFailed"
+	// ReasonIngesterScaleDownInProgress when the ingesters removed by a scale-down are flushing their data and leaving the ring.
+	ReasonIngesterScaleDownInProgress ConditionReason = "IngesterScaleDownInProgress"
+	// ReasonIngesterScaleDownFailed when the ingesters removed by a scale-down cannot be shut down safely,
+	// for example because the ingester ring is not reachable.
+	ReasonIngesterScaleDownFailed ConditionReason = "IngesterScaleDownFailed"
+	// ReasonStorageCheckSucceeded when the object storage is reachable with the configured credentials.
+	ReasonStorageCheckSucceeded ConditionReason = "StorageCheckSucceeded"
+	// ReasonStorageCheckFailed when the object storage cannot be listed or written with the configured credentials.
+	ReasonStorageCheckFailed ConditionReason = "StorageCheckFailed"
+	// ReasonStorageCheckNotSupported when the object storage cannot be checked by the operator, e.g. in the token credential mode.
+	ReasonStorageCheckNotSupported ConditionReason = "StorageCheckNotSupported"
 )
 
 // Resources defines resources configuration.
@@ -323,6 +550,8 @@ type Resources struct {
 	// The total amount of resources for Tempo instance.
 	// The operator autonomously splits resources between deployed Tempo components.
 	// Only limits are supported, the operator calculates requests automatically.
+	// Components with explicit resources (spec.template.<component>.resources) are excluded from the split,
+	// and their limits are subtracted from the total resources.
 	// See http://github.com/grafana/tempo/issues/1540.
 	//
 	// +optional
@@ -370,6 +599,20 @@ const (
 	ObjectStorageSecretS3 ObjectStorageSecretType = "s3"
 )
 
+// CredentialMode defines how the Tempo components authenticate to the object storage.
+//
+// +kubebuilder:validation:Enum=static;token
+type CredentialMode string
+
+const (
+	// CredentialModeStatic uses the long-lived credentials stored in the object storage secret.
+	CredentialModeStatic CredentialMode = "static"
+
+	// CredentialModeToken uses short-lived credentials, which are obtained by exchanging
+	// a projected service account token with the identity provider of the cloud provider.
+	CredentialModeToken CredentialMode = "token"
+)
+
 // ObjectStorageSecretSpec is a secret reference containing name only, no namespace.
 type ObjectStorageSecretSpec struct {
 	// Type of object storage that should be used
@@ -405,6 +648,32 @@ type ObjectStorageSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Object Storage Secret"
 	Secret ObjectStorageSecretSpec `json:"secret"`
 	// Don't forget to update storageSecretField in tempostack_controller.go if this field name changes.
+
+	// CredentialMode defines how the Tempo components authenticate to the object storage.
+	// The static mode (default) uses the credentials stored in the object storage secret.
+	// The token mode uses short-lived credentials obtained with a projected service account token,
+	// i.e. AWS STS with IAM roles for service accounts (IRSA), Azure Workload Identity or
+	// GCP Workload Identity Federation.
+	// In token mode, the S3 storage secret must contain the "bucket", "region" and "role_arn" fields,
+	// and the Azure storage secret must contain the "container", "account_name", "client_id", "tenant_id"
+	// and "subscription_id" fields.
+	// The GCS storage secret must contain the "bucketname" field, and optionally a workload identity federation
+	// credential configuration in the "key.json" field (without it, GKE Workload Identity is used) and the
+	// GCP service account to impersonate in the "iam_service_account" field.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:static","urn:alm:descriptor:com.tectonic.ui:select:token"},displayName="Credential Mode"
+	CredentialMode CredentialMode `json:"credentialMode,omitempty"`
+
+	// Prefix is the path prefix of the objects written to the bucket or container.
+	// It allows several TempoStacks to share a bucket, each with its own prefix.
+	// If not set, the "prefix" field of the storage secret is used.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Prefix"
+	Prefix string `json:"prefix,omitempty"`
 }
 
 // ObjectStorageTLSSpec is the TLS configuration for reaching the object storage endpoint.
@@ -426,6 +695,19 @@ type MemberListSpec struct {
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch",displayName="Enable IPv6"
 	EnableIPv6 *bool `json:"enableIPv6,omitempty"`
+
+	// Security defines the isolation and encryption of the memberlist gossip ring.
+	// Secure sets a cluster label derived from the namespace and name of the TempoStack,
+	// rejects gossip messages of other rings, and encrypts the gossip traffic if the grpcEncryption feature gate is enabled.
+	// TempoStacks created before operator version 0.7.0 are set to Legacy by the operator upgrade,
+	// and are switched over by setting the next step after the rollout of the previous step has finished:
+	// Legacy, LabelVerificationDisabled, ClusterLabelUnverified, ClusterLabel, Secure.
+	// Defaults to Secure.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Security",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:Legacy","urn:alm:descriptor:com.tectonic.ui:select:LabelVerificationDisabled","urn:alm:descriptor:com.tectonic.ui:select:ClusterLabelUnverified","urn:alm:descriptor:com.tectonic.ui:select:ClusterLabel","urn:alm:descriptor:com.tectonic.ui:select:Secure"}
+	Security MemberListSecurity `json:"security,omitempty"`
 }
 
 // HashRingSpec defines the hash ring configuration.
@@ -481,7 +763,7 @@ type TempoTemplateSpec struct {
 	// +optional
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Ingester pods"
-	Ingester TempoComponentSpec `json:"ingester,omitempty"`
+	Ingester TempoIngesterSpec `json:"ingester,omitempty"`
 
 	// Compactor defines the tempo compactor component spec.
 	//
@@ -510,6 +792,13 @@ type TempoTemplateSpec struct {
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Gateway pods"
 	Gateway TempoGatewaySpec `json:"gateway,omitempty"`
+
+	// MetricsGenerator defines the metrics-generator component spec.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Metrics Generator pods"
+	MetricsGenerator TempoMetricsGeneratorSpec `json:"metricsGenerator,omitempty"`
 }
 
 // TempoDistributorSpec defines the template of all requirements to configure
@@ -531,6 +820,28 @@ type TempoDistributorSpec struct {
 	TLS ReceiversTLSSpec `json:"tls,omitempty"`
 }
 
+// TempoIngesterSpec defines the template of all requirements to configure
+// scheduling of Tempo ingester component to be deployed.
+type TempoIngesterSpec struct {
+	// TempoComponentSpec is embedded to extend this definition with further options.
+	// The fields are inlined to keep the fields of the ingester spec unchanged.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	TempoComponentSpec `json:",inline"`
+
+	// ZoneAwareReplication defines the zone-aware replication of the ingesters.
+	// If enabled, the operator creates one ingester StatefulSet per zone, and the replicas of a trace
+	// are placed in different zones.
+	// Zone-aware replication cannot be enabled or disabled, and the zones cannot be renamed, added, removed
+	// or reordered after the TempoStack is created.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Zone-Aware Replication"
+	ZoneAwareReplication ZoneAwareReplicationSpec `json:"zoneAwareReplication,omitempty"`
+}
+
 // TempoComponentSpec defines specific schedule settings for tempo components.
 type TempoComponentSpec struct {
 	// Replicas represents the number of replicas to create for this component.
@@ -550,6 +861,218 @@ type TempoComponentSpec struct {
 	// +listType=atomic
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Tolerations"
 	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Autoscaling defines a HorizontalPodAutoscaler for this component.
+	// Autoscaling is supported by the distributor, ingester, querier, query-frontend and compactor.
+	// If enabled, the replicas of the component are managed by the HorizontalPodAutoscaler.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Autoscaling"
+	Autoscaling AutoscalingSpec `json:"autoscaling,omitempty"`
+
+	// PodDisruptionBudget overrides the PodDisruptionBudget created by the operator for this component.
+	// By default, one pod of each component can be unavailable at a time.
+	// For the ingester, the maximum number of unavailable pods is derived from the replication factor.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Pod Disruption Budget"
+	PodDisruptionBudget PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+
+	// LogLevel defines the log level of this component.
+	// The log level of the query-frontend is also used by the tempo-query container.
+	// Defaults to info.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Log Level",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:debug","urn:alm:descriptor:com.tectonic.ui:select:info","urn:alm:descriptor:com.tectonic.ui:select:warn","urn:alm:descriptor:com.tectonic.ui:select:error"}
+	LogLevel LogLevel `json:"logLevel,omitempty"`
+
+	// Resources defines the compute resources of this component.
+	// If set, the resources take precedence over the share of the total resources (spec.resources.total).
+	// The remaining total resources are split across the components without explicit resources.
+	// The managed cache does not receive a share of the total resources.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resources",xDescriptors="urn:alm:descriptor:com.tectonic.ui:resourceRequirements"
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// PodTemplate defines overrides of the pod template of this component.
+	// The overrides are applied on top of the pod template created by the operator.
+	// PodTemplate is supported by the distributor, ingester, querier, query-frontend, compactor, gateway and metrics-generator.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Type=object
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Pod Template"
+	PodTemplate *PodTemplateSpec `json:"podTemplate,omitempty"`
+}
+
+// ZoneAwareReplicationSpec defines the zone-aware replication of the ingesters.
+type ZoneAwareReplicationSpec struct {
+	// Enabled defines if zone-aware replication is enabled.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enabled",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Zones defines the availability zones of the ingesters.
+	// The number of zones must be greater than or equal to the replication factor.
+	// The ingester replicas are distributed evenly across the zones.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Zones"
+	Zones []ZoneSpec `json:"zones,omitempty"`
+}
+
+// ZoneSpec defines an availability zone of the ingesters.
+type ZoneSpec struct {
+	// Name of the zone.
+	// The name is used in the name of the ingester StatefulSet of this zone.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Name"
+	Name string `json:"name"`
+
+	// NodeSelector schedules the ingesters of this zone on the nodes of the zone,
+	// for example topology.kubernetes.io/zone: us-east-1a.
+	// The node selector is merged with the node selector of the ingester.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Node Selector"
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+}
+
+// PodTemplateSpec defines overrides of the pod template of a component.
+// The overrides are applied as a strategic merge patch, i.e. the env vars, volumes and volume mounts
+// are merged with the ones created by the operator by their name (mount path for volume mounts).
+type PodTemplateSpec struct {
+	// Labels are added to the labels of the pods.
+	// The labels created by the operator can't be overridden.
+	//
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations are added to the annotations of the pods.
+	//
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Affinity replaces the default affinity of the pods.
+	//
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// TopologySpreadConstraints defines the topology spread constraints of the pods.
+	//
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// PriorityClassName defines the priority class of the pods.
+	//
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// Env defines additional environment variables of the main container.
+	//
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Volumes defines additional volumes of the pods.
+	//
+	// +optional
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+
+	// VolumeMounts defines additional volume mounts of the main container.
+	//
+	// +optional
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+
+	// Sidecars defines additional containers of the pods.
+	//
+	// +optional
+	Sidecars []corev1.Container `json:"sidecars,omitempty"`
+}
+
+// PodDisruptionBudgetSpec defines the PodDisruptionBudget settings of a component.
+// Only one of MinAvailable and MaxUnavailable can be set.
+type PodDisruptionBudgetSpec struct {
+	// MinAvailable is the number or percentage of pods which must be available after an eviction.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Minimum Available"
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// MaxUnavailable is the number or percentage of pods which can be unavailable after an eviction.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Maximum Unavailable"
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// AutoscalingSpec defines the HorizontalPodAutoscaler settings of a component.
+type AutoscalingSpec struct {
+	// Enabled defines if a HorizontalPodAutoscaler should be created for this component.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enabled",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled bool `json:"enabled,omitempty"`
+
+	// MinReplicas is the lower limit for the number of replicas of this component.
+	// Defaults to the replicas of the component.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Minimum Replicas",xDescriptors="urn:alm:descriptor:com.tectonic.ui:podCount"
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas is the upper limit for the number of replicas of this component.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Maximum Replicas",xDescriptors="urn:alm:descriptor:com.tectonic.ui:podCount"
+	MaxReplicas int32 `json:"maxReplicas,omitempty"`
+
+	// TargetCPUUtilization is the target average CPU utilization (in percent of the requested CPU) of all pods.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Target CPU Utilization"
+	TargetCPUUtilization *int32 `json:"targetCPUUtilization,omitempty"`
+
+	// TargetMemoryUtilization is the target average memory utilization (in percent of the requested memory) of all pods.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Target Memory Utilization"
+	TargetMemoryUtilization *int32 `json:"targetMemoryUtilization,omitempty"`
+
+	// Metrics defines additional metrics, for example custom or external metrics,
+	// which are used by the HorizontalPodAutoscaler to calculate the number of replicas.
+	// The metrics are validated by the HorizontalPodAutoscaler API (autoscaling/v2).
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Metrics",xDescriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	Metrics []autoscalingv2.MetricSpec `json:"metrics,omitempty"`
 }
 
 // TempoGatewaySpec extends TempoComponentSpec with gateway parameters.
@@ -570,6 +1093,92 @@ type TempoGatewaySpec struct {
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Jaeger gateway Ingress Settings"
 	Ingress IngressSpec `json:"ingress,omitempty"`
+
+	// OPA defines the opa-openshift container, which is deployed in the openshift tenant mode.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="OPA Settings"
+	OPA GatewayOPASpec `json:"opa,omitempty"`
+}
+
+// GatewayOPASpec defines the opa-openshift container of the gateway.
+type GatewayOPASpec struct {
+	// Resources defines the compute resources of the opa-openshift container.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resources",xDescriptors="urn:alm:descriptor:com.tectonic.ui:resourceRequirements"
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// TempoMetricsGeneratorSpec extends TempoComponentSpec with metrics-generator specific parameters.
+type TempoMetricsGeneratorSpec struct {
+	// TempoComponentSpec is embedded to extend this definition with further options.
+	//
+	// Currently there is no way to inline this field.
+	// See: https://github.com/golang/go/issues/6213
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	TempoComponentSpec `json:"component,omitempty"`
+
+	// Enabled defines if the metrics-generator should be deployed.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enabled",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled bool `json:"enabled"`
+
+	// Processors defines the processors enabled for all tenants.
+	// Defaults to span-metrics and service-graphs if the metrics-generator is enabled.
+	// The processors can be overridden per tenant in .spec.limits.perTenant.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Processors"
+	Processors []MetricsGeneratorProcessor `json:"processors,omitempty"`
+
+	// RemoteWrite defines the Prometheus remote write endpoint of the generated metrics.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Remote Write"
+	RemoteWrite MetricsGeneratorRemoteWriteSpec `json:"remoteWrite,omitempty"`
+}
+
+// MetricsGeneratorProcessor defines a processor of the metrics-generator.
+//
+// +kubebuilder:validation:Enum=span-metrics;service-graphs;local-blocks
+type MetricsGeneratorProcessor string
+
+const (
+	// MetricsGeneratorProcessorSpanMetrics generates RED metrics from spans.
+	MetricsGeneratorProcessorSpanMetrics MetricsGeneratorProcessor = "span-metrics"
+	// MetricsGeneratorProcessorServiceGraphs generates metrics describing the relationships between services.
+	MetricsGeneratorProcessorServiceGraphs MetricsGeneratorProcessor = "service-graphs"
+	// MetricsGeneratorProcessorLocalBlocks keeps recent traces in local blocks, required for TraceQL metrics.
+	MetricsGeneratorProcessorLocalBlocks MetricsGeneratorProcessor = "local-blocks"
+)
+
+// MetricsGeneratorRemoteWriteSpec defines the Prometheus remote write endpoint.
+type MetricsGeneratorRemoteWriteSpec struct {
+	// URL is the URL of the Prometheus remote write endpoint, e.g. http://prometheus:9090/api/v1/write.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="URL"
+	URL string `json:"url,omitempty"`
+
+	// Secret is the name of a Secret in the namespace of the TempoStack containing the CA certificate
+	// and the credentials of the remote write endpoint.
+	// Supported keys are ca.crt (CA certificate), username and password (basic auth) and token (bearer token).
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Secret",xDescriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	Secret string `json:"secret,omitempty"`
 }
 
 // TempoQueryFrontendSpec extends TempoComponentSpec with frontend specific parameters.
@@ -612,6 +1221,14 @@ type JaegerQuerySpec struct {
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Jaeger Query UI Monitor Tab Settings"
 	MonitorTab JaegerQueryMonitor `json:"monitorTab"`
+
+	// Resources defines the compute resources of the tempo-query container.
+	// Defaults to the resources of the query-frontend container.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resources",xDescriptors="urn:alm:descriptor:com.tectonic.ui:resourceRequirements"
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
 }
 
 // JaegerQueryMonitor defines configuration for the service monitoring tab in the Jaeger console.
@@ -715,6 +1332,31 @@ type RateLimitSpec struct {
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Query Limit"
 	Query QueryLimit `json:"query"`
+
+	// MetricsGenerator is used to configure the metrics-generator processors.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Metrics Generator"
+	MetricsGenerator MetricsGeneratorLimitSpec `json:"metricsGenerator,omitempty"`
+}
+
+// MetricsGeneratorLimitSpec defines the metrics-generator processors of a tenant.
+type MetricsGeneratorLimitSpec struct {
+	// Processors overrides the processors defined in .spec.template.metricsGenerator.processors.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Processors"
+	Processors []MetricsGeneratorProcessor `json:"processors,omitempty"`
+
+	// Disabled disables all metrics-generator processors.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Disabled",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Disabled bool `json:"disabled,omitempty"`
 }
 
 // IngestionLimitSpec defines the limits applied at the ingestion path.
@@ -778,6 +1420,7 @@ type QueryLimit struct {
 // RetentionSpec defines global and per tenant retention configurations.
 type RetentionSpec struct {
 	// PerTenant is used to configure retention per tenant.
+	// The key is the tenant ID, the retention is rendered in the per-tenant overrides of Tempo.
 	//
 	// +optional
 	// +kubebuilder:validation:Optional
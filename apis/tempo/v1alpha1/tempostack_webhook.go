@@ -1,19 +1,30 @@
 package v1alpha1
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"math"
 	"net"
+	"net/url"
+	"reflect"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
+	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -33,6 +44,7 @@ var (
 const maxLabelLength = 63
 const defaultRouteGatewayTLSTermination = TLSRouteTerminationTypePassthrough
 const defaultUITLSTermination = TLSRouteTerminationTypeEdge
+const defaultMemcachedMemoryLimitMB = 1024
 
 // SetupWebhookWithManager initializes the webhook.
 func (r *TempoStack) SetupWebhookWithManager(mgr ctrl.Manager, ctrlConfig v1alpha1.ProjectConfig) error {
@@ -111,12 +123,45 @@ func (d *Defaulter) Default(ctx context.Context, obj runtime.Object) error {
 	if r.Spec.Template.QueryFrontend.Replicas == nil {
 		r.Spec.Template.QueryFrontend.Replicas = defaultComponentReplicas
 	}
+	if r.Spec.Template.MetricsGenerator.Enabled {
+		if r.Spec.Template.MetricsGenerator.Replicas == nil {
+			r.Spec.Template.MetricsGenerator.Replicas = defaultComponentReplicas
+		}
+		if len(r.Spec.Template.MetricsGenerator.Processors) == 0 {
+			r.Spec.Template.MetricsGenerator.Processors = []MetricsGeneratorProcessor{
+				MetricsGeneratorProcessorSpanMetrics,
+				MetricsGeneratorProcessorServiceGraphs,
+			}
+		}
+	}
+
+	if r.Spec.Cache.Managed.Enabled {
+		if r.Spec.Cache.Managed.Replicas == nil {
+			r.Spec.Cache.Managed.Replicas = defaultComponentReplicas
+		}
+		if r.Spec.Cache.Managed.MemoryLimitMB == 0 {
+			r.Spec.Cache.Managed.MemoryLimitMB = defaultMemcachedMemoryLimitMB
+		}
+	}
 
 	// Default replication factor if not specified.
 	if r.Spec.ReplicationFactor == 0 {
 		r.Spec.ReplicationFactor = defaultReplicationFactor
 	}
 
+	// Default the lower limit of the autoscaler to the replicas of the component.
+	for _, component := range []*TempoComponentSpec{
+		&r.Spec.Template.Distributor.TempoComponentSpec,
+		&r.Spec.Template.Ingester.TempoComponentSpec,
+		&r.Spec.Template.Querier,
+		&r.Spec.Template.QueryFrontend.TempoComponentSpec,
+		&r.Spec.Template.Compactor,
+	} {
+		if component.Autoscaling.Enabled && component.Autoscaling.MinReplicas == nil {
+			component.Autoscaling.MinReplicas = ptr.To(*component.Replicas)
+		}
+	}
+
 	// if tenant mode is Openshift, ingress type should be route by default.
 	if r.Spec.Tenants != nil && r.Spec.Tenants.Mode == ModeOpenShift && r.Spec.Template.Gateway.Ingress.Type == "" {
 		r.Spec.Template.Gateway.Ingress.Type = IngressTypeRoute
@@ -149,11 +194,11 @@ type validator struct {
 }
 
 func (v *validator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
-	return v.validate(ctx, obj)
+	return v.validate(ctx, nil, obj)
 }
 
 func (v *validator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
-	return v.validate(ctx, newObj)
+	return v.validate(ctx, oldObj, newObj)
 }
 
 func (v *validator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
@@ -204,6 +249,23 @@ func (v *validator) validateStorageCA(ctx context.Context, tempo TempoStack) (ad
 	return admission.Warnings{}, ValidateStorageCAConfigMap(*caConfigMap)
 }
 
+// validateStorageLocation rejects a TempoStack which would write to the same bucket and prefix as another TempoStack or TempoMonolithic.
+func (v *validator) validateStorageLocation(ctx context.Context, tempo TempoStack) field.ErrorList {
+	inUse, err := storageLocationInUse(ctx, v.client, storageLocation{
+		kind:    "TempoStack",
+		key:     types.NamespacedName{Namespace: tempo.Namespace, Name: tempo.Name},
+		storage: tempo.Spec.Storage,
+	})
+	path := field.NewPath("spec").Child("storage").Child("prefix")
+	if err != nil {
+		return field.ErrorList{field.InternalError(path, err)}
+	}
+	if inUse {
+		return field.ErrorList{field.Invalid(path, tempo.Spec.Storage.Prefix, storageLocationInUseMessage)}
+	}
+	return nil
+}
+
 func (v *validator) validateReplicationFactor(tempo TempoStack) field.ErrorList {
 	// Validate minimum quorum on ingestors according to replicas and replication factor
 	replicatonFactor := tempo.Spec.ReplicationFactor
@@ -423,7 +485,549 @@ func (v *validator) validateReceiverTLS(tempo TempoStack) field.ErrorList {
 	return nil
 }
 
-func (v *validator) validate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+func (v *validator) validateMetricsGenerator(tempo TempoStack) field.ErrorList {
+	spec := tempo.Spec.Template.MetricsGenerator
+	if !spec.Enabled {
+		return nil
+	}
+
+	for _, processor := range spec.Processors {
+		if processor != MetricsGeneratorProcessorLocalBlocks && spec.RemoteWrite.URL == "" {
+			return field.ErrorList{
+				field.Invalid(
+					field.NewPath("spec").Child("template").Child("metricsGenerator").Child("remoteWrite").Child("url"),
+					spec.RemoteWrite.URL,
+					fmt.Sprintf("the %s processor requires a remote write URL", processor),
+				)}
+		}
+	}
+	return nil
+}
+
+func (v *validator) validateCache(tempo TempoStack) field.ErrorList {
+	spec := tempo.Spec.Cache
+	path := field.NewPath("spec").Child("cache")
+
+	if spec.Managed.TempoComponentSpec.Resources != nil {
+		return field.ErrorList{field.Invalid(
+			path.Child("managed").Child("component").Child("resources"),
+			spec.Managed.TempoComponentSpec.Resources,
+			"please configure the resources of the managed cache in spec.cache.managed.resources",
+		)}
+	}
+
+	if spec.Managed.Enabled {
+		if spec.Backend != CacheBackendMemcached {
+			return field.ErrorList{field.Invalid(
+				path.Child("backend"),
+				spec.Backend,
+				"a managed cache requires the memcached backend",
+			)}
+		}
+		if spec.Endpoint != "" {
+			return field.ErrorList{field.Invalid(
+				path.Child("endpoint"),
+				spec.Endpoint,
+				"cannot use an external endpoint and a managed cache at the same time",
+			)}
+		}
+		return nil
+	}
+
+	if spec.Backend != "" && spec.Endpoint == "" {
+		return field.ErrorList{field.Invalid(
+			path.Child("endpoint"),
+			spec.Endpoint,
+			"please configure the endpoint of the cache or enable the managed cache",
+		)}
+	}
+	return nil
+}
+
+func (v *validator) validateAutoscaling(tempo TempoStack) field.ErrorList {
+	templatePath := field.NewPath("spec").Child("template")
+	var errs field.ErrorList
+
+	// The gateway, the metrics-generator and the managed memcached do not support autoscaling.
+	unsupported := []struct {
+		path *field.Path
+		spec AutoscalingSpec
+	}{
+		{templatePath.Child("gateway").Child("component").Child("autoscaling"), tempo.Spec.Template.Gateway.Autoscaling},
+		{templatePath.Child("metricsGenerator").Child("component").Child("autoscaling"), tempo.Spec.Template.MetricsGenerator.Autoscaling},
+		{field.NewPath("spec").Child("cache").Child("managed").Child("component").Child("autoscaling"), tempo.Spec.Cache.Managed.Autoscaling},
+	}
+	for _, component := range unsupported {
+		if component.spec.Enabled {
+			errs = append(errs, field.Forbidden(component.path.Child("enabled"), "autoscaling is not supported for this component"))
+		}
+	}
+
+	supported := []struct {
+		path *field.Path
+		spec AutoscalingSpec
+	}{
+		{templatePath.Child("distributor").Child("component").Child("autoscaling"), tempo.Spec.Template.Distributor.Autoscaling},
+		{templatePath.Child("ingester").Child("autoscaling"), tempo.Spec.Template.Ingester.Autoscaling},
+		{templatePath.Child("querier").Child("autoscaling"), tempo.Spec.Template.Querier.Autoscaling},
+		{templatePath.Child("queryFrontend").Child("component").Child("autoscaling"), tempo.Spec.Template.QueryFrontend.Autoscaling},
+		{templatePath.Child("compactor").Child("autoscaling"), tempo.Spec.Template.Compactor.Autoscaling},
+	}
+	for _, component := range supported {
+		spec := component.spec
+		if !spec.Enabled {
+			continue
+		}
+
+		minReplicas := int32(1)
+		if spec.MinReplicas != nil {
+			minReplicas = *spec.MinReplicas
+		}
+		if spec.MaxReplicas < minReplicas {
+			errs = append(errs, field.Invalid(component.path.Child("maxReplicas"), spec.MaxReplicas,
+				fmt.Sprintf("maxReplicas must be greater than or equal to minReplicas (%d)", minReplicas)))
+		}
+		if spec.TargetCPUUtilization == nil && spec.TargetMemoryUtilization == nil && len(spec.Metrics) == 0 {
+			errs = append(errs, field.Required(component.path,
+				"please configure at least one of targetCPUUtilization, targetMemoryUtilization or metrics"))
+		}
+	}
+
+	// Scaling the ingesters below the replication factor would leave traces under-replicated.
+	ingester := tempo.Spec.Template.Ingester.Autoscaling
+	if ingester.Enabled && ingester.MinReplicas != nil && int(*ingester.MinReplicas) < tempo.Spec.ReplicationFactor {
+		errs = append(errs, field.Invalid(templatePath.Child("ingester").Child("autoscaling").Child("minReplicas"), *ingester.MinReplicas,
+			fmt.Sprintf("replication factor of %d requires at least %d ingester replicas", tempo.Spec.ReplicationFactor, tempo.Spec.ReplicationFactor)))
+	}
+
+	return errs
+}
+
+func (v *validator) validateZoneAwareReplication(tempo TempoStack) field.ErrorList {
+	templatePath := field.NewPath("spec").Child("template")
+	var errs field.ErrorList
+
+	ingester := tempo.Spec.Template.Ingester
+	spec := ingester.ZoneAwareReplication
+	if !spec.Enabled {
+		return errs
+	}
+
+	path := templatePath.Child("ingester").Child("zoneAwareReplication")
+	if len(spec.Zones) < tempo.Spec.ReplicationFactor {
+		errs = append(errs, field.Invalid(path.Child("zones"), len(spec.Zones),
+			fmt.Sprintf("replication factor of %d requires at least %d zones", tempo.Spec.ReplicationFactor, tempo.Spec.ReplicationFactor)))
+	}
+
+	names := map[string]bool{}
+	for i, zone := range spec.Zones {
+		namePath := path.Child("zones").Index(i).Child("name")
+		if zone.Name == "" {
+			errs = append(errs, field.Required(namePath, "the name of the zone is required"))
+			continue
+		}
+		for _, msg := range validation.IsDNS1123Label(zone.Name) {
+			errs = append(errs, field.Invalid(namePath, zone.Name, msg))
+		}
+		if names[zone.Name] {
+			errs = append(errs, field.Duplicate(namePath, zone.Name))
+		}
+		names[zone.Name] = true
+	}
+
+	if ingester.Autoscaling.Enabled {
+		errs = append(errs, field.Forbidden(templatePath.Child("ingester").Child("autoscaling").Child("enabled"),
+			"autoscaling is not supported with zone-aware replication"))
+	}
+	if ingester.Replicas != nil && int(*ingester.Replicas) < len(spec.Zones) {
+		errs = append(errs, field.Invalid(templatePath.Child("ingester").Child("replicas"), *ingester.Replicas,
+			fmt.Sprintf("zone-aware replication requires at least one ingester per zone (%d)", len(spec.Zones))))
+	}
+
+	return errs
+}
+
+func (v *validator) validatePodDisruptionBudgets(tempo TempoStack) field.ErrorList {
+	templatePath := field.NewPath("spec").Child("template")
+	components := []struct {
+		path *field.Path
+		spec PodDisruptionBudgetSpec
+	}{
+		{templatePath.Child("distributor").Child("component"), tempo.Spec.Template.Distributor.PodDisruptionBudget},
+		{templatePath.Child("ingester"), tempo.Spec.Template.Ingester.PodDisruptionBudget},
+		{templatePath.Child("querier"), tempo.Spec.Template.Querier.PodDisruptionBudget},
+		{templatePath.Child("queryFrontend").Child("component"), tempo.Spec.Template.QueryFrontend.PodDisruptionBudget},
+		{templatePath.Child("compactor"), tempo.Spec.Template.Compactor.PodDisruptionBudget},
+		{templatePath.Child("gateway").Child("component"), tempo.Spec.Template.Gateway.PodDisruptionBudget},
+		{templatePath.Child("metricsGenerator").Child("component"), tempo.Spec.Template.MetricsGenerator.PodDisruptionBudget},
+		{field.NewPath("spec").Child("cache").Child("managed").Child("component"), tempo.Spec.Cache.Managed.PodDisruptionBudget},
+	}
+
+	var errs field.ErrorList
+	for _, component := range components {
+		if component.spec.MinAvailable != nil && component.spec.MaxUnavailable != nil {
+			errs = append(errs, field.Invalid(component.path.Child("podDisruptionBudget"), component.spec,
+				"only one of minAvailable and maxUnavailable can be set"))
+		}
+	}
+	return errs
+}
+
+// podTemplateComponents returns the pod template overrides of all components supporting them.
+func podTemplateComponents(tempo TempoStack) []struct {
+	path        *field.Path
+	podTemplate *PodTemplateSpec
+} {
+	templatePath := field.NewPath("spec").Child("template")
+	return []struct {
+		path        *field.Path
+		podTemplate *PodTemplateSpec
+	}{
+		{templatePath.Child("distributor").Child("component").Child("podTemplate"), tempo.Spec.Template.Distributor.PodTemplate},
+		{templatePath.Child("ingester").Child("podTemplate"), tempo.Spec.Template.Ingester.PodTemplate},
+		{templatePath.Child("querier").Child("podTemplate"), tempo.Spec.Template.Querier.PodTemplate},
+		{templatePath.Child("queryFrontend").Child("component").Child("podTemplate"), tempo.Spec.Template.QueryFrontend.PodTemplate},
+		{templatePath.Child("compactor").Child("podTemplate"), tempo.Spec.Template.Compactor.PodTemplate},
+		{templatePath.Child("gateway").Child("component").Child("podTemplate"), tempo.Spec.Template.Gateway.PodTemplate},
+		{templatePath.Child("metricsGenerator").Child("component").Child("podTemplate"), tempo.Spec.Template.MetricsGenerator.PodTemplate},
+	}
+}
+
+// validatePodTemplates validates the pod template overrides of the components.
+// The podTemplate field is not validated by the API server (it is schemaless in the CRD), therefore
+// the fields are validated here, before they are rejected when the operator applies the pod template.
+func (v *validator) validatePodTemplates(tempo TempoStack) field.ErrorList {
+	var errs field.ErrorList
+	if tempo.Spec.Cache.Managed.PodTemplate != nil {
+		errs = append(errs, field.Invalid(field.NewPath("spec").Child("cache").Child("managed").Child("component").Child("podTemplate"),
+			tempo.Spec.Cache.Managed.PodTemplate, "pod template overrides are not supported by the managed cache"))
+	}
+
+	// The env vars, volumes, volume mounts and sidecars are merged by their name (mount path for volume mounts).
+	for _, component := range podTemplateComponents(tempo) {
+		if component.podTemplate == nil {
+			continue
+		}
+		errs = append(errs, validatePodTemplate(component.path, *component.podTemplate)...)
+	}
+	return errs
+}
+
+func validatePodTemplate(path *field.Path, podTemplate PodTemplateSpec) field.ErrorList {
+	errs := metav1validation.ValidateLabels(podTemplate.Labels, path.Child("labels"))
+	errs = append(errs, apimachineryvalidation.ValidateAnnotations(podTemplate.Annotations, path.Child("annotations"))...)
+
+	if podTemplate.PriorityClassName != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(podTemplate.PriorityClassName) {
+			errs = append(errs, field.Invalid(path.Child("priorityClassName"), podTemplate.PriorityClassName, msg))
+		}
+	}
+
+	for i, constraint := range podTemplate.TopologySpreadConstraints {
+		constraintPath := path.Child("topologySpreadConstraints").Index(i)
+		if constraint.MaxSkew <= 0 {
+			errs = append(errs, field.Invalid(constraintPath.Child("maxSkew"), constraint.MaxSkew, "must be greater than zero"))
+		}
+		if constraint.TopologyKey == "" {
+			errs = append(errs, field.Required(constraintPath.Child("topologyKey"), "the topology key is required"))
+		}
+		if constraint.WhenUnsatisfiable != corev1.DoNotSchedule && constraint.WhenUnsatisfiable != corev1.ScheduleAnyway {
+			errs = append(errs, field.NotSupported(constraintPath.Child("whenUnsatisfiable"), constraint.WhenUnsatisfiable,
+				[]string{string(corev1.DoNotSchedule), string(corev1.ScheduleAnyway)}))
+		}
+	}
+
+	for i, env := range podTemplate.Env {
+		envPath := path.Child("env").Index(i).Child("name")
+		if env.Name == "" {
+			errs = append(errs, field.Required(envPath, "the name of the env var is required"))
+			continue
+		}
+		for _, msg := range validation.IsEnvVarName(env.Name) {
+			errs = append(errs, field.Invalid(envPath, env.Name, msg))
+		}
+	}
+
+	for i, volume := range podTemplate.Volumes {
+		volumePath := path.Child("volumes").Index(i)
+		if volume.Name == "" {
+			errs = append(errs, field.Required(volumePath.Child("name"), "the name of the volume is required"))
+		} else {
+			for _, msg := range validation.IsDNS1123Label(volume.Name) {
+				errs = append(errs, field.Invalid(volumePath.Child("name"), volume.Name, msg))
+			}
+		}
+		if countVolumeSources(volume.VolumeSource) != 1 {
+			errs = append(errs, field.Invalid(volumePath, volume.Name, "exactly one volume source must be set"))
+		}
+	}
+
+	for i, volumeMount := range podTemplate.VolumeMounts {
+		volumeMountPath := path.Child("volumeMounts").Index(i)
+		if volumeMount.Name == "" {
+			errs = append(errs, field.Required(volumeMountPath.Child("name"), "the name of the volume is required"))
+		}
+		if volumeMount.MountPath == "" {
+			errs = append(errs, field.Required(volumeMountPath.Child("mountPath"), "the mount path of the volume mount is required"))
+		}
+	}
+
+	for i, sidecar := range podTemplate.Sidecars {
+		sidecarPath := path.Child("sidecars").Index(i)
+		if sidecar.Name == "" {
+			errs = append(errs, field.Required(sidecarPath.Child("name"), "the name of the sidecar is required"))
+		} else {
+			for _, msg := range validation.IsDNS1123Label(sidecar.Name) {
+				errs = append(errs, field.Invalid(sidecarPath.Child("name"), sidecar.Name, msg))
+			}
+		}
+		if sidecar.Image == "" {
+			errs = append(errs, field.Required(sidecarPath.Child("image"), "the image of the sidecar is required"))
+		}
+	}
+	return errs
+}
+
+// countVolumeSources returns the number of volume sources which are set.
+func countVolumeSources(source corev1.VolumeSource) int {
+	count := 0
+	value := reflect.ValueOf(source)
+	for i := 0; i < value.NumField(); i++ {
+		if !value.Field(i).IsNil() {
+			count++
+		}
+	}
+	return count
+}
+
+// validatePodTemplateFields rejects unknown fields in the pod template overrides, for example misspelled fields,
+// which would be dropped silently, because the podTemplate field is schemaless in the CRD.
+// The fields can only be checked in the raw object of the admission request.
+func (v *validator) validatePodTemplateFields(ctx context.Context, tempo TempoStack) field.ErrorList {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil || len(req.Object.Raw) == 0 {
+		return nil
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(req.Object.Raw, &raw); err != nil {
+		return nil
+	}
+
+	var errs field.ErrorList
+	for _, component := range podTemplateComponents(tempo) {
+		if component.podTemplate == nil {
+			continue
+		}
+
+		value, found, err := unstructured.NestedFieldNoCopy(raw, strings.Split(component.path.String(), ".")...)
+		if err != nil || !found {
+			continue
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			continue
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&PodTemplateSpec{}); err != nil {
+			errs = append(errs, field.Invalid(component.path, component.podTemplate, fmt.Sprintf("invalid pod template: %s", err)))
+		}
+	}
+	return errs
+}
+
+// validateResources validates that the explicit resources of the components don't exceed the total resources.
+func (v *validator) validateResources(tempo TempoStack) field.ErrorList {
+	var errs field.ErrorList
+	total := tempo.Spec.Resources.Total
+	if total == nil {
+		return errs
+	}
+
+	templatePath := field.NewPath("spec").Child("template")
+	components := []struct {
+		path      *field.Path
+		resources *corev1.ResourceRequirements
+	}{
+		{templatePath.Child("distributor").Child("component").Child("resources"), tempo.Spec.Template.Distributor.Resources},
+		{templatePath.Child("ingester").Child("resources"), tempo.Spec.Template.Ingester.Resources},
+		{templatePath.Child("querier").Child("resources"), tempo.Spec.Template.Querier.Resources},
+		{templatePath.Child("queryFrontend").Child("component").Child("resources"), tempo.Spec.Template.QueryFrontend.Resources},
+		{templatePath.Child("queryFrontend").Child("jaegerQuery").Child("resources"), tempo.Spec.Template.QueryFrontend.JaegerQuery.Resources},
+		{templatePath.Child("compactor").Child("resources"), tempo.Spec.Template.Compactor.Resources},
+		{templatePath.Child("gateway").Child("component").Child("resources"), tempo.Spec.Template.Gateway.Resources},
+		{templatePath.Child("gateway").Child("opa").Child("resources"), tempo.Spec.Template.Gateway.OPA.Resources},
+		{templatePath.Child("metricsGenerator").Child("component").Child("resources"), tempo.Spec.Template.MetricsGenerator.Resources},
+	}
+
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		limit, ok := total.Limits[name]
+		if !ok {
+			continue
+		}
+
+		sum := resource.Quantity{}
+		for _, component := range components {
+			if component.resources == nil {
+				continue
+			}
+
+			quantity, ok := component.resources.Limits[name]
+			if !ok {
+				quantity = component.resources.Requests[name]
+			}
+			sum.Add(quantity)
+			if sum.Cmp(limit) > 0 {
+				errs = append(errs, field.Invalid(component.path, component.resources,
+					fmt.Sprintf("the %s resources of the components exceed the total %s limit of %s", name, name, limit.String())))
+				break
+			}
+		}
+	}
+	return errs
+}
+
+// validatePerTenantRetention warns about per-tenant retention of tenants, which are not defined in spec.tenants.
+// The tenant ID is the tenant identifier used by Tempo, therefore the keys of the per-tenant retention must match a tenant ID.
+// Without a tenant list (multi-tenancy without the gateway), any tenant ID can be used.
+func (v *validator) validatePerTenantRetention(tempo TempoStack) admission.Warnings {
+	if len(tempo.Spec.Retention.PerTenant) == 0 {
+		return nil
+	}
+	if tempo.Spec.Tenants != nil && len(tempo.Spec.Tenants.Authentication) == 0 {
+		return nil
+	}
+
+	tenantIDs := map[string]bool{}
+	if tempo.Spec.Tenants != nil {
+		for _, tenant := range tempo.Spec.Tenants.Authentication {
+			tenantIDs[tenant.TenantID] = true
+		}
+	}
+
+	tenants := make([]string, 0, len(tempo.Spec.Retention.PerTenant))
+	for tenant := range tempo.Spec.Retention.PerTenant {
+		tenants = append(tenants, tenant)
+	}
+	sort.Strings(tenants)
+
+	var warnings admission.Warnings
+	path := field.NewPath("spec").Child("retention").Child("perTenant")
+	for _, tenant := range tenants {
+		if !tenantIDs[tenant] {
+			warnings = append(warnings, fmt.Sprintf("%s: tenant '%s' is not defined in spec.tenants, its retention has no effect", path.Key(tenant), tenant))
+		}
+	}
+	return warnings
+}
+
+var (
+	// managedTempoConfigKeys are settings of tempo.yaml, which are managed by the operator.
+	// Overriding them with the extra configuration can break the deployment.
+	managedTempoConfigKeys = []string{
+		"distributor.receivers",
+		"ingester.lifecycler.ring.replication_factor",
+		"ingester.lifecycler.tokens_file_path",
+		"ingester_client.grpc_client_config",
+		"internal_server",
+		"memberlist.join_members",
+		"metrics_generator.storage.path",
+		"metrics_generator.storage.remote_write",
+		"metrics_generator.traces_storage.path",
+		"metrics_generator_client.grpc_client_config",
+		"multitenancy_enabled",
+		"overrides.per_tenant_override_config",
+		"querier.frontend_worker",
+		"server.grpc_tls_config",
+		"server.http_listen_port",
+		"server.http_tls_config",
+		"storage.trace.azure",
+		"storage.trace.backend",
+		"storage.trace.gcs",
+		"storage.trace.local.path",
+		"storage.trace.s3",
+		"storage.trace.wal.path",
+	}
+	// managedTempoQueryConfigKeys are settings of tempo-query.yaml, which are managed by the operator.
+	managedTempoQueryConfigKeys = []string{
+		"backend",
+		"tenant_header_key",
+		"tls_ca_path",
+		"tls_cert_path",
+		"tls_enabled",
+		"tls_key_path",
+		"tls_server_name",
+	}
+)
+
+func (v *validator) validateExtraConfig(tempo TempoStack) (admission.Warnings, field.ErrorList) {
+	if tempo.Spec.ExtraConfig == nil {
+		return nil, nil
+	}
+
+	path := field.NewPath("spec").Child("extraConfig")
+	configs := []struct {
+		path        *field.Path
+		config      apiextensionsv1.JSON
+		managedKeys []string
+	}{
+		{path.Child("tempo"), tempo.Spec.ExtraConfig.Tempo, managedTempoConfigKeys},
+		{path.Child("tempoQuery"), tempo.Spec.ExtraConfig.TempoQuery, managedTempoQueryConfigKeys},
+	}
+
+	var warnings admission.Warnings
+	var errs field.ErrorList
+	for _, cfg := range configs {
+		if len(cfg.config.Raw) == 0 {
+			continue
+		}
+
+		extraConfig := map[string]interface{}{}
+		if err := json.Unmarshal(cfg.config.Raw, &extraConfig); err != nil {
+			errs = append(errs, field.Invalid(cfg.path, string(cfg.config.Raw), "extra configuration must be an object"))
+			continue
+		}
+
+		for _, key := range overriddenKeys(extraConfig, cfg.managedKeys) {
+			warnings = append(warnings, fmt.Sprintf("%s overrides the operator-managed setting '%s', this can result in a non-functional deployment", cfg.path, key))
+		}
+	}
+	return warnings, errs
+}
+
+// overriddenKeys returns the managed keys (in dot notation) which are set in the given configuration.
+// A managed key is overridden if the configuration sets the key itself, any of its children
+// or replaces one of its parents with a non-map value.
+func overriddenKeys(config map[string]interface{}, managedKeys []string) []string {
+	var leaves []string
+	var collect func(prefix string, config map[string]interface{})
+	collect = func(prefix string, config map[string]interface{}) {
+		for key, value := range config {
+			if child, ok := value.(map[string]interface{}); ok && len(child) > 0 {
+				collect(prefix+key+".", child)
+			} else {
+				leaves = append(leaves, prefix+key)
+			}
+		}
+	}
+	collect("", config)
+
+	var overridden []string
+	for _, managedKey := range managedKeys {
+		for _, leaf := range leaves {
+			if leaf == managedKey || strings.HasPrefix(leaf, managedKey+".") || strings.HasPrefix(managedKey, leaf+".") {
+				overridden = append(overridden, managedKey)
+				break
+			}
+		}
+	}
+	sort.Strings(overridden)
+	return overridden
+}
+
+func (v *validator) validate(ctx context.Context, oldObj, obj runtime.Object) (admission.Warnings, error) {
 	tempo, ok := obj.(*TempoStack)
 	if !ok {
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a TempoStack object but got %T", obj))
@@ -450,6 +1054,9 @@ func (v *validator) validate(ctx context.Context, obj runtime.Object) (admission
 		allErrors = append(allErrors, errors...)
 	}
 
+	if oldObj == nil {
+		allErrors = append(allErrors, v.validateStorageLocation(ctx, *tempo)...)
+	}
 	allErrors = append(allErrors, v.validateReplicationFactor(*tempo)...)
 	allErrors = append(allErrors, v.validateQueryFrontend(*tempo)...)
 	allErrors = append(allErrors, v.validateGateway(*tempo)...)
@@ -457,6 +1064,35 @@ func (v *validator) validate(ctx context.Context, obj runtime.Object) (admission
 	allErrors = append(allErrors, v.validateObservability(*tempo)...)
 	allErrors = append(allErrors, v.validateDeprecatedFields(*tempo)...)
 	allErrors = append(allErrors, v.validateReceiverTLS(*tempo)...)
+	allErrors = append(allErrors, v.validateMetricsGenerator(*tempo)...)
+	allErrors = append(allErrors, v.validateCache(*tempo)...)
+	allErrors = append(allErrors, v.validateAutoscaling(*tempo)...)
+	allErrors = append(allErrors, v.validateZoneAwareReplication(*tempo)...)
+	allErrors = append(allErrors, v.validatePodDisruptionBudgets(*tempo)...)
+	allErrors = append(allErrors, v.validateResources(*tempo)...)
+	allErrors = append(allErrors, v.validatePodTemplates(*tempo)...)
+	allErrors = append(allErrors, v.validatePodTemplateFields(ctx, *tempo)...)
+
+	if oldObj != nil {
+		oldTempo, ok := oldObj.(*TempoStack)
+		if !ok {
+			return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a TempoStack object but got %T", oldObj))
+		}
+		allErrors = append(allErrors, v.validateStorageSize(*oldTempo, *tempo)...)
+		allErrors = append(allErrors, v.validateZoneAwareReplicationUpdate(*oldTempo, *tempo)...)
+		allWarnings = append(allWarnings, v.validateMemberListSecurityUpdate(*oldTempo, *tempo)...)
+
+		// Listing all instances is expensive, therefore the storage location is only validated if it changed.
+		if oldTempo.Spec.Storage.Secret != tempo.Spec.Storage.Secret || oldTempo.Spec.Storage.Prefix != tempo.Spec.Storage.Prefix {
+			allErrors = append(allErrors, v.validateStorageLocation(ctx, *tempo)...)
+		}
+	}
+
+	allWarnings = append(allWarnings, v.validatePerTenantRetention(*tempo)...)
+
+	warnings, errors = v.validateExtraConfig(*tempo)
+	allWarnings = append(allWarnings, warnings...)
+	allErrors = append(allErrors, errors...)
 
 	if len(allErrors) == 0 {
 		return allWarnings, nil
@@ -464,6 +1100,90 @@ func (v *validator) validate(ctx context.Context, obj runtime.Object) (admission
 	return allWarnings, apierrors.NewInvalid(tempo.GroupVersionKind().GroupKind(), tempo.Name, allErrors)
 }
 
+// memberListSecuritySteps lists the memberlist security settings in the order of the switch-over
+// from the legacy memberlist configuration. Every step can be rolled out without splitting the ring,
+// except for the step to Secure if the grpcEncryption feature gate is enabled,
+// because members with and without TLS can't gossip with each other.
+var memberListSecuritySteps = []MemberListSecurity{
+	MemberListSecurityLegacy,
+	MemberListSecurityLabelVerificationDisabled,
+	MemberListSecurityClusterLabelUnverified,
+	MemberListSecurityClusterLabel,
+	MemberListSecuritySecure,
+}
+
+// validateMemberListSecurityUpdate warns if a change of the memberlist security skips a step of the switch-over.
+// Members with and without cluster label verification or TLS can't gossip with each other,
+// therefore skipping a step splits the ring until all components are restarted.
+func (v *validator) validateMemberListSecurityUpdate(oldTempo, tempo TempoStack) admission.Warnings {
+	step := func(security MemberListSecurity) int {
+		if security == "" {
+			security = MemberListSecuritySecure
+		}
+		for i, s := range memberListSecuritySteps {
+			if s == security {
+				return i
+			}
+		}
+		return -1
+	}
+
+	oldStep := step(oldTempo.Spec.HashRing.MemberList.Security)
+	newStep := step(tempo.Spec.HashRing.MemberList.Security)
+	if oldStep < 0 || newStep < 0 || math.Abs(float64(newStep-oldStep)) <= 1 {
+		return nil
+	}
+
+	return admission.Warnings{fmt.Sprintf(
+		"changing spec.hashRing.memberlist.security from %s to %s skips steps of the switch-over, the ring is split until all components are restarted",
+		memberListSecuritySteps[oldStep], memberListSecuritySteps[newStep],
+	)}
+}
+
+// validateStorageSize rejects decreasing the storage size of the ingester volumes,
+// because persistent volumes can only be expanded.
+func (v *validator) validateStorageSize(oldTempo, tempo TempoStack) field.ErrorList {
+	if oldTempo.Spec.StorageSize.IsZero() || tempo.Spec.StorageSize.Cmp(oldTempo.Spec.StorageSize) >= 0 {
+		return nil
+	}
+
+	return field.ErrorList{field.Invalid(
+		field.NewPath("spec").Child("storageSize"),
+		tempo.Spec.StorageSize.String(),
+		fmt.Sprintf("the storage size cannot be decreased from %s", oldTempo.Spec.StorageSize.String()),
+	)}
+}
+
+// validateZoneAwareReplicationUpdate rejects enabling or disabling zone-aware replication and changing the zones
+// of an existing TempoStack, because the ingester StatefulSets of the zones would be replaced
+// without flushing the traces in the write-ahead log of the removed ingesters.
+func (v *validator) validateZoneAwareReplicationUpdate(oldTempo, tempo TempoStack) field.ErrorList {
+	oldSpec := oldTempo.Spec.Template.Ingester.ZoneAwareReplication
+	spec := tempo.Spec.Template.Ingester.ZoneAwareReplication
+	path := field.NewPath("spec").Child("template").Child("ingester").Child("zoneAwareReplication")
+
+	if oldSpec.Enabled != spec.Enabled {
+		return field.ErrorList{field.Forbidden(path.Child("enabled"),
+			"zone-aware replication cannot be enabled or disabled after the TempoStack is created")}
+	}
+	if !spec.Enabled {
+		return nil
+	}
+
+	zoneNames := func(zones []ZoneSpec) []string {
+		names := make([]string, len(zones))
+		for i, zone := range zones {
+			names[i] = zone.Name
+		}
+		return names
+	}
+	if !reflect.DeepEqual(zoneNames(oldSpec.Zones), zoneNames(spec.Zones)) {
+		return field.ErrorList{field.Forbidden(path.Child("zones"),
+			"the names and the order of the zones cannot be changed after the TempoStack is created")}
+	}
+	return nil
+}
+
 // ValidateTenantConfigs validates the tenants mode specification.
 func ValidateTenantConfigs(tempo TempoStack) error {
 	if tempo.Spec.Tenants == nil {
@@ -483,6 +1203,10 @@ func ValidateTenantConfigs(tempo TempoStack) error {
 				return fmt.Errorf("spec.tenants.authorization is required in static mode")
 			}
 
+			if tenants.Authorization.OPA != nil {
+				return validateTenantsOPA(*tenants.Authorization)
+			}
+
 			if tenants.Authorization.Roles == nil {
 				return fmt.Errorf("spec.tenants.authorization.roles is required in static mode")
 			}
@@ -506,3 +1230,16 @@ func ValidateTenantConfigs(tempo TempoStack) error {
 	}
 	return nil
 }
+
+// validateTenantsOPA validates the external OPA endpoint, which replaces the static roles and role bindings.
+func validateTenantsOPA(authorization AuthorizationSpec) error {
+	if len(authorization.Roles) > 0 || len(authorization.RoleBindings) > 0 {
+		return fmt.Errorf("spec.tenants.authorization.opa is mutually exclusive with spec.tenants.authorization.roles and spec.tenants.authorization.roleBindings")
+	}
+
+	u, err := url.Parse(authorization.OPA.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("spec.tenants.authorization.opa.url must be a valid http or https URL")
+	}
+	return nil
+}
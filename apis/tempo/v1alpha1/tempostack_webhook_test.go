@@ -7,14 +7,19 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/grafana/tempo-operator/apis/config/v1alpha1"
 	"github.com/grafana/tempo-operator/internal/manifests/naming"
@@ -113,8 +118,10 @@ func TestDefault(t *testing.T) {
 							},
 							TLS: ReceiversTLSSpec{},
 						},
-						Ingester: TempoComponentSpec{
-							Replicas: ptr.To(int32(1)),
+						Ingester: TempoIngesterSpec{
+							TempoComponentSpec: TempoComponentSpec{
+								Replicas: ptr.To(int32(1)),
+							},
 						},
 						Querier: TempoComponentSpec{
 							Replicas: ptr.To(int32(1)),
@@ -174,8 +181,10 @@ func TestDefault(t *testing.T) {
 							},
 							TLS: ReceiversTLSSpec{},
 						},
-						Ingester: TempoComponentSpec{
-							Replicas: ptr.To(int32(1)),
+						Ingester: TempoIngesterSpec{
+							TempoComponentSpec: TempoComponentSpec{
+								Replicas: ptr.To(int32(1)),
+							},
 						},
 						Querier: TempoComponentSpec{
 							Replicas: ptr.To(int32(1)),
@@ -240,8 +249,10 @@ func TestDefault(t *testing.T) {
 							},
 							TLS: ReceiversTLSSpec{},
 						},
-						Ingester: TempoComponentSpec{
-							Replicas: ptr.To(int32(1)),
+						Ingester: TempoIngesterSpec{
+							TempoComponentSpec: TempoComponentSpec{
+								Replicas: ptr.To(int32(1)),
+							},
 						},
 						Querier: TempoComponentSpec{
 							Replicas: ptr.To(int32(1)),
@@ -275,6 +286,52 @@ func TestDefault(t *testing.T) {
 	}
 }
 
+func TestDefaultMetricsGenerator(t *testing.T) {
+	defaulter := &Defaulter{}
+	tempo := &TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: TempoStackSpec{
+			Template: TempoTemplateSpec{
+				MetricsGenerator: TempoMetricsGeneratorSpec{
+					Enabled: true,
+				},
+			},
+		},
+	}
+
+	err := defaulter.Default(context.Background(), tempo)
+	require.NoError(t, err)
+	assert.Equal(t, ptr.To(int32(1)), tempo.Spec.Template.MetricsGenerator.Replicas)
+	assert.Equal(t, []MetricsGeneratorProcessor{
+		MetricsGeneratorProcessorSpanMetrics,
+		MetricsGeneratorProcessorServiceGraphs,
+	}, tempo.Spec.Template.MetricsGenerator.Processors)
+}
+
+func TestDefaultManagedCache(t *testing.T) {
+	defaulter := &Defaulter{}
+	tempo := &TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: TempoStackSpec{
+			Cache: CacheSpec{
+				Backend: CacheBackendMemcached,
+				Managed: ManagedCacheSpec{
+					Enabled: true,
+				},
+			},
+		},
+	}
+
+	err := defaulter.Default(context.Background(), tempo)
+	require.NoError(t, err)
+	assert.Equal(t, ptr.To(int32(1)), tempo.Spec.Cache.Managed.Replicas)
+	assert.Equal(t, 1024, tempo.Spec.Cache.Managed.MemoryLimitMB)
+}
+
 func TestValidateStorageSecret(t *testing.T) {
 	tempoAzure := TempoStack{
 		Spec: TempoStackSpec{
@@ -297,6 +354,40 @@ func TestValidateStorageSecret(t *testing.T) {
 		},
 	}
 
+	tempoS3Token := TempoStack{
+		Spec: TempoStackSpec{
+			Storage: ObjectStorageSpec{
+				Secret: ObjectStorageSecretSpec{
+					Name: "testsecret",
+					Type: "s3",
+				},
+				CredentialMode: CredentialModeToken,
+			},
+		},
+	}
+	tempoAzureToken := TempoStack{
+		Spec: TempoStackSpec{
+			Storage: ObjectStorageSpec{
+				Secret: ObjectStorageSecretSpec{
+					Name: "testsecret",
+					Type: "azure",
+				},
+				CredentialMode: CredentialModeToken,
+			},
+		},
+	}
+	tempoGCSToken := TempoStack{
+		Spec: TempoStackSpec{
+			Storage: ObjectStorageSpec{
+				Secret: ObjectStorageSecretSpec{
+					Name: "testsecret",
+					Type: "gcs",
+				},
+				CredentialMode: CredentialModeToken,
+			},
+		},
+	}
+
 	tempoUnknown := TempoStack{
 		Spec: TempoStackSpec{
 			Storage: ObjectStorageSpec{
@@ -375,6 +466,50 @@ func TestValidateStorageSecret(t *testing.T) {
 				field.Invalid(path, tempoAzure.Spec.Storage.Secret, "storage secret must contain \"account_key\" field"),
 			},
 		},
+		{
+			name:  "Azure secret with custom endpoint",
+			tempo: tempoAzure,
+			input: corev1.Secret{
+				Data: map[string][]byte{
+					"container":    []byte("container-test"),
+					"account_name": []byte("devstoreaccount1"),
+					"account_key":  []byte("key"),
+					"endpoint":     []byte("http://azurite:10000"),
+				},
+			},
+			expected: nil,
+		},
+		{
+			name:  "Azure secret with endpoint and endpoint suffix",
+			tempo: tempoAzure,
+			input: corev1.Secret{
+				Data: map[string][]byte{
+					"container":       []byte("container-test"),
+					"account_name":    []byte("account"),
+					"account_key":     []byte("key"),
+					"endpoint":        []byte("http://azurite:10000"),
+					"endpoint_suffix": []byte("blob.core.chinacloudapi.cn"),
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(path, tempoAzure.Spec.Storage.Secret, "storage secret must not contain both the \"endpoint\" and the \"endpoint_suffix\" field"),
+			},
+		},
+		{
+			name:  "Azure secret with invalid endpoint",
+			tempo: tempoAzure,
+			input: corev1.Secret{
+				Data: map[string][]byte{
+					"container":    []byte("container-test"),
+					"account_name": []byte("account"),
+					"account_key":  []byte("key"),
+					"endpoint":     []byte("azurite"),
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(path, tempoAzure.Spec.Storage.Secret, "\"endpoint\" field of storage secret must be a valid URL"),
+			},
+		},
 		{
 			name:  "empty S3 secret",
 			tempo: tempoS3,
@@ -441,6 +576,182 @@ func TestValidateStorageSecret(t *testing.T) {
 			},
 			expected: nil,
 		},
+		{
+			name:  "valid S3 secret with region and path-style addressing",
+			tempo: tempoS3,
+			input: corev1.Secret{
+				Data: map[string][]byte{
+					"endpoint":          []byte("http://minio.minio.svc:9000"),
+					"bucket":            []byte("bucket"),
+					"access_key_id":     []byte("id"),
+					"access_key_secret": []byte("secret"),
+					"region":            []byte("eu-central-1"),
+					"forcepathstyle":    []byte("true"),
+				},
+			},
+			expected: nil,
+		},
+		{
+			name:  "invalid S3 path-style addressing",
+			tempo: tempoS3,
+			input: corev1.Secret{
+				Data: map[string][]byte{
+					"endpoint":          []byte("http://minio.minio.svc:9000"),
+					"bucket":            []byte("bucket"),
+					"access_key_id":     []byte("id"),
+					"access_key_secret": []byte("secret"),
+					"forcepathstyle":    []byte("yes"),
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(path, tempoS3.Spec.Storage.Secret, "\"forcepathstyle\" field of storage secret must be a boolean"),
+			},
+		},
+		{
+			name:  "unsupported S3 server-side encryption",
+			tempo: tempoS3Token,
+			input: corev1.Secret{
+				Data: map[string][]byte{
+					"bucket":     []byte("bucket"),
+					"region":     []byte("eu-central-1"),
+					"role_arn":   []byte("arn:aws:iam::123456789012:role/tempo"),
+					"sse_type":   []byte("SSE-KMS"),
+					"kms_key_id": []byte("key"),
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(path, tempoS3Token.Spec.Storage.Secret, "\"sse_type\" field of storage secret is not supported, because Tempo 2.3 has no server-side encryption settings for S3 (configure the default encryption of the bucket instead)"),
+				field.Invalid(path, tempoS3Token.Spec.Storage.Secret, "\"kms_key_id\" field of storage secret is not supported, because Tempo 2.3 has no server-side encryption settings for S3 (configure the default encryption of the bucket instead)"),
+			},
+		},
+		{
+			name:  "missing or empty fields in S3 token secret",
+			tempo: tempoS3Token,
+			input: corev1.Secret{
+				Data: map[string][]byte{
+					"bucket": []byte("bucket"),
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(path, tempoS3Token.Spec.Storage.Secret, "storage secret must contain \"region\" field"),
+				field.Invalid(path, tempoS3Token.Spec.Storage.Secret, "storage secret must contain \"role_arn\" field"),
+			},
+		},
+		{
+			name:  "valid S3 token secret",
+			tempo: tempoS3Token,
+			input: corev1.Secret{
+				Data: map[string][]byte{
+					"bucket":   []byte("bucket"),
+					"region":   []byte("eu-central-1"),
+					"role_arn": []byte("arn:aws:iam::123456789012:role/tempo"),
+				},
+			},
+			expected: nil,
+		},
+		{
+			name:  "missing or empty fields in Azure token secret",
+			tempo: tempoAzureToken,
+			input: corev1.Secret{
+				Data: map[string][]byte{
+					"container":    []byte("container-test"),
+					"account_name": []byte("account"),
+					"client_id":    []byte("client"),
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(path, tempoAzureToken.Spec.Storage.Secret, "storage secret must contain \"tenant_id\" field"),
+				field.Invalid(path, tempoAzureToken.Spec.Storage.Secret, "storage secret must contain \"subscription_id\" field"),
+			},
+		},
+		{
+			name:  "valid Azure token secret",
+			tempo: tempoAzureToken,
+			input: corev1.Secret{
+				Data: map[string][]byte{
+					"container":       []byte("container-test"),
+					"account_name":    []byte("account"),
+					"client_id":       []byte("client"),
+					"tenant_id":       []byte("tenant"),
+					"subscription_id": []byte("subscription"),
+				},
+			},
+			expected: nil,
+		},
+		{
+			name:  "GCS token mode with GKE workload identity",
+			tempo: tempoGCSToken,
+			input: corev1.Secret{
+				Data: map[string][]byte{
+					"bucketname":          []byte("bucket"),
+					"iam_service_account": []byte("tempo@project.iam.gserviceaccount.com"),
+				},
+			},
+			expected: nil,
+		},
+		{
+			name:  "GCS token mode with custom endpoint",
+			tempo: tempoGCSToken,
+			input: corev1.Secret{
+				Data: map[string][]byte{
+					"bucketname": []byte("bucket"),
+					"endpoint":   []byte("https://fake-gcs-server:4443/storage/v1/"),
+				},
+			},
+			expected: nil,
+		},
+		{
+			name:  "GCS token mode with invalid endpoint",
+			tempo: tempoGCSToken,
+			input: corev1.Secret{
+				Data: map[string][]byte{
+					"bucketname": []byte("bucket"),
+					"endpoint":   []byte("fake-gcs-server"),
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(path, tempoGCSToken.Spec.Storage.Secret, "\"endpoint\" field of storage secret must be a valid URL"),
+			},
+		},
+		{
+			name:  "GCS token mode with workload identity federation",
+			tempo: tempoGCSToken,
+			input: corev1.Secret{
+				Data: map[string][]byte{
+					"bucketname": []byte("bucket"),
+					"key.json":   []byte(`{"type":"external_account","audience":"//iam.googleapis.com/projects/1/locations/global/workloadIdentityPools/pool/providers/provider","credential_source":{"file":"/var/run/secrets/storage/serviceaccount/token"}}`),
+				},
+			},
+			expected: nil,
+		},
+		{
+			name:  "GCS token mode with service account key",
+			tempo: tempoGCSToken,
+			input: corev1.Secret{
+				Data: map[string][]byte{
+					"bucketname": []byte("bucket"),
+					"key.json":   []byte(`{"type":"service_account","credential_source":{"file":"/tmp/token"}}`),
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(path, tempoGCSToken.Spec.Storage.Secret, "\"key.json\" field of storage secret must be a credential configuration of type \"external_account\""),
+				field.Invalid(path, tempoGCSToken.Spec.Storage.Secret, "\"key.json\" field of storage secret must specify the audience of the workload identity provider"),
+				field.Invalid(path, tempoGCSToken.Spec.Storage.Secret, "\"key.json\" field of storage secret must use the credential source file /var/run/secrets/storage/serviceaccount/token"),
+			},
+		},
+		{
+			name:  "GCS token mode with invalid credential configuration",
+			tempo: tempoGCSToken,
+			input: corev1.Secret{
+				Data: map[string][]byte{
+					"key.json": []byte("invalid"),
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(path, tempoGCSToken.Spec.Storage.Secret, "storage secret must contain \"bucketname\" field"),
+				field.Invalid(path, tempoGCSToken.Spec.Storage.Secret, "\"key.json\" field of storage secret must be a valid JSON document"),
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -451,6 +762,100 @@ func TestValidateStorageSecret(t *testing.T) {
 	}
 }
 
+func TestValidateStorageSecretEndpointScheme(t *testing.T) {
+	path := field.NewPath("spec").Child("storage").Child("secret")
+	azureSecret := ObjectStorageSecretSpec{Name: "testsecret", Type: ObjectStorageSecretAzure}
+	gcsSecret := ObjectStorageSecretSpec{Name: "testsecret", Type: ObjectStorageSecretGCS}
+	azureData := func(key, value string) map[string][]byte {
+		return map[string][]byte{
+			"container":    []byte("container"),
+			"account_name": []byte("account"),
+			"account_key":  []byte("key"),
+			key:            []byte(value),
+		}
+	}
+
+	tests := []struct {
+		name     string
+		storage  ObjectStorageSpec
+		input    map[string][]byte
+		expected field.ErrorList
+	}{
+		{
+			name:    "Azure https endpoint of an Azure cloud",
+			storage: ObjectStorageSpec{Secret: azureSecret, TLS: ObjectStorageTLSSpec{CA: "custom-ca"}},
+			input:   azureData("endpoint", "https://blob.core.chinacloudapi.cn"),
+		},
+		{
+			name:    "Azure http endpoint of Azurite",
+			storage: ObjectStorageSpec{Secret: azureSecret},
+			input:   azureData("endpoint", "http://azurite:10000"),
+		},
+		{
+			name:    "Azure https endpoint accessed over plain HTTP by Tempo",
+			storage: ObjectStorageSpec{Secret: azureSecret},
+			input:   azureData("endpoint", "https://azurite:10000"),
+			expected: field.ErrorList{
+				field.Invalid(path, azureSecret, "\"endpoint\" field of storage secret must start with https:// if the host starts with \"blob.core\" and with http:// otherwise, because Tempo uses plain HTTP for all other endpoints"),
+			},
+		},
+		{
+			name:    "Azure http endpoint accessed over HTTPS by Tempo",
+			storage: ObjectStorageSpec{Secret: azureSecret},
+			input:   azureData("endpoint", "http://blob.core.chinacloudapi.cn"),
+			expected: field.ErrorList{
+				field.Invalid(path, azureSecret, "\"endpoint\" field of storage secret must start with https:// if the host starts with \"blob.core\" and with http:// otherwise, because Tempo uses plain HTTP for all other endpoints"),
+			},
+		},
+		{
+			name:    "Azure CA with an endpoint suffix accessed over plain HTTP",
+			storage: ObjectStorageSpec{Secret: azureSecret, TLS: ObjectStorageTLSSpec{CA: "custom-ca"}},
+			input:   azureData("endpoint_suffix", "azurite:10000"),
+			expected: field.ErrorList{
+				field.Invalid(path, azureSecret, "a CA is configured, but Tempo accesses Azure endpoints whose host does not start with \"blob.core\" over plain HTTP"),
+			},
+		},
+		{
+			name:    "GCS CA with an http endpoint",
+			storage: ObjectStorageSpec{Secret: gcsSecret, TLS: ObjectStorageTLSSpec{CA: "custom-ca"}},
+			input: map[string][]byte{
+				"bucketname": []byte("bucket"),
+				"key.json":   []byte("{}"),
+				"endpoint":   []byte("http://fake-gcs-server:4443/storage/v1/"),
+			},
+			expected: field.ErrorList{
+				field.Invalid(path, gcsSecret, "a CA is configured, but the \"endpoint\" field of storage secret uses plain HTTP"),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := ValidateObjectStorageSecret(path, test.storage, corev1.Secret{Data: test.input})
+			assert.Equal(t, test.expected, errs)
+		})
+	}
+}
+
+func TestAzureEndpointSuffixUsesHTTPS(t *testing.T) {
+	tests := []struct {
+		endpointSuffix string
+		expected       bool
+	}{
+		{endpointSuffix: "", expected: true},
+		{endpointSuffix: "blob.core.windows.net", expected: true},
+		{endpointSuffix: "blob.core.chinacloudapi.cn", expected: true},
+		{endpointSuffix: "azurite:10000", expected: false},
+		{endpointSuffix: "storage.example.com/blob.core", expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.endpointSuffix, func(t *testing.T) {
+			assert.Equal(t, test.expected, AzureEndpointSuffixUsesHTTPS(test.endpointSuffix))
+		})
+	}
+}
+
 func TestValidateStorageCAConfigMap(t *testing.T) {
 	path := field.NewPath("spec").Child("storage").Child("tls").Child("caName")
 
@@ -504,8 +909,10 @@ func TestValidateReplicationFactor(t *testing.T) {
 				Spec: TempoStackSpec{
 					ReplicationFactor: 3,
 					Template: TempoTemplateSpec{
-						Ingester: TempoComponentSpec{
-							Replicas: ptr.To(int32(2)),
+						Ingester: TempoIngesterSpec{
+							TempoComponentSpec: TempoComponentSpec{
+								Replicas: ptr.To(int32(2)),
+							},
 						},
 					},
 				},
@@ -518,8 +925,10 @@ func TestValidateReplicationFactor(t *testing.T) {
 				Spec: TempoStackSpec{
 					ReplicationFactor: 3,
 					Template: TempoTemplateSpec{
-						Ingester: TempoComponentSpec{
-							Replicas: ptr.To(int32(3)),
+						Ingester: TempoIngesterSpec{
+							TempoComponentSpec: TempoComponentSpec{
+								Replicas: ptr.To(int32(3)),
+							},
 						},
 					},
 				},
@@ -532,8 +941,10 @@ func TestValidateReplicationFactor(t *testing.T) {
 				Spec: TempoStackSpec{
 					ReplicationFactor: 3,
 					Template: TempoTemplateSpec{
-						Ingester: TempoComponentSpec{
-							Replicas: ptr.To(int32(1)),
+						Ingester: TempoIngesterSpec{
+							TempoComponentSpec: TempoComponentSpec{
+								Replicas: ptr.To(int32(1)),
+							},
 						},
 					},
 				},
@@ -1048,13 +1459,86 @@ func TestValidateTenantConfigs(t *testing.T) {
 			wantErr: fmt.Errorf("spec.tenants.authorization.roleBindings is required in static mode"),
 		},
 		{
-			name: "openshift: RBAC should not be defined",
+			name: "static with OPA",
 			input: TempoStack{
 				Spec: TempoStackSpec{
 					Tenants: &TenantsSpec{
-						Mode: ModeOpenShift,
+						Mode: ModeStatic,
 						Authorization: &AuthorizationSpec{
-							Roles: []RoleSpec{},
+							OPA: &OPASpec{
+								URL: "https://opa.example.com/v1/data/tempostack/allow",
+							},
+						},
+						Authentication: []AuthenticationSpec{},
+					},
+					Template: TempoTemplateSpec{
+						Gateway: TempoGatewaySpec{
+							Enabled: true,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "static with OPA and roles",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Tenants: &TenantsSpec{
+						Mode: ModeStatic,
+						Authorization: &AuthorizationSpec{
+							Roles: []RoleSpec{
+								{
+									Name:        "read-write",
+									Resources:   []string{"traces"},
+									Tenants:     []string{"dev"},
+									Permissions: []PermissionType{Read, Write},
+								},
+							},
+							OPA: &OPASpec{
+								URL: "https://opa.example.com/v1/data/tempostack/allow",
+							},
+						},
+						Authentication: []AuthenticationSpec{},
+					},
+					Template: TempoTemplateSpec{
+						Gateway: TempoGatewaySpec{
+							Enabled: true,
+						},
+					},
+				},
+			},
+			wantErr: fmt.Errorf("spec.tenants.authorization.opa is mutually exclusive with spec.tenants.authorization.roles and spec.tenants.authorization.roleBindings"),
+		},
+		{
+			name: "static with invalid OPA URL",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Tenants: &TenantsSpec{
+						Mode: ModeStatic,
+						Authorization: &AuthorizationSpec{
+							OPA: &OPASpec{
+								URL: "opa.example.com/v1/data/tempostack/allow",
+							},
+						},
+						Authentication: []AuthenticationSpec{},
+					},
+					Template: TempoTemplateSpec{
+						Gateway: TempoGatewaySpec{
+							Enabled: true,
+						},
+					},
+				},
+			},
+			wantErr: fmt.Errorf("spec.tenants.authorization.opa.url must be a valid http or https URL"),
+		},
+		{
+			name: "openshift: RBAC should not be defined",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Tenants: &TenantsSpec{
+						Mode: ModeOpenShift,
+						Authorization: &AuthorizationSpec{
+							Roles: []RoleSpec{},
 						},
 					},
 					Template: TempoTemplateSpec{
@@ -1307,8 +1791,10 @@ func TestValidatorValidate(t *testing.T) {
 						},
 					},
 					Template: TempoTemplateSpec{
-						Ingester: TempoComponentSpec{
-							Replicas: func(i int32) *int32 { return &i }(1),
+						Ingester: TempoIngesterSpec{
+							TempoComponentSpec: TempoComponentSpec{
+								Replicas: func(i int32) *int32 { return &i }(1),
+							},
 						},
 					},
 				},
@@ -1319,7 +1805,7 @@ func TestValidatorValidate(t *testing.T) {
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
 			v := &validator{ctrlConfig: v1alpha1.ProjectConfig{}, client: &k8sFake{}}
-			_, err := v.validate(context.Background(), tc.input)
+			_, err := v.validate(context.Background(), nil, tc.input)
 			assert.Equal(t, tc.expected, err)
 		})
 	}
@@ -1578,10 +2064,1217 @@ func TestValidateReceiverTLSAndGateway(t *testing.T) {
 	}
 }
 
-type k8sFake struct {
-	client.Client
+func TestValidateMetricsGenerator(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    TempoMetricsGeneratorSpec
+		expected field.ErrorList
+	}{
+		{
+			name:     "disabled",
+			input:    TempoMetricsGeneratorSpec{},
+			expected: nil,
+		},
+		{
+			name: "valid remote write",
+			input: TempoMetricsGeneratorSpec{
+				Enabled:    true,
+				Processors: []MetricsGeneratorProcessor{MetricsGeneratorProcessorSpanMetrics},
+				RemoteWrite: MetricsGeneratorRemoteWriteSpec{
+					URL: "http://prometheus:9090/api/v1/write",
+				},
+			},
+			expected: nil,
+		},
+		{
+			name: "local-blocks without remote write",
+			input: TempoMetricsGeneratorSpec{
+				Enabled:    true,
+				Processors: []MetricsGeneratorProcessor{MetricsGeneratorProcessorLocalBlocks},
+			},
+			expected: nil,
+		},
+		{
+			name: "service-graphs without remote write",
+			input: TempoMetricsGeneratorSpec{
+				Enabled:    true,
+				Processors: []MetricsGeneratorProcessor{MetricsGeneratorProcessorLocalBlocks, MetricsGeneratorProcessorServiceGraphs},
+			},
+			expected: field.ErrorList{field.Invalid(
+				field.NewPath("spec").Child("template").Child("metricsGenerator").Child("remoteWrite").Child("url"),
+				"",
+				"the service-graphs processor requires a remote write URL",
+			)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tempo := TempoStack{
+				Spec: TempoStackSpec{
+					Template: TempoTemplateSpec{
+						MetricsGenerator: test.input,
+					},
+				},
+			}
+			validator := &validator{ctrlConfig: v1alpha1.ProjectConfig{}}
+			errs := validator.validateMetricsGenerator(tempo)
+			assert.Equal(t, test.expected, errs)
+		})
+	}
 }
 
-func (*k8sFake) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
-	return fmt.Errorf("mock: fails always")
+func TestValidateCache(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    CacheSpec
+		expected field.ErrorList
+	}{
+		{
+			name:     "cache disabled",
+			input:    CacheSpec{},
+			expected: nil,
+		},
+		{
+			name: "external memcached",
+			input: CacheSpec{
+				Backend:  CacheBackendMemcached,
+				Endpoint: "dns+memcached:11211",
+			},
+			expected: nil,
+		},
+		{
+			name: "managed memcached",
+			input: CacheSpec{
+				Backend: CacheBackendMemcached,
+				Managed: ManagedCacheSpec{Enabled: true},
+			},
+			expected: nil,
+		},
+		{
+			name: "managed redis",
+			input: CacheSpec{
+				Backend: CacheBackendRedis,
+				Managed: ManagedCacheSpec{Enabled: true},
+			},
+			expected: field.ErrorList{field.Invalid(
+				field.NewPath("spec").Child("cache").Child("backend"),
+				CacheBackendRedis,
+				"a managed cache requires the memcached backend",
+			)},
+		},
+		{
+			name: "managed and external",
+			input: CacheSpec{
+				Backend:  CacheBackendMemcached,
+				Endpoint: "memcached:11211",
+				Managed:  ManagedCacheSpec{Enabled: true},
+			},
+			expected: field.ErrorList{field.Invalid(
+				field.NewPath("spec").Child("cache").Child("endpoint"),
+				"memcached:11211",
+				"cannot use an external endpoint and a managed cache at the same time",
+			)},
+		},
+		{
+			name: "managed memcached with component resources",
+			input: CacheSpec{
+				Backend: CacheBackendMemcached,
+				Managed: ManagedCacheSpec{
+					TempoComponentSpec: TempoComponentSpec{
+						Resources: &corev1.ResourceRequirements{},
+					},
+					Enabled: true,
+				},
+			},
+			expected: field.ErrorList{field.Invalid(
+				field.NewPath("spec").Child("cache").Child("managed").Child("component").Child("resources"),
+				&corev1.ResourceRequirements{},
+				"please configure the resources of the managed cache in spec.cache.managed.resources",
+			)},
+		},
+		{
+			name: "missing endpoint",
+			input: CacheSpec{
+				Backend: CacheBackendRedis,
+			},
+			expected: field.ErrorList{field.Invalid(
+				field.NewPath("spec").Child("cache").Child("endpoint"),
+				"",
+				"please configure the endpoint of the cache or enable the managed cache",
+			)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tempo := TempoStack{
+				Spec: TempoStackSpec{
+					Cache: test.input,
+				},
+			}
+			validator := &validator{ctrlConfig: v1alpha1.ProjectConfig{}}
+			errs := validator.validateCache(tempo)
+			assert.Equal(t, test.expected, errs)
+		})
+	}
+}
+
+func TestDefaultAutoscaling(t *testing.T) {
+	defaulter := &Defaulter{}
+	tempo := &TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: TempoStackSpec{
+			Template: TempoTemplateSpec{
+				Querier: TempoComponentSpec{
+					Replicas: ptr.To(int32(2)),
+					Autoscaling: AutoscalingSpec{
+						Enabled:     true,
+						MaxReplicas: 5,
+					},
+				},
+				Compactor: TempoComponentSpec{
+					Autoscaling: AutoscalingSpec{
+						MaxReplicas: 5,
+					},
+				},
+			},
+		},
+	}
+
+	err := defaulter.Default(context.Background(), tempo)
+	require.NoError(t, err)
+	assert.Equal(t, ptr.To(int32(2)), tempo.Spec.Template.Querier.Autoscaling.MinReplicas)
+	assert.Nil(t, tempo.Spec.Template.Compactor.Autoscaling.MinReplicas)
+}
+
+func TestValidateAutoscaling(t *testing.T) {
+	templatePath := field.NewPath("spec").Child("template")
+
+	tests := []struct {
+		name     string
+		input    TempoStackSpec
+		expected field.ErrorList
+	}{
+		{
+			name:     "autoscaling disabled",
+			input:    TempoStackSpec{ReplicationFactor: 1},
+			expected: nil,
+		},
+		{
+			name: "valid querier autoscaling",
+			input: TempoStackSpec{
+				ReplicationFactor: 1,
+				Template: TempoTemplateSpec{
+					Querier: TempoComponentSpec{
+						Autoscaling: AutoscalingSpec{
+							Enabled:              true,
+							MinReplicas:          ptr.To(int32(1)),
+							MaxReplicas:          3,
+							TargetCPUUtilization: ptr.To(int32(80)),
+						},
+					},
+				},
+			},
+			expected: nil,
+		},
+		{
+			name: "maxReplicas lower than minReplicas",
+			input: TempoStackSpec{
+				ReplicationFactor: 1,
+				Template: TempoTemplateSpec{
+					Distributor: TempoDistributorSpec{
+						TempoComponentSpec: TempoComponentSpec{
+							Autoscaling: AutoscalingSpec{
+								Enabled:              true,
+								MinReplicas:          ptr.To(int32(3)),
+								MaxReplicas:          2,
+								TargetCPUUtilization: ptr.To(int32(80)),
+							},
+						},
+					},
+				},
+			},
+			expected: field.ErrorList{field.Invalid(
+				templatePath.Child("distributor").Child("component").Child("autoscaling").Child("maxReplicas"),
+				int32(2),
+				"maxReplicas must be greater than or equal to minReplicas (3)",
+			)},
+		},
+		{
+			name: "missing target",
+			input: TempoStackSpec{
+				ReplicationFactor: 1,
+				Template: TempoTemplateSpec{
+					Compactor: TempoComponentSpec{
+						Autoscaling: AutoscalingSpec{
+							Enabled:     true,
+							MaxReplicas: 2,
+						},
+					},
+				},
+			},
+			expected: field.ErrorList{field.Required(
+				templatePath.Child("compactor").Child("autoscaling"),
+				"please configure at least one of targetCPUUtilization, targetMemoryUtilization or metrics",
+			)},
+		},
+		{
+			name: "ingester minReplicas lower than replication factor",
+			input: TempoStackSpec{
+				ReplicationFactor: 3,
+				Template: TempoTemplateSpec{
+					Ingester: TempoIngesterSpec{
+						TempoComponentSpec: TempoComponentSpec{
+							Autoscaling: AutoscalingSpec{
+								Enabled:                 true,
+								MinReplicas:             ptr.To(int32(2)),
+								MaxReplicas:             5,
+								TargetMemoryUtilization: ptr.To(int32(80)),
+							},
+						},
+					},
+				},
+			},
+			expected: field.ErrorList{field.Invalid(
+				templatePath.Child("ingester").Child("autoscaling").Child("minReplicas"),
+				int32(2),
+				"replication factor of 3 requires at least 3 ingester replicas",
+			)},
+		},
+		{
+			name: "unsupported component",
+			input: TempoStackSpec{
+				ReplicationFactor: 1,
+				Template: TempoTemplateSpec{
+					Gateway: TempoGatewaySpec{
+						TempoComponentSpec: TempoComponentSpec{
+							Autoscaling: AutoscalingSpec{
+								Enabled:     true,
+								MaxReplicas: 2,
+							},
+						},
+					},
+				},
+			},
+			expected: field.ErrorList{field.Forbidden(
+				templatePath.Child("gateway").Child("component").Child("autoscaling").Child("enabled"),
+				"autoscaling is not supported for this component",
+			)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tempo := TempoStack{
+				Spec: test.input,
+			}
+			validator := &validator{ctrlConfig: v1alpha1.ProjectConfig{}}
+			errs := validator.validateAutoscaling(tempo)
+			assert.Equal(t, test.expected, errs)
+		})
+	}
+}
+
+func TestValidateZoneAwareReplication(t *testing.T) {
+	zones := []ZoneSpec{{Name: "zone-a"}, {Name: "zone-b"}, {Name: "zone-c"}}
+	ingesterPath := field.NewPath("spec", "template", "ingester")
+
+	tests := []struct {
+		name     string
+		input    TempoStackSpec
+		expected field.ErrorList
+	}{
+		{
+			name: "valid zones",
+			input: TempoStackSpec{
+				ReplicationFactor: 3,
+				Template: TempoTemplateSpec{
+					Ingester: TempoIngesterSpec{
+						TempoComponentSpec: TempoComponentSpec{
+							Replicas: ptr.To(int32(3)),
+						},
+						ZoneAwareReplication: ZoneAwareReplicationSpec{Enabled: true, Zones: zones},
+					},
+				},
+			},
+		},
+		{
+			name: "invalid zones",
+			input: TempoStackSpec{
+				ReplicationFactor: 3,
+				Template: TempoTemplateSpec{
+					Ingester: TempoIngesterSpec{
+						TempoComponentSpec: TempoComponentSpec{
+							Replicas: ptr.To(int32(2)),
+							Autoscaling: AutoscalingSpec{
+								Enabled: true,
+							},
+						},
+						ZoneAwareReplication: ZoneAwareReplicationSpec{
+							Enabled: true,
+							Zones:   []ZoneSpec{{Name: "zone-a"}, {Name: "zone-a"}},
+						},
+					},
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(ingesterPath.Child("zoneAwareReplication", "zones"), 2, "replication factor of 3 requires at least 3 zones"),
+				field.Duplicate(ingesterPath.Child("zoneAwareReplication", "zones").Index(1).Child("name"), "zone-a"),
+				field.Forbidden(ingesterPath.Child("autoscaling", "enabled"), "autoscaling is not supported with zone-aware replication"),
+			},
+		},
+		{
+			name: "invalid zone names and too few replicas",
+			input: TempoStackSpec{
+				ReplicationFactor: 1,
+				Template: TempoTemplateSpec{
+					Ingester: TempoIngesterSpec{
+						TempoComponentSpec: TempoComponentSpec{
+							Replicas: ptr.To(int32(1)),
+						},
+						ZoneAwareReplication: ZoneAwareReplicationSpec{
+							Enabled: true,
+							Zones:   []ZoneSpec{{}, {Name: "Zone_B"}},
+						},
+					},
+				},
+			},
+			expected: field.ErrorList{
+				field.Required(ingesterPath.Child("zoneAwareReplication", "zones").Index(0).Child("name"), "the name of the zone is required"),
+				field.Invalid(ingesterPath.Child("zoneAwareReplication", "zones").Index(1).Child("name"), "Zone_B",
+					"a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')"),
+				field.Invalid(ingesterPath.Child("replicas"), int32(1), "zone-aware replication requires at least one ingester per zone (2)"),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tempo := TempoStack{
+				Spec: test.input,
+			}
+			validator := &validator{ctrlConfig: v1alpha1.ProjectConfig{}}
+			errs := validator.validateZoneAwareReplication(tempo)
+			assert.Equal(t, test.expected, errs)
+		})
+	}
+}
+
+func TestValidatePodDisruptionBudgets(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    TempoTemplateSpec
+		expected field.ErrorList
+	}{
+		{
+			name:     "no overrides",
+			input:    TempoTemplateSpec{},
+			expected: nil,
+		},
+		{
+			name: "valid override",
+			input: TempoTemplateSpec{
+				Ingester: TempoIngesterSpec{
+					TempoComponentSpec: TempoComponentSpec{
+						PodDisruptionBudget: PodDisruptionBudgetSpec{
+							MinAvailable: ptr.To(intstr.FromString("50%")),
+						},
+					},
+				},
+			},
+			expected: nil,
+		},
+		{
+			name: "minAvailable and maxUnavailable",
+			input: TempoTemplateSpec{
+				Querier: TempoComponentSpec{
+					PodDisruptionBudget: PodDisruptionBudgetSpec{
+						MinAvailable:   ptr.To(intstr.FromInt32(1)),
+						MaxUnavailable: ptr.To(intstr.FromInt32(1)),
+					},
+				},
+			},
+			expected: field.ErrorList{field.Invalid(
+				field.NewPath("spec").Child("template").Child("querier").Child("podDisruptionBudget"),
+				PodDisruptionBudgetSpec{
+					MinAvailable:   ptr.To(intstr.FromInt32(1)),
+					MaxUnavailable: ptr.To(intstr.FromInt32(1)),
+				},
+				"only one of minAvailable and maxUnavailable can be set",
+			)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tempo := TempoStack{
+				Spec: TempoStackSpec{
+					Template: test.input,
+				},
+			}
+			validator := &validator{ctrlConfig: v1alpha1.ProjectConfig{}}
+			errs := validator.validatePodDisruptionBudgets(tempo)
+			assert.Equal(t, test.expected, errs)
+		})
+	}
+}
+
+func TestValidateResources(t *testing.T) {
+	total := &corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("1"),
+			corev1.ResourceMemory: resource.MustParse("2Gi"),
+		},
+	}
+	ingesterResources := &corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("500m"),
+			corev1.ResourceMemory: resource.MustParse("1Gi"),
+		},
+	}
+	jaegerQueryResources := &corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU: resource.MustParse("600m"),
+		},
+	}
+	cacheResources := &corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("4Gi"),
+		},
+	}
+
+	tests := []struct {
+		name     string
+		input    TempoStackSpec
+		expected field.ErrorList
+	}{
+		{
+			name: "no total resources",
+			input: TempoStackSpec{
+				Template: TempoTemplateSpec{
+					Ingester: TempoIngesterSpec{
+						TempoComponentSpec: TempoComponentSpec{Resources: ingesterResources},
+					},
+				},
+			},
+		},
+		{
+			name: "explicit resources within the total resources",
+			input: TempoStackSpec{
+				Resources: Resources{Total: total},
+				Template: TempoTemplateSpec{
+					Ingester: TempoIngesterSpec{
+						TempoComponentSpec: TempoComponentSpec{Resources: ingesterResources},
+					},
+					Querier: TempoComponentSpec{Resources: ingesterResources},
+				},
+			},
+		},
+		{
+			name: "explicit resources exceed the total resources",
+			input: TempoStackSpec{
+				Resources: Resources{Total: total},
+				Template: TempoTemplateSpec{
+					Ingester: TempoIngesterSpec{
+						TempoComponentSpec: TempoComponentSpec{Resources: ingesterResources},
+					},
+					QueryFrontend: TempoQueryFrontendSpec{
+						JaegerQuery: JaegerQuerySpec{Resources: jaegerQueryResources},
+					},
+				},
+			},
+			expected: field.ErrorList{field.Invalid(
+				field.NewPath("spec").Child("template").Child("queryFrontend").Child("jaegerQuery").Child("resources"),
+				jaegerQueryResources,
+				"the cpu resources of the components exceed the total cpu limit of 1",
+			)},
+		},
+		{
+			name: "resources of the managed cache are not part of the total resources",
+			input: TempoStackSpec{
+				Resources: Resources{Total: total},
+				Template: TempoTemplateSpec{
+					Ingester: TempoIngesterSpec{
+						TempoComponentSpec: TempoComponentSpec{Resources: ingesterResources},
+					},
+				},
+				Cache: CacheSpec{
+					Managed: ManagedCacheSpec{
+						TempoComponentSpec: TempoComponentSpec{Resources: cacheResources},
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tempo := TempoStack{
+				Spec: test.input,
+			}
+			validator := &validator{ctrlConfig: v1alpha1.ProjectConfig{}}
+			errs := validator.validateResources(tempo)
+			assert.Equal(t, test.expected, errs)
+		})
+	}
+}
+
+func TestValidatePodTemplates(t *testing.T) {
+	cachePodTemplate := &PodTemplateSpec{PriorityClassName: "high-priority"}
+
+	tests := []struct {
+		name     string
+		input    TempoStackSpec
+		expected field.ErrorList
+	}{
+		{
+			name: "valid pod template",
+			input: TempoStackSpec{
+				Template: TempoTemplateSpec{
+					Ingester: TempoIngesterSpec{
+						TempoComponentSpec: TempoComponentSpec{
+							PodTemplate: &PodTemplateSpec{
+								PriorityClassName: "high-priority",
+								Env:               []corev1.EnvVar{{Name: "A", Value: "a"}},
+								Volumes: []corev1.Volume{{
+									Name:         "extra",
+									VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+								}},
+								VolumeMounts: []corev1.VolumeMount{{Name: "extra", MountPath: "/extra"}},
+								Sidecars:     []corev1.Container{{Name: "sidecar", Image: "sidecar"}},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "missing names",
+			input: TempoStackSpec{
+				Template: TempoTemplateSpec{
+					Gateway: TempoGatewaySpec{
+						TempoComponentSpec: TempoComponentSpec{
+							PodTemplate: &PodTemplateSpec{
+								Env:          []corev1.EnvVar{{Value: "a"}},
+								Volumes:      []corev1.Volume{{}},
+								VolumeMounts: []corev1.VolumeMount{{Name: "extra"}},
+								Sidecars:     []corev1.Container{{Image: "sidecar"}},
+							},
+						},
+					},
+				},
+			},
+			expected: field.ErrorList{
+				field.Required(field.NewPath("spec", "template", "gateway", "component", "podTemplate", "env").Index(0).Child("name"), "the name of the env var is required"),
+				field.Required(field.NewPath("spec", "template", "gateway", "component", "podTemplate", "volumes").Index(0).Child("name"), "the name of the volume is required"),
+				field.Invalid(field.NewPath("spec", "template", "gateway", "component", "podTemplate", "volumes").Index(0), "", "exactly one volume source must be set"),
+				field.Required(field.NewPath("spec", "template", "gateway", "component", "podTemplate", "volumeMounts").Index(0).Child("mountPath"), "the mount path of the volume mount is required"),
+				field.Required(field.NewPath("spec", "template", "gateway", "component", "podTemplate", "sidecars").Index(0).Child("name"), "the name of the sidecar is required"),
+			},
+		},
+		{
+			name: "invalid fields",
+			input: TempoStackSpec{
+				Template: TempoTemplateSpec{
+					Querier: TempoComponentSpec{
+						PodTemplate: &PodTemplateSpec{
+							Labels:            map[string]string{"invalid label": "a"},
+							PriorityClassName: "High_Priority",
+							TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{
+								WhenUnsatisfiable: "Never",
+							}},
+							Env:      []corev1.EnvVar{{Name: "1A"}},
+							Sidecars: []corev1.Container{{Name: "Sidecar"}},
+						},
+					},
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "template", "querier", "podTemplate", "labels"), "invalid label",
+					"name part must consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my.name',  or '123-abc', regex used for validation is '([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]')"),
+				field.Invalid(field.NewPath("spec", "template", "querier", "podTemplate", "priorityClassName"), "High_Priority",
+					"a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')"),
+				field.Invalid(field.NewPath("spec", "template", "querier", "podTemplate", "topologySpreadConstraints").Index(0).Child("maxSkew"), int32(0), "must be greater than zero"),
+				field.Required(field.NewPath("spec", "template", "querier", "podTemplate", "topologySpreadConstraints").Index(0).Child("topologyKey"), "the topology key is required"),
+				field.NotSupported(field.NewPath("spec", "template", "querier", "podTemplate", "topologySpreadConstraints").Index(0).Child("whenUnsatisfiable"),
+					corev1.UnsatisfiableConstraintAction("Never"), []string{"DoNotSchedule", "ScheduleAnyway"}),
+				field.Invalid(field.NewPath("spec", "template", "querier", "podTemplate", "env").Index(0).Child("name"), "1A",
+					"a valid environment variable name must consist of alphabetic characters, digits, '_', '-', or '.', and must not start with a digit (e.g. 'my.env-name',  or 'MY_ENV.NAME',  or 'MyEnvName1', regex used for validation is '[-._a-zA-Z][-._a-zA-Z0-9]*')"),
+				field.Invalid(field.NewPath("spec", "template", "querier", "podTemplate", "sidecars").Index(0).Child("name"), "Sidecar",
+					"a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')"),
+				field.Required(field.NewPath("spec", "template", "querier", "podTemplate", "sidecars").Index(0).Child("image"), "the image of the sidecar is required"),
+			},
+		},
+		{
+			name: "pod template of the managed cache",
+			input: TempoStackSpec{
+				Cache: CacheSpec{
+					Managed: ManagedCacheSpec{
+						TempoComponentSpec: TempoComponentSpec{PodTemplate: cachePodTemplate},
+					},
+				},
+			},
+			expected: field.ErrorList{field.Invalid(
+				field.NewPath("spec", "cache", "managed", "component", "podTemplate"),
+				cachePodTemplate,
+				"pod template overrides are not supported by the managed cache",
+			)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tempo := TempoStack{
+				Spec: test.input,
+			}
+			validator := &validator{ctrlConfig: v1alpha1.ProjectConfig{}}
+			errs := validator.validatePodTemplates(tempo)
+			assert.Equal(t, test.expected, errs)
+		})
+	}
+}
+
+func TestValidatePodTemplateFields(t *testing.T) {
+	podTemplate := &PodTemplateSpec{PriorityClassName: "high-priority"}
+	tempo := TempoStack{
+		Spec: TempoStackSpec{
+			Template: TempoTemplateSpec{
+				Ingester: TempoIngesterSpec{
+					TempoComponentSpec: TempoComponentSpec{PodTemplate: podTemplate},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		raw      string
+		expected field.ErrorList
+	}{
+		{
+			name: "known fields",
+			raw:  `{"spec":{"template":{"ingester":{"podTemplate":{"priorityClassName":"high-priority"}}}}}`,
+		},
+		{
+			name: "unknown field",
+			raw:  `{"spec":{"template":{"ingester":{"podTemplate":{"priorityClassName":"high-priority","nodeSelectr":{"a":"b"}}}}}}`,
+			expected: field.ErrorList{field.Invalid(
+				field.NewPath("spec", "template", "ingester", "podTemplate"),
+				podTemplate,
+				`invalid pod template: json: unknown field "nodeSelectr"`,
+			)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := admission.NewContextWithRequest(context.Background(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Object: runtime.RawExtension{Raw: []byte(test.raw)},
+				},
+			})
+			validator := &validator{ctrlConfig: v1alpha1.ProjectConfig{}}
+			errs := validator.validatePodTemplateFields(ctx, tempo)
+			assert.Equal(t, test.expected, errs)
+		})
+	}
+
+	t.Run("no admission request", func(t *testing.T) {
+		validator := &validator{ctrlConfig: v1alpha1.ProjectConfig{}}
+		assert.Empty(t, validator.validatePodTemplateFields(context.Background(), tempo))
+	})
+}
+
+func TestValidateStorageSize(t *testing.T) {
+	tests := []struct {
+		name     string
+		old      string
+		new      string
+		expected field.ErrorList
+	}{
+		{
+			name: "unchanged",
+			old:  "10Gi",
+			new:  "10Gi",
+		},
+		{
+			name: "expanded",
+			old:  "10Gi",
+			new:  "20Gi",
+		},
+		{
+			name: "decreased",
+			old:  "10Gi",
+			new:  "5Gi",
+			expected: field.ErrorList{field.Invalid(
+				field.NewPath("spec", "storageSize"),
+				"5Gi",
+				"the storage size cannot be decreased from 10Gi",
+			)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			oldTempo := TempoStack{Spec: TempoStackSpec{StorageSize: resource.MustParse(test.old)}}
+			tempo := TempoStack{Spec: TempoStackSpec{StorageSize: resource.MustParse(test.new)}}
+			validator := &validator{ctrlConfig: v1alpha1.ProjectConfig{}}
+			errs := validator.validateStorageSize(oldTempo, tempo)
+			assert.Equal(t, test.expected, errs)
+		})
+	}
+}
+
+func TestValidateStorageLocation(t *testing.T) {
+	newStack := func(namespace, name, secret, prefix string) TempoStack {
+		return TempoStack{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec: TempoStackSpec{
+				Storage: ObjectStorageSpec{
+					Secret: ObjectStorageSecretSpec{Type: ObjectStorageSecretS3, Name: secret},
+					Prefix: prefix,
+				},
+			},
+		}
+	}
+	newSecret := func(namespace, name, bucket, prefix string) corev1.Secret {
+		return corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Data: map[string][]byte{
+				"endpoint": []byte("http://minio:9000"),
+				"bucket":   []byte(bucket),
+				"prefix":   []byte(prefix),
+			},
+		}
+	}
+
+	existing := newStack("team-a", "simplest", "storage", "")
+	existingWithPrefix := newStack("team-b", "simplest", "storage", "team-b")
+	existingMonolithic := TempoMonolithic{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-d", Name: "simplest"},
+		Spec: TempoMonolithicSpec{
+			Storage: MonolithicStorageSpec{
+				Traces: MonolithicTracesStorageSpec{
+					Backend: MonolithicTracesStorageBackendS3,
+					S3: &MonolithicTracesStorageS3Spec{
+						MonolithicTracesObjectStorageSpec: MonolithicTracesObjectStorageSpec{Secret: "storage"},
+					},
+				},
+			},
+		},
+	}
+	secrets := []corev1.Secret{
+		newSecret("team-a", "storage", "tempo-a", ""),
+		newSecret("team-b", "storage", "tempo-shared", ""),
+		newSecret("team-d", "storage", "tempo-shared", "team-d"),
+		newSecret("team-c", "same-bucket", "tempo-a", ""),
+		newSecret("team-c", "shared-bucket", "tempo-shared", ""),
+		newSecret("team-c", "shared-bucket-prefix", "tempo-shared", "team-c"),
+		newSecret("team-c", "other-bucket", "tempo-c", ""),
+	}
+
+	tests := []struct {
+		name     string
+		input    TempoStack
+		expected field.ErrorList
+	}{
+		{
+			name:  "other bucket",
+			input: newStack("team-c", "simplest", "other-bucket", ""),
+		},
+		{
+			name:  "shared bucket with other prefix",
+			input: newStack("team-c", "simplest", "shared-bucket", "team-c"),
+		},
+		{
+			name:  "shared bucket with other prefix in storage secret",
+			input: newStack("team-c", "simplest", "shared-bucket-prefix", ""),
+		},
+		{
+			name:  "update of existing stack",
+			input: existing,
+		},
+		{
+			name:  "same bucket",
+			input: newStack("team-c", "simplest", "same-bucket", ""),
+			expected: field.ErrorList{field.Invalid(
+				field.NewPath("spec", "storage", "prefix"),
+				"",
+				"the bucket is already used by another TempoStack or TempoMonolithic with an overlapping prefix, the prefixes of instances sharing a bucket must not overlap",
+			)},
+		},
+		{
+			name:  "shared bucket with same prefix",
+			input: newStack("team-c", "simplest", "shared-bucket", "/team-b/"),
+			expected: field.ErrorList{field.Invalid(
+				field.NewPath("spec", "storage", "prefix"),
+				"/team-b/",
+				"the bucket is already used by another TempoStack or TempoMonolithic with an overlapping prefix, the prefixes of instances sharing a bucket must not overlap",
+			)},
+		},
+		{
+			name:  "shared bucket with prefix of TempoMonolithic",
+			input: newStack("team-c", "simplest", "shared-bucket", "team-d"),
+			expected: field.ErrorList{field.Invalid(
+				field.NewPath("spec", "storage", "prefix"),
+				"team-d",
+				"the bucket is already used by another TempoStack or TempoMonolithic with an overlapping prefix, the prefixes of instances sharing a bucket must not overlap",
+			)},
+		},
+		{
+			name:  "shared bucket with nested prefix",
+			input: newStack("team-c", "simplest", "shared-bucket", "team-b/nested"),
+			expected: field.ErrorList{field.Invalid(
+				field.NewPath("spec", "storage", "prefix"),
+				"team-b/nested",
+				"the bucket is already used by another TempoStack or TempoMonolithic with an overlapping prefix, the prefixes of instances sharing a bucket must not overlap",
+			)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			v := &validator{
+				ctrlConfig: v1alpha1.ProjectConfig{},
+				client: &storageLocationFake{
+					tempostacks: []TempoStack{existing, existingWithPrefix},
+					monolithics: []TempoMonolithic{existingMonolithic},
+					secrets:     secrets,
+				},
+			}
+			errs := v.validateStorageLocation(context.Background(), test.input)
+			assert.Equal(t, test.expected, errs)
+		})
+	}
+}
+
+func TestValidateStorageLocationUpdate(t *testing.T) {
+	newStack := func(namespace, prefix string) *TempoStack {
+		return &TempoStack{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "simplest"},
+			Spec: TempoStackSpec{
+				ServiceAccount: naming.DefaultServiceAccountName("simplest"),
+				Storage: ObjectStorageSpec{
+					Secret: ObjectStorageSecretSpec{Type: ObjectStorageSecretS3, Name: "storage"},
+					Prefix: prefix,
+				},
+				Template: TempoTemplateSpec{
+					Ingester: TempoIngesterSpec{
+						TempoComponentSpec: TempoComponentSpec{Replicas: ptr.To(int32(1))},
+					},
+				},
+			},
+		}
+	}
+	newSecret := func(namespace string) corev1.Secret {
+		return corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "storage"},
+			Data: map[string][]byte{
+				"endpoint":          []byte("http://minio:9000"),
+				"bucket":            []byte("tempo"),
+				"access_key_id":     []byte("id"),
+				"access_key_secret": []byte("secret"),
+			},
+		}
+	}
+
+	// Both TempoStacks were created before the storage location was validated.
+	v := &validator{
+		ctrlConfig: v1alpha1.ProjectConfig{},
+		client: &storageLocationFake{
+			tempostacks: []TempoStack{*newStack("team-a", ""), *newStack("team-b", "")},
+			secrets:     []corev1.Secret{newSecret("team-a"), newSecret("team-b")},
+		},
+	}
+
+	_, err := v.validate(context.Background(), newStack("team-b", ""), newStack("team-b", ""))
+	assert.NoError(t, err)
+
+	_, err = v.validate(context.Background(), newStack("team-b", ""), newStack("team-b", "team-b"))
+	assert.ErrorContains(t, err, storageLocationInUseMessage)
+}
+
+func TestValidateZoneAwareReplicationUpdate(t *testing.T) {
+	path := field.NewPath("spec", "template", "ingester", "zoneAwareReplication")
+	zones := []ZoneSpec{{Name: "zone-a"}, {Name: "zone-b"}, {Name: "zone-c"}}
+
+	tests := []struct {
+		name     string
+		old      ZoneAwareReplicationSpec
+		new      ZoneAwareReplicationSpec
+		expected field.ErrorList
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name: "unchanged zones with a different node selector",
+			old:  ZoneAwareReplicationSpec{Enabled: true, Zones: zones},
+			new: ZoneAwareReplicationSpec{Enabled: true, Zones: []ZoneSpec{
+				{Name: "zone-a", NodeSelector: map[string]string{"topology.kubernetes.io/zone": "a"}},
+				{Name: "zone-b"},
+				{Name: "zone-c"},
+			}},
+		},
+		{
+			name: "enabled",
+			new:  ZoneAwareReplicationSpec{Enabled: true, Zones: zones},
+			expected: field.ErrorList{field.Forbidden(path.Child("enabled"),
+				"zone-aware replication cannot be enabled or disabled after the TempoStack is created")},
+		},
+		{
+			name: "disabled on an existing stack",
+			old:  ZoneAwareReplicationSpec{Enabled: true, Zones: zones},
+			expected: field.ErrorList{field.Forbidden(path.Child("enabled"),
+				"zone-aware replication cannot be enabled or disabled after the TempoStack is created")},
+		},
+		{
+			name: "zone added",
+			old:  ZoneAwareReplicationSpec{Enabled: true, Zones: zones},
+			new:  ZoneAwareReplicationSpec{Enabled: true, Zones: append([]ZoneSpec{{Name: "zone-d"}}, zones...)},
+			expected: field.ErrorList{field.Forbidden(path.Child("zones"),
+				"the names and the order of the zones cannot be changed after the TempoStack is created")},
+		},
+		{
+			name: "zones reordered",
+			old:  ZoneAwareReplicationSpec{Enabled: true, Zones: zones},
+			new:  ZoneAwareReplicationSpec{Enabled: true, Zones: []ZoneSpec{zones[1], zones[0], zones[2]}},
+			expected: field.ErrorList{field.Forbidden(path.Child("zones"),
+				"the names and the order of the zones cannot be changed after the TempoStack is created")},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			oldTempo := TempoStack{Spec: TempoStackSpec{Template: TempoTemplateSpec{Ingester: TempoIngesterSpec{ZoneAwareReplication: test.old}}}}
+			tempo := TempoStack{Spec: TempoStackSpec{Template: TempoTemplateSpec{Ingester: TempoIngesterSpec{ZoneAwareReplication: test.new}}}}
+			validator := &validator{ctrlConfig: v1alpha1.ProjectConfig{}}
+			errs := validator.validateZoneAwareReplicationUpdate(oldTempo, tempo)
+			assert.Equal(t, test.expected, errs)
+		})
+	}
+}
+
+func TestValidateMemberListSecurityUpdate(t *testing.T) {
+	tests := []struct {
+		name     string
+		old      MemberListSecurity
+		new      MemberListSecurity
+		expected admission.Warnings
+	}{
+		{
+			name: "unchanged",
+		},
+		{
+			name: "next step",
+			old:  MemberListSecurityLegacy,
+			new:  MemberListSecurityLabelVerificationDisabled,
+		},
+		{
+			name: "last step to the default",
+			old:  MemberListSecurityClusterLabel,
+			new:  "",
+		},
+		{
+			name: "previous step",
+			old:  MemberListSecurityClusterLabelUnverified,
+			new:  MemberListSecurityLabelVerificationDisabled,
+		},
+		{
+			name: "skipped steps",
+			old:  MemberListSecurityLegacy,
+			new:  "",
+			expected: admission.Warnings{
+				"changing spec.hashRing.memberlist.security from Legacy to Secure skips steps of the switch-over, the ring is split until all components are restarted",
+			},
+		},
+		{
+			name: "skipped steps backwards",
+			old:  MemberListSecuritySecure,
+			new:  MemberListSecurityLegacy,
+			expected: admission.Warnings{
+				"changing spec.hashRing.memberlist.security from Secure to Legacy skips steps of the switch-over, the ring is split until all components are restarted",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			oldTempo := TempoStack{Spec: TempoStackSpec{HashRing: HashRingSpec{MemberList: MemberListSpec{Security: test.old}}}}
+			tempo := TempoStack{Spec: TempoStackSpec{HashRing: HashRingSpec{MemberList: MemberListSpec{Security: test.new}}}}
+			validator := &validator{ctrlConfig: v1alpha1.ProjectConfig{}}
+			warnings := validator.validateMemberListSecurityUpdate(oldTempo, tempo)
+			assert.Equal(t, test.expected, warnings)
+		})
+	}
+}
+
+func TestValidatePerTenantRetention(t *testing.T) {
+	retention := RetentionSpec{
+		PerTenant: map[string]RetentionConfig{
+			"dev-id":     {Traces: metav1.Duration{Duration: time.Hour}},
+			"unknown-id": {Traces: metav1.Duration{Duration: time.Hour}},
+		},
+	}
+	authentication := []AuthenticationSpec{
+		{TenantName: "dev", TenantID: "dev-id"},
+		{TenantName: "prod", TenantID: "prod-id"},
+	}
+
+	tests := []struct {
+		name     string
+		input    TempoStackSpec
+		expected admission.Warnings
+	}{
+		{
+			name:     "no per-tenant retention",
+			input:    TempoStackSpec{},
+			expected: nil,
+		},
+		{
+			name: "tenants defined in spec.tenants",
+			input: TempoStackSpec{
+				Retention: RetentionSpec{
+					PerTenant: map[string]RetentionConfig{
+						"dev-id": {Traces: metav1.Duration{Duration: time.Hour}},
+					},
+				},
+				Tenants: &TenantsSpec{Mode: ModeStatic, Authentication: authentication},
+			},
+			expected: nil,
+		},
+		{
+			name: "tenant not defined in spec.tenants",
+			input: TempoStackSpec{
+				Retention: retention,
+				Tenants:   &TenantsSpec{Mode: ModeStatic, Authentication: authentication},
+			},
+			expected: admission.Warnings{
+				"spec.retention.perTenant[unknown-id]: tenant 'unknown-id' is not defined in spec.tenants, its retention has no effect",
+			},
+		},
+		{
+			name: "multi-tenancy disabled",
+			input: TempoStackSpec{
+				Retention: retention,
+			},
+			expected: admission.Warnings{
+				"spec.retention.perTenant[dev-id]: tenant 'dev-id' is not defined in spec.tenants, its retention has no effect",
+				"spec.retention.perTenant[unknown-id]: tenant 'unknown-id' is not defined in spec.tenants, its retention has no effect",
+			},
+		},
+		{
+			name: "multi-tenancy without tenant list",
+			input: TempoStackSpec{
+				Retention: retention,
+				Tenants:   &TenantsSpec{Mode: ModeStatic},
+			},
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			validator := &validator{ctrlConfig: v1alpha1.ProjectConfig{}}
+			warnings := validator.validatePerTenantRetention(TempoStack{Spec: test.input})
+			assert.Equal(t, test.expected, warnings)
+		})
+	}
+}
+
+func TestValidateExtraConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *ExtraConfigSpec
+		warnings admission.Warnings
+		errors   field.ErrorList
+	}{
+		{
+			name:  "no extra config",
+			input: nil,
+		},
+		{
+			name: "unmanaged settings",
+			input: &ExtraConfigSpec{
+				Tempo: apiextensionsv1.JSON{Raw: []byte(`{"ingester": {"max_block_duration": "30m"}, "storage": {"trace": {"blocklist_poll": "10m"}}}`)},
+			},
+		},
+		{
+			name: "managed settings",
+			input: &ExtraConfigSpec{
+				Tempo:      apiextensionsv1.JSON{Raw: []byte(`{"storage": {"trace": {"s3": {"bucket": "other"}}}, "server": {"http_listen_port": 8080}, "distributor": "none"}`)},
+				TempoQuery: apiextensionsv1.JSON{Raw: []byte(`{"backend": "127.0.0.1:8080"}`)},
+			},
+			warnings: admission.Warnings{
+				"spec.extraConfig.tempo overrides the operator-managed setting 'distributor.receivers', this can result in a non-functional deployment",
+				"spec.extraConfig.tempo overrides the operator-managed setting 'server.http_listen_port', this can result in a non-functional deployment",
+				"spec.extraConfig.tempo overrides the operator-managed setting 'storage.trace.s3', this can result in a non-functional deployment",
+				"spec.extraConfig.tempoQuery overrides the operator-managed setting 'backend', this can result in a non-functional deployment",
+			},
+		},
+		{
+			name: "not an object",
+			input: &ExtraConfigSpec{
+				Tempo: apiextensionsv1.JSON{Raw: []byte(`["a"]`)},
+			},
+			errors: field.ErrorList{field.Invalid(
+				field.NewPath("spec").Child("extraConfig").Child("tempo"),
+				`["a"]`,
+				"extra configuration must be an object",
+			)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tempo := TempoStack{
+				Spec: TempoStackSpec{
+					ExtraConfig: test.input,
+				},
+			}
+			validator := &validator{ctrlConfig: v1alpha1.ProjectConfig{}}
+			warnings, errs := validator.validateExtraConfig(tempo)
+			assert.Equal(t, test.warnings, warnings)
+			assert.Equal(t, test.errors, errs)
+		})
+	}
+}
+
+type k8sFake struct {
+	client.Client
+}
+
+func (*k8sFake) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return fmt.Errorf("mock: fails always")
+}
+
+// storageLocationFake returns the given TempoStacks, TempoMonolithics and storage secrets.
+type storageLocationFake struct {
+	client.Client
+	tempostacks []TempoStack
+	monolithics []TempoMonolithic
+	secrets     []corev1.Secret
+}
+
+func (f *storageLocationFake) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	for _, secret := range f.secrets {
+		if secret.Namespace == key.Namespace && secret.Name == key.Name {
+			secret.DeepCopyInto(obj.(*corev1.Secret))
+			return nil
+		}
+	}
+	return apierrors.NewNotFound(corev1.Resource("secrets"), key.Name)
+}
+
+func (f *storageLocationFake) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	switch list := list.(type) {
+	case *TempoStackList:
+		list.Items = f.tempostacks
+	case *TempoMonolithicList:
+		list.Items = f.monolithics
+	}
+	return nil
 }
@@ -6,9 +6,11 @@
 package v1alpha1
 
 import (
-	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/api/autoscaling/v2"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -48,6 +50,11 @@ func (in *AuthorizationSpec) DeepCopyInto(out *AuthorizationSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.OPA != nil {
+		in, out := &in.OPA, &out.OPA
+		*out = new(OPASpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthorizationSpec.
@@ -60,6 +67,59 @@ func (in *AuthorizationSpec) DeepCopy() *AuthorizationSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingSpec) DeepCopyInto(out *AutoscalingSpec) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetCPUUtilization != nil {
+		in, out := &in.TargetCPUUtilization, &out.TargetCPUUtilization
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetMemoryUtilization != nil {
+		in, out := &in.TargetMemoryUtilization, &out.TargetMemoryUtilization
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]v2.MetricSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingSpec.
+func (in *AutoscalingSpec) DeepCopy() *AutoscalingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CacheSpec) DeepCopyInto(out *CacheSpec) {
+	*out = *in
+	in.Managed.DeepCopyInto(&out.Managed)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CacheSpec.
+func (in *CacheSpec) DeepCopy() *CacheSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CacheSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComponentStatus) DeepCopyInto(out *ComponentStatus) {
 	*out = *in
@@ -138,6 +198,36 @@ func (in *ComponentStatus) DeepCopyInto(out *ComponentStatus) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.MetricsGenerator != nil {
+		in, out := &in.MetricsGenerator, &out.MetricsGenerator
+		*out = make(PodStatusMap, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Memcached != nil {
+		in, out := &in.Memcached, &out.Memcached
+		*out = make(PodStatusMap, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
 	if in.Gateway != nil {
 		in, out := &in.Gateway, &out.Gateway
 		*out = make(PodStatusMap, len(*in))
@@ -181,6 +271,43 @@ func (in *Defaulter) DeepCopy() *Defaulter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtraConfigSpec) DeepCopyInto(out *ExtraConfigSpec) {
+	*out = *in
+	in.Tempo.DeepCopyInto(&out.Tempo)
+	in.TempoQuery.DeepCopyInto(&out.TempoQuery)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtraConfigSpec.
+func (in *ExtraConfigSpec) DeepCopy() *ExtraConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtraConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayOPASpec) DeepCopyInto(out *GatewayOPASpec) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayOPASpec.
+func (in *GatewayOPASpec) DeepCopy() *GatewayOPASpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayOPASpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GrafanaConfigSpec) DeepCopyInto(out *GrafanaConfigSpec) {
 	*out = *in
@@ -296,6 +423,11 @@ func (in *JaegerQuerySpec) DeepCopyInto(out *JaegerQuerySpec) {
 	*out = *in
 	in.Ingress.DeepCopyInto(&out.Ingress)
 	out.MonitorTab = in.MonitorTab
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JaegerQuerySpec.
@@ -331,6 +463,23 @@ func (in *LimitSpec) DeepCopy() *LimitSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedCacheSpec) DeepCopyInto(out *ManagedCacheSpec) {
+	*out = *in
+	in.TempoComponentSpec.DeepCopyInto(&out.TempoComponentSpec)
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedCacheSpec.
+func (in *ManagedCacheSpec) DeepCopy() *ManagedCacheSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedCacheSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MemberListSpec) DeepCopyInto(out *MemberListSpec) {
 	*out = *in
@@ -366,6 +515,232 @@ func (in *MetricsConfigSpec) DeepCopy() *MetricsConfigSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsGeneratorLimitSpec) DeepCopyInto(out *MetricsGeneratorLimitSpec) {
+	*out = *in
+	if in.Processors != nil {
+		in, out := &in.Processors, &out.Processors
+		*out = make([]MetricsGeneratorProcessor, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsGeneratorLimitSpec.
+func (in *MetricsGeneratorLimitSpec) DeepCopy() *MetricsGeneratorLimitSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricsGeneratorLimitSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsGeneratorRemoteWriteSpec) DeepCopyInto(out *MetricsGeneratorRemoteWriteSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsGeneratorRemoteWriteSpec.
+func (in *MetricsGeneratorRemoteWriteSpec) DeepCopy() *MetricsGeneratorRemoteWriteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricsGeneratorRemoteWriteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonolithicIngestionOTLPProtocolSpec) DeepCopyInto(out *MonolithicIngestionOTLPProtocolSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonolithicIngestionOTLPProtocolSpec.
+func (in *MonolithicIngestionOTLPProtocolSpec) DeepCopy() *MonolithicIngestionOTLPProtocolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MonolithicIngestionOTLPProtocolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonolithicIngestionOTLPSpec) DeepCopyInto(out *MonolithicIngestionOTLPSpec) {
+	*out = *in
+	if in.GRPC != nil {
+		in, out := &in.GRPC, &out.GRPC
+		*out = new(MonolithicIngestionOTLPProtocolSpec)
+		**out = **in
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(MonolithicIngestionOTLPProtocolSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonolithicIngestionOTLPSpec.
+func (in *MonolithicIngestionOTLPSpec) DeepCopy() *MonolithicIngestionOTLPSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MonolithicIngestionOTLPSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonolithicIngestionSpec) DeepCopyInto(out *MonolithicIngestionSpec) {
+	*out = *in
+	if in.OTLP != nil {
+		in, out := &in.OTLP, &out.OTLP
+		*out = new(MonolithicIngestionOTLPSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	out.TLS = in.TLS
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonolithicIngestionSpec.
+func (in *MonolithicIngestionSpec) DeepCopy() *MonolithicIngestionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MonolithicIngestionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonolithicJaegerUISpec) DeepCopyInto(out *MonolithicJaegerUISpec) {
+	*out = *in
+	in.Ingress.DeepCopyInto(&out.Ingress)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonolithicJaegerUISpec.
+func (in *MonolithicJaegerUISpec) DeepCopy() *MonolithicJaegerUISpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MonolithicJaegerUISpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonolithicObservabilityMetricsSpec) DeepCopyInto(out *MonolithicObservabilityMetricsSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonolithicObservabilityMetricsSpec.
+func (in *MonolithicObservabilityMetricsSpec) DeepCopy() *MonolithicObservabilityMetricsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MonolithicObservabilityMetricsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonolithicObservabilitySpec) DeepCopyInto(out *MonolithicObservabilitySpec) {
+	*out = *in
+	out.Metrics = in.Metrics
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonolithicObservabilitySpec.
+func (in *MonolithicObservabilitySpec) DeepCopy() *MonolithicObservabilitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MonolithicObservabilitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonolithicStorageSpec) DeepCopyInto(out *MonolithicStorageSpec) {
+	*out = *in
+	in.Traces.DeepCopyInto(&out.Traces)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonolithicStorageSpec.
+func (in *MonolithicStorageSpec) DeepCopy() *MonolithicStorageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MonolithicStorageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonolithicTracesObjectStorageSpec) DeepCopyInto(out *MonolithicTracesObjectStorageSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonolithicTracesObjectStorageSpec.
+func (in *MonolithicTracesObjectStorageSpec) DeepCopy() *MonolithicTracesObjectStorageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MonolithicTracesObjectStorageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonolithicTracesStorageS3Spec) DeepCopyInto(out *MonolithicTracesStorageS3Spec) {
+	*out = *in
+	out.MonolithicTracesObjectStorageSpec = in.MonolithicTracesObjectStorageSpec
+	out.TLS = in.TLS
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonolithicTracesStorageS3Spec.
+func (in *MonolithicTracesStorageS3Spec) DeepCopy() *MonolithicTracesStorageS3Spec {
+	if in == nil {
+		return nil
+	}
+	out := new(MonolithicTracesStorageS3Spec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonolithicTracesStorageSpec) DeepCopyInto(out *MonolithicTracesStorageSpec) {
+	*out = *in
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(MonolithicTracesStorageS3Spec)
+		**out = **in
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(MonolithicTracesObjectStorageSpec)
+		**out = **in
+	}
+	if in.GCS != nil {
+		in, out := &in.GCS, &out.GCS
+		*out = new(MonolithicTracesObjectStorageSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonolithicTracesStorageSpec.
+func (in *MonolithicTracesStorageSpec) DeepCopy() *MonolithicTracesStorageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MonolithicTracesStorageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OIDCSpec) DeepCopyInto(out *OIDCSpec) {
 	*out = *in
@@ -386,6 +761,21 @@ func (in *OIDCSpec) DeepCopy() *OIDCSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OPASpec) DeepCopyInto(out *OPASpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OPASpec.
+func (in *OPASpec) DeepCopy() *OPASpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OPASpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectStorageSecretSpec) DeepCopyInto(out *ObjectStorageSecretSpec) {
 	*out = *in
@@ -451,6 +841,31 @@ func (in *ObservabilitySpec) DeepCopy() *ObservabilitySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodDisruptionBudgetSpec) DeepCopyInto(out *PodDisruptionBudgetSpec) {
+	*out = *in
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDisruptionBudgetSpec.
+func (in *PodDisruptionBudgetSpec) DeepCopy() *PodDisruptionBudgetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PodDisruptionBudgetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in PodStatusMap) DeepCopyInto(out *PodStatusMap) {
 	{
@@ -480,6 +895,75 @@ func (in PodStatusMap) DeepCopy() PodStatusMap {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodTemplateSpec) DeepCopyInto(out *PodTemplateSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]v1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]v1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Sidecars != nil {
+		in, out := &in.Sidecars, &out.Sidecars
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodTemplateSpec.
+func (in *PodTemplateSpec) DeepCopy() *PodTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PodTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *QueryLimit) DeepCopyInto(out *QueryLimit) {
 	*out = *in
@@ -511,6 +995,7 @@ func (in *RateLimitSpec) DeepCopyInto(out *RateLimitSpec) {
 	*out = *in
 	in.Ingestion.DeepCopyInto(&out.Ingestion)
 	in.Query.DeepCopyInto(&out.Query)
+	in.MetricsGenerator.DeepCopyInto(&out.MetricsGenerator)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitSpec.
@@ -543,7 +1028,7 @@ func (in *Resources) DeepCopyInto(out *Resources) {
 	*out = *in
 	if in.Total != nil {
 		in, out := &in.Total, &out.Total
-		*out = new(corev1.ResourceRequirements)
+		*out = new(v1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 }
@@ -720,11 +1205,23 @@ func (in *TempoComponentSpec) DeepCopyInto(out *TempoComponentSpec) {
 	}
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]corev1.Toleration, len(*in))
+		*out = make([]v1.Toleration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.Autoscaling.DeepCopyInto(&out.Autoscaling)
+	in.PodDisruptionBudget.DeepCopyInto(&out.PodDisruptionBudget)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodTemplate != nil {
+		in, out := &in.PodTemplate, &out.PodTemplate
+		*out = new(PodTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempoComponentSpec.
@@ -759,6 +1256,7 @@ func (in *TempoGatewaySpec) DeepCopyInto(out *TempoGatewaySpec) {
 	*out = *in
 	in.TempoComponentSpec.DeepCopyInto(&out.TempoComponentSpec)
 	in.Ingress.DeepCopyInto(&out.Ingress)
+	in.OPA.DeepCopyInto(&out.OPA)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempoGatewaySpec.
@@ -771,6 +1269,162 @@ func (in *TempoGatewaySpec) DeepCopy() *TempoGatewaySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TempoIngesterSpec) DeepCopyInto(out *TempoIngesterSpec) {
+	*out = *in
+	in.TempoComponentSpec.DeepCopyInto(&out.TempoComponentSpec)
+	in.ZoneAwareReplication.DeepCopyInto(&out.ZoneAwareReplication)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempoIngesterSpec.
+func (in *TempoIngesterSpec) DeepCopy() *TempoIngesterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TempoIngesterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TempoMetricsGeneratorSpec) DeepCopyInto(out *TempoMetricsGeneratorSpec) {
+	*out = *in
+	in.TempoComponentSpec.DeepCopyInto(&out.TempoComponentSpec)
+	if in.Processors != nil {
+		in, out := &in.Processors, &out.Processors
+		*out = make([]MetricsGeneratorProcessor, len(*in))
+		copy(*out, *in)
+	}
+	out.RemoteWrite = in.RemoteWrite
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempoMetricsGeneratorSpec.
+func (in *TempoMetricsGeneratorSpec) DeepCopy() *TempoMetricsGeneratorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TempoMetricsGeneratorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TempoMonolithic) DeepCopyInto(out *TempoMonolithic) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempoMonolithic.
+func (in *TempoMonolithic) DeepCopy() *TempoMonolithic {
+	if in == nil {
+		return nil
+	}
+	out := new(TempoMonolithic)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TempoMonolithic) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TempoMonolithicList) DeepCopyInto(out *TempoMonolithicList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TempoMonolithic, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempoMonolithicList.
+func (in *TempoMonolithicList) DeepCopy() *TempoMonolithicList {
+	if in == nil {
+		return nil
+	}
+	out := new(TempoMonolithicList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TempoMonolithicList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TempoMonolithicSpec) DeepCopyInto(out *TempoMonolithicSpec) {
+	*out = *in
+	in.Storage.DeepCopyInto(&out.Storage)
+	if in.Ingestion != nil {
+		in, out := &in.Ingestion, &out.Ingestion
+		*out = new(MonolithicIngestionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JaegerUI != nil {
+		in, out := &in.JaegerUI, &out.JaegerUI
+		*out = new(MonolithicJaegerUISpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Observability != nil {
+		in, out := &in.Observability, &out.Observability
+		*out = new(MonolithicObservabilitySpec)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempoMonolithicSpec.
+func (in *TempoMonolithicSpec) DeepCopy() *TempoMonolithicSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TempoMonolithicSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TempoMonolithicStatus) DeepCopyInto(out *TempoMonolithicStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempoMonolithicStatus.
+func (in *TempoMonolithicStatus) DeepCopy() *TempoMonolithicStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TempoMonolithicStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TempoQueryFrontendSpec) DeepCopyInto(out *TempoQueryFrontendSpec) {
 	*out = *in
@@ -870,6 +1524,12 @@ func (in *TempoStackSpec) DeepCopyInto(out *TempoStackSpec) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.Observability.DeepCopyInto(&out.Observability)
+	in.Cache.DeepCopyInto(&out.Cache)
+	if in.ExtraConfig != nil {
+		in, out := &in.ExtraConfig, &out.ExtraConfig
+		*out = new(ExtraConfigSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempoStackSpec.
@@ -888,7 +1548,7 @@ func (in *TempoStackStatus) DeepCopyInto(out *TempoStackStatus) {
 	in.Components.DeepCopyInto(&out.Components)
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+		*out = make([]metav1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -914,6 +1574,7 @@ func (in *TempoTemplateSpec) DeepCopyInto(out *TempoTemplateSpec) {
 	in.Querier.DeepCopyInto(&out.Querier)
 	in.QueryFrontend.DeepCopyInto(&out.QueryFrontend)
 	in.Gateway.DeepCopyInto(&out.Gateway)
+	in.MetricsGenerator.DeepCopyInto(&out.MetricsGenerator)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempoTemplateSpec.
@@ -982,3 +1643,47 @@ func (in *TracingConfigSpec) DeepCopy() *TracingConfigSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneAwareReplicationSpec) DeepCopyInto(out *ZoneAwareReplicationSpec) {
+	*out = *in
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]ZoneSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneAwareReplicationSpec.
+func (in *ZoneAwareReplicationSpec) DeepCopy() *ZoneAwareReplicationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneAwareReplicationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneSpec) DeepCopyInto(out *ZoneSpec) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneSpec.
+func (in *ZoneSpec) DeepCopy() *ZoneSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneSpec)
+	in.DeepCopyInto(out)
+	return out
+}
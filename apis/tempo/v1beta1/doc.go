@@ -0,0 +1,12 @@
+// Package v1beta1 contains API Schema definitions for the tempo v1beta1 API group.
+//
+// The package contains a complete copy of the TempoStack types instead of reusing the unchanged
+// types of v1alpha1, because:
+//   - v1beta1 is the conversion hub, i.e. v1alpha1 imports this package and this package cannot import v1alpha1
+//   - changes to a type of one API version must not silently change the schema of the other version
+//   - each version of a CRD contains its complete OpenAPI schema, sharing the Go types would not reduce the size of the CRD
+//
+// The differences between the versions are listed in the conversion functions of v1alpha1.
+// +kubebuilder:object:generate=true
+// +groupName=tempo.grafana.com
+package v1beta1
@@ -0,0 +1,5 @@
+package v1beta1
+
+// Hub marks this type as a conversion hub.
+// The v1alpha1 TempoStack implements the conversion from and to this version.
+func (*TempoStack) Hub() {}
@@ -0,0 +1,1443 @@
+package v1beta1
+
+import (
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/grafana/tempo-operator/apis/config/v1alpha1"
+)
+
+// ManagementStateType defines the type for CR management states.
+//
+// +kubebuilder:validation:Enum=Managed;Unmanaged
+type ManagementStateType string
+
+const (
+	// ManagementStateManaged when the TempoStack custom resource should be
+	// reconciled by the operator.
+	ManagementStateManaged ManagementStateType = "Managed"
+
+	// ManagementStateUnmanaged when the TempoStack custom resource should not be
+	// reconciled by the operator.
+	ManagementStateUnmanaged ManagementStateType = "Unmanaged"
+)
+
+// VolumeRetentionPolicy defines if the PVCs of removed ingesters are retained or deleted.
+//
+// +kubebuilder:validation:Enum=Retain;Delete
+type VolumeRetentionPolicy string
+
+const (
+	// VolumeRetentionPolicyRetain keeps the PVCs of removed ingesters.
+	VolumeRetentionPolicyRetain VolumeRetentionPolicy = "Retain"
+	// VolumeRetentionPolicyDelete deletes the PVCs of removed ingesters.
+	VolumeRetentionPolicyDelete VolumeRetentionPolicy = "Delete"
+)
+
+// LogLevel defines the log level of a component.
+//
+// +kubebuilder:validation:Enum=debug;info;warn;error
+type LogLevel string
+
+const (
+	// LogLevelDebug logs debug, info, warn and error messages.
+	LogLevelDebug LogLevel = "debug"
+	// LogLevelInfo logs info, warn and error messages.
+	LogLevelInfo LogLevel = "info"
+	// LogLevelWarn logs warn and error messages.
+	LogLevelWarn LogLevel = "warn"
+	// LogLevelError logs error messages.
+	LogLevelError LogLevel = "error"
+)
+
+// LogFormat defines the log format of the components.
+//
+// +kubebuilder:validation:Enum=logfmt;json
+type LogFormat string
+
+const (
+	// LogFormatLogfmt logs in the logfmt format.
+	LogFormatLogfmt LogFormat = "logfmt"
+	// LogFormatJSON logs in the JSON format.
+	LogFormatJSON LogFormat = "json"
+)
+
+// MemberListSecurity defines the isolation and encryption of the memberlist gossip ring.
+//
+// +kubebuilder:validation:Enum=Legacy;LabelVerificationDisabled;ClusterLabelUnverified;ClusterLabel;Secure
+type MemberListSecurity string
+
+const (
+	// MemberListSecurityLegacy configures memberlist without cluster label and TLS, as operator versions before 0.7.0.
+	MemberListSecurityLegacy MemberListSecurity = "Legacy"
+	// MemberListSecurityLabelVerificationDisabled accepts gossip messages with any cluster label, without setting a cluster label.
+	MemberListSecurityLabelVerificationDisabled MemberListSecurity = "LabelVerificationDisabled"
+	// MemberListSecurityClusterLabelUnverified sets the cluster label and accepts gossip messages with any cluster label.
+	MemberListSecurityClusterLabelUnverified MemberListSecurity = "ClusterLabelUnverified"
+	// MemberListSecurityClusterLabel sets the cluster label and rejects gossip messages with a different cluster label.
+	MemberListSecurityClusterLabel MemberListSecurity = "ClusterLabel"
+	// MemberListSecuritySecure sets and verifies the cluster label, and encrypts the gossip traffic
+	// if the grpcEncryption feature gate is enabled.
+	MemberListSecuritySecure MemberListSecurity = "Secure"
+)
+
+// TempoStackSpec defines the desired state of TempoStack.
+type TempoStackSpec struct {
+	// ManagementState defines if the CR should be managed by the operator or not.
+	// Default is managed.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:default:=Managed
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:Managed","urn:alm:descriptor:com.tectonic.ui:select:Unmanaged"},displayName="Management State"
+	ManagementState ManagementStateType `json:"managementState,omitempty"`
+
+	// LimitSpec is used to limit ingestion and querying rates.
+	//
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Ingestion and Querying Ratelimiting"
+	LimitSpec LimitSpec `json:"limits,omitempty"`
+
+	// StorageClassName for PVCs used by ingester. Defaults to nil (default storage class in the cluster).
+	//
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="StorageClassName for PVCs"
+	StorageClassName *string `json:"storageClassName,omitempty"`
+
+	// Resources defines resources configuration.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resources"
+	Resources Resources `json:"resources,omitempty"`
+
+	// StorageSize for PVCs used by ingester. Defaults to 10Gi.
+	// The size can be increased if the StorageClass of the PVCs allows volume expansion, it cannot be decreased.
+	//
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Storage size for PVCs"
+	StorageSize resource.Quantity `json:"storageSize,omitempty"`
+
+	// VolumeRetentionPolicy defines if the PVCs of ingesters, which are removed by a scale-down, are retained or deleted.
+	// The PVCs are deleted after the ingesters flushed their data to the object storage and left the ring.
+	// Defaults to Retain.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Volume Retention Policy",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:Retain","urn:alm:descriptor:com.tectonic.ui:select:Delete"}
+	VolumeRetentionPolicy VolumeRetentionPolicy `json:"volumeRetentionPolicy,omitempty"`
+
+	// Images defines the image for each container.
+	//
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Container Images"
+	Images v1alpha1.ImagesSpec `json:"images,omitempty"`
+
+	// Storage defines the spec for the object storage endpoint to store traces.
+	// User is required to create secret and supply it.
+	//
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Object Storage"
+	Storage ObjectStorageSpec `json:"storage"`
+
+	// NOTE: currently this field is not considered.
+	// Retention period defined by dataset.
+	// User can specify how long data should be stored.
+	//
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Retention Period"
+	Retention RetentionSpec `json:"retention,omitempty"`
+
+	// ServiceAccount defines the service account to use for all tempo components.
+	//
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Service Account"
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+
+	// SearchSpec control the configuration for the search capabilities.
+	//
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Search configuration options"
+	SearchSpec SearchSpec `json:"search,omitempty"`
+
+	// HashRing defines the spec for the distributed hash ring configuration.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:com.tectonic.ui:advanced",displayName="Hash Ring"
+	HashRing HashRingSpec `json:"hashRing,omitempty"`
+
+	// Template defines requirements for a set of tempo components.
+	//
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Tempo Component Templates"
+	Template TempoTemplateSpec `json:"template,omitempty"`
+
+	// ReplicationFactor is used to define how many component replicas should exist.
+	//
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Replication Factor"
+	ReplicationFactor int `json:"replicationFactor,omitempty"`
+
+	// Tenants defines the per-tenant authentication and authorization spec.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Tenants Configuration"
+	Tenants *TenantsSpec `json:"tenants,omitempty"`
+
+	// ObservabilitySpec defines how telemetry data gets handled.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Observability"
+	Observability ObservabilitySpec `json:"observability,omitempty"`
+
+	// LogFormat defines the log format of the Tempo components and the gateway.
+	// Defaults to logfmt.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Log Format",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:logfmt","urn:alm:descriptor:com.tectonic.ui:select:json"}
+	LogFormat LogFormat `json:"logFormat,omitempty"`
+
+	// Cache defines the caching layer used by the querier and query-frontend.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Cache"
+	Cache CacheSpec `json:"cache,omitempty"`
+
+	// ExtraConfig defines any extra (overlay) configuration for components.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Extra Configurations"
+	ExtraConfig *ExtraConfigSpec `json:"extraConfig,omitempty"`
+}
+
+// ExtraConfigSpec defines extra configurations for Tempo and tempo-query.
+// The extra configuration is deep-merged over the configuration generated by the operator,
+// i.e. maps are merged recursively and all other values (including lists) replace the generated values.
+// Overriding settings managed by the operator can result in a non-functional deployment.
+type ExtraConfigSpec struct {
+	// Tempo defines any extra Tempo configuration, which will be merged with the operator's generated Tempo configuration (tempo.yaml).
+	// The values are used literally, environment variable references like ${VAR} are not expanded.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Tempo Extra Configurations"
+	Tempo apiextensionsv1.JSON `json:"tempo,omitempty"`
+
+	// TempoQuery defines any extra tempo-query configuration, which will be merged with the operator's generated
+	// tempo-query configuration (tempo-query.yaml).
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Tempo Query Extra Configurations"
+	TempoQuery apiextensionsv1.JSON `json:"tempoQuery,omitempty"`
+}
+
+// CacheSpec defines the caching layer used by Tempo.
+type CacheSpec struct {
+	// Backend defines the cache backend.
+	// The cache is disabled if no backend is set.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Backend",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:memcached","urn:alm:descriptor:com.tectonic.ui:select:redis"}
+	Backend CacheBackendType `json:"backend,omitempty"`
+
+	// Endpoint defines the address of an external cache.
+	// For memcached, this is a comma separated list of addresses, e.g. dns+memcached.svc:11211.
+	// For redis, this is the host:port of the redis server.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Endpoint"
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Managed defines a memcached instance deployed and managed by the operator.
+	// Only supported by the memcached backend.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Managed memcached"
+	Managed ManagedCacheSpec `json:"managed,omitempty"`
+}
+
+// CacheBackendType defines the cache backend.
+//
+// +kubebuilder:validation:Enum=memcached;redis
+type CacheBackendType string
+
+const (
+	// CacheBackendMemcached uses memcached as cache backend.
+	CacheBackendMemcached CacheBackendType = "memcached"
+	// CacheBackendRedis uses redis as cache backend.
+	CacheBackendRedis CacheBackendType = "redis"
+)
+
+// ManagedCacheSpec defines a memcached instance managed by the operator.
+type ManagedCacheSpec struct {
+	// TempoComponentSpec is embedded to extend this definition with further options.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	TempoComponentSpec `json:",inline"`
+
+	// Enabled defines if the operator should deploy memcached.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enabled",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled bool `json:"enabled"`
+
+	// MemoryLimitMB defines the memory (in megabytes) memcached uses for items.
+	// Defaults to 1024.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=64
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Memory Limit (MB)",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	MemoryLimitMB int `json:"memoryLimitMB,omitempty"`
+}
+
+// ObservabilitySpec defines how telemetry data gets handled.
+type ObservabilitySpec struct {
+	// Metrics defines the metrics configuration for operands.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Metrics Config"
+	Metrics MetricsConfigSpec `json:"metrics,omitempty"`
+
+	// Tracing defines a config for operands.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Tracing Config"
+	Tracing TracingConfigSpec `json:"tracing,omitempty"`
+
+	// Grafana defines the Grafana configuration for operands.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Grafana Config"
+	Grafana GrafanaConfigSpec `json:"grafana,omitempty"`
+}
+
+// MetricsConfigSpec defines a metrics config.
+type MetricsConfigSpec struct {
+	// CreateServiceMonitors specifies if ServiceMonitors should be created for Tempo components.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Create ServiceMonitors for Tempo components"
+	CreateServiceMonitors bool `json:"createServiceMonitors,omitempty"`
+
+	// CreatePrometheusRules specifies if Prometheus rules for alerts should be created for Tempo components.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Create PrometheusRules for Tempo components"
+	CreatePrometheusRules bool `json:"createPrometheusRules,omitempty"`
+}
+
+// TracingConfigSpec defines a tracing config including endpoints and sampling.
+type TracingConfigSpec struct {
+	// SamplingFraction defines the sampling ratio. Valid values are 0 to 1.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Sampling Fraction"
+	SamplingFraction string `json:"samplingFraction,omitempty"`
+
+	// JaegerAgentEndpoint defines the jaeger endpoint data gets send to.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="localhost:6831"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Jaeger-Agent-Endpoint"
+	JaegerAgentEndpoint string `json:"jaegerAgentEndpoint,omitempty"`
+}
+
+// GrafanaConfigSpec defines configuration for Grafana.
+type GrafanaConfigSpec struct {
+	// CreateDatasource specifies if a Grafana Datasource should be created for Tempo.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Create Datasource for Tempo"
+	CreateDatasource bool `json:"createDatasource,omitempty"`
+
+	// InstanceSelector specifies the Grafana instance where the datasource should be created.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Create CreateDatasource for Tempo"
+	InstanceSelector metav1.LabelSelector `json:"instanceSelector,omitempty"`
+}
+
+// PodStatusMap defines the type for mapping pod status to pod name.
+type PodStatusMap map[corev1.PodPhase][]string
+
+// ComponentStatus defines the status of each component.
+type ComponentStatus struct {
+	// Compactor is a map to the pod status of the compactor pod.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:com.tectonic.ui:podStatuses",displayName="Compactor",order=5
+	Compactor PodStatusMap `json:"compactor"`
+
+	// Distributor is a map to the per pod status of the distributor deployment
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:com.tectonic.ui:podStatuses",displayName="Distributor",order=1
+	Distributor PodStatusMap `json:"distributor"`
+
+	// Ingester is a map to the per pod status of the ingester statefulset
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:com.tectonic.ui:podStatuses",displayName="Ingester",order=2
+	Ingester PodStatusMap `json:"ingester"`
+
+	// Querier is a map to the per pod status of the querier deployment
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:com.tectonic.ui:podStatuses",displayName="Querier",order=3
+	Querier PodStatusMap `json:"querier"`
+
+	// QueryFrontend is a map to the per pod status of the query frontend deployment
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:com.tectonic.ui:podStatuses",displayName="Query Frontend",order=4
+	QueryFrontend PodStatusMap `json:"queryFrontend"`
+
+	// MetricsGenerator is a map to the per pod status of the metrics-generator deployment
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:com.tectonic.ui:podStatuses",displayName="Metrics Generator",order=6
+	MetricsGenerator PodStatusMap `json:"metricsGenerator,omitempty"`
+
+	// Memcached is a map to the per pod status of the managed memcached statefulset
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:com.tectonic.ui:podStatuses",displayName="Memcached",order=7
+	Memcached PodStatusMap `json:"memcached,omitempty"`
+
+	// Gateway is a map to the per pod status of the query frontend deployment
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:com.tectonic.ui:podStatuses",displayName="Query Frontend",order=4
+	Gateway PodStatusMap `json:"gateway"`
+}
+
+// TempoStackStatus defines the observed state of TempoStack.
+type TempoStackStatus struct {
+	// Version of the Tempo Operator.
+	// +optional
+	OperatorVersion string `json:"operatorVersion,omitempty"`
+
+	// Version of the managed Tempo instance.
+	// +optional
+	TempoVersion string `json:"tempoVersion,omitempty"`
+
+	// Components provides summary of all Tempo pod status grouped
+	// per component.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	Components ComponentStatus `json:"components,omitempty"`
+
+	// Conditions of the Tempo deployment health.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:io.kubernetes.conditions"
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// ConditionStatus defines the status of a condition (e.g. ready, failed, pending or configuration error).
+type ConditionStatus string
+
+const (
+	// ConditionReady defines that all components are ready.
+	ConditionReady ConditionStatus = "Ready"
+	// ConditionFailed defines that one or more components are in a failed state.
+	ConditionFailed ConditionStatus = "Failed"
+	// ConditionPending defines that one or more components are in a pending state.
+	ConditionPending ConditionStatus = "Pending"
+	// ConditionConfigurationError defines that there is a configuration error.
+	ConditionConfigurationError ConditionStatus = "ConfigurationError"
+	// ConditionStorageReachable defines whether the object storage is reachable with the configured credentials.
+	// In contrast to the other conditions, it is set independently of the state of the components.
+	ConditionStorageReachable ConditionStatus = "StorageReachable"
+)
+
+// AllStatusConditions lists all possible status conditions.
+var AllStatusConditions = []ConditionStatus{ConditionReady, ConditionFailed, ConditionPending, ConditionConfigurationError}
+
+// ConditionReason defines possible reasons for each condition.
+type ConditionReason string
+
+const (
+	// ReasonReady defines a healthy tempo instance.
+	ReasonReady ConditionReason = "Ready"
+	// ReasonInvalidStorageConfig defines that the object storage configuration is invalid (missing or incomplete storage secret).
+	ReasonInvalidStorageConfig ConditionReason = "InvalidStorageConfig"
+	// ReasonFailedComponents when all/some Tempo components fail to roll out.
+	ReasonFailedComponents ConditionReason = "FailedComponents"
+	// ReasonPendingComponents when all/some Tempo components pending dependencies.
+	ReasonPendingComponents ConditionReason = "PendingComponents"
+	// ReasonCouldNotGetOpenShiftBaseDomain when operator cannot get OpenShift base domain, that is used for OAuth redirect URL.
+	ReasonCouldNotGetOpenShiftBaseDomain ConditionReason = "CouldNotGetOpenShiftBaseDomain"
+	// ReasonCouldNotGetOpenShiftTLSPolicy when operator cannot get OpenShift TLS security cluster policy.
+	ReasonCouldNotGetOpenShiftTLSPolicy ConditionReason = "CouldNotGetOpenShiftTLSPolicy"
+	// ReasonMissingGatewayTenantSecret when operator cannot get Secret containing sensitive Gateway information.
+	ReasonMissingGatewayTenantSecret ConditionReason = "ReasonMissingGatewayTenantSecret"
+	// ReasonInvalidTenantsConfiguration when the tenant configuration provided is invalid.
+	ReasonInvalidTenantsConfiguration ConditionReason = "InvalidTenantsConfiguration"
+	// ReasonInvalidMetricsGeneratorConfig defines that the metrics-generator configuration is invalid (missing or incomplete remote write secret).
+	ReasonInvalidMetricsGeneratorConfig ConditionReason = "InvalidMetricsGeneratorConfig"
+	// ReasonFailedReconciliation when the operator failed to reconcile.
+	ReasonFailedReconciliation ConditionReason = "FailedReconciliation"
+	// ReasonVolumeExpansionInProgress when the persistent volumes of the ingesters are being expanded.
+	ReasonVolumeExpansionInProgress ConditionReason = "VolumeExpansionInProgress"
+	// ReasonVolumeExpansionFailed when the persistent volumes of the ingesters cannot be expanded.
+	ReasonVolumeExpansionFailed ConditionReason = "VolumeExpansionFailed"
+	// ReasonIngesterScaleDownInProgress when the ingesters removed by a scale-down are flushing their data and leaving the ring.
+	ReasonIngesterScaleDownInProgress ConditionReason = "IngesterScaleDownInProgress"
+	// ReasonIngesterScaleDownFailed when the ingesters removed by a scale-down cannot be shut down safely,
+	// for example because the ingester ring is not reachable.
+	ReasonIngesterScaleDownFailed ConditionReason = "IngesterScaleDownFailed"
+	// ReasonStorageCheckSucceeded when the object storage is reachable with the configured credentials.
+	ReasonStorageCheckSucceeded ConditionReason = "StorageCheckSucceeded"
+	// ReasonStorageCheckFailed when the object storage cannot be listed or written with the configured credentials.
+	ReasonStorageCheckFailed ConditionReason = "StorageCheckFailed"
+	// ReasonStorageCheckNotSupported when the object storage cannot be checked by the operator, e.g. in the token credential mode.
+	ReasonStorageCheckNotSupported ConditionReason = "StorageCheckNotSupported"
+)
+
+// Resources defines resources configuration.
+type Resources struct {
+	// The total amount of resources for Tempo instance.
+	// The operator autonomously splits resources between deployed Tempo components.
+	// Only limits are supported, the operator calculates requests automatically.
+	// Components with explicit resources (spec.template.<component>.resources) are excluded from the split,
+	// and their limits are subtracted from the total resources.
+	// See http://github.com/grafana/tempo/issues/1540.
+	//
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Requirements"
+	Total *corev1.ResourceRequirements `json:"total,omitempty"`
+}
+
+// SearchSpec specified the global search parameters.
+type SearchSpec struct {
+	// Limit used for search requests if none is set by the caller (default: 20)
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Limit used for search requests if none is set by the caller, this limit the number of traces returned by the query"
+	DefaultResultLimit *int `json:"defaultResultLimit,omitempty"`
+	// The maximum allowed time range for a search, default: 0s which means unlimited.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max search time range allowed"
+	MaxDuration metav1.Duration `json:"maxDuration,omitempty"`
+	// The maximum allowed value of the limit parameter on search requests. If the search request limit parameter
+	// exceeds the value configured here it will be set to the value configured here.
+	// The default value of 0 disables this limit.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="The maximum allowed value of the limit parameter on search requests, this determine the max number of traces allowed to be returned"
+	MaxResultLimit int `json:"maxResultLimit,omitempty"`
+}
+
+// ObjectStorageSecretType defines the type of storage which can be used with the Tempo cluster.
+//
+// +kubebuilder:validation:Enum=azure;gcs;s3
+type ObjectStorageSecretType string
+
+const (
+	// ObjectStorageSecretAzure when using Azure Storage for Tempo storage.
+	ObjectStorageSecretAzure ObjectStorageSecretType = "azure"
+
+	// ObjectStorageSecretGCS when using Google Cloud Storage for Tempo storage.
+	ObjectStorageSecretGCS ObjectStorageSecretType = "gcs"
+
+	// ObjectStorageSecretS3 when using S3 for Tempo storage.
+	ObjectStorageSecretS3 ObjectStorageSecretType = "s3"
+)
+
+// CredentialMode defines how the Tempo components authenticate to the object storage.
+//
+// +kubebuilder:validation:Enum=static;token
+type CredentialMode string
+
+const (
+	// CredentialModeStatic uses the long-lived credentials stored in the object storage secret.
+	CredentialModeStatic CredentialMode = "static"
+
+	// CredentialModeToken uses short-lived credentials, which are obtained by exchanging
+	// a projected service account token with the identity provider of the cloud provider.
+	CredentialModeToken CredentialMode = "token"
+)
+
+// ObjectStorageSecretSpec is a secret reference containing name only, no namespace.
+type ObjectStorageSecretSpec struct {
+	// Type of object storage that should be used
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:azure","urn:alm:descriptor:com.tectonic.ui:select:gcs","urn:alm:descriptor:com.tectonic.ui:select:s3"},displayName="Object Storage Secret Type"
+	Type ObjectStorageSecretType `json:"type"`
+
+	// Name of a secret in the namespace configured for object storage secrets.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:io.kubernetes:Secret",displayName="Object Storage Secret Name"
+	Name string `json:"name"`
+}
+
+// ObjectStorageSpec defines the requirements to access the object
+// storage bucket to persist traces by the ingester component.
+type ObjectStorageSpec struct {
+	// TLS configuration for reaching the object storage endpoint.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="TLS Config"
+	TLS ObjectStorageTLSSpec `json:"tls,omitempty"`
+
+	// Secret for object storage authentication.
+	// Name of a secret in the same namespace as the TempoStack custom resource.
+	//
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Object Storage Secret"
+	Secret ObjectStorageSecretSpec `json:"secret"`
+	// Don't forget to update storageSecretField in tempostack_controller.go if this field name changes.
+
+	// CredentialMode defines how the Tempo components authenticate to the object storage.
+	// The static mode (default) uses the credentials stored in the object storage secret.
+	// The token mode uses short-lived credentials obtained with a projected service account token,
+	// i.e. AWS STS with IAM roles for service accounts (IRSA), Azure Workload Identity or
+	// GCP Workload Identity Federation.
+	// In token mode, the S3 storage secret must contain the "bucket", "region" and "role_arn" fields,
+	// and the Azure storage secret must contain the "container", "account_name", "client_id", "tenant_id"
+	// and "subscription_id" fields.
+	// The GCS storage secret must contain the "bucketname" field, and optionally a workload identity federation
+	// credential configuration in the "key.json" field (without it, GKE Workload Identity is used) and the
+	// GCP service account to impersonate in the "iam_service_account" field.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:static","urn:alm:descriptor:com.tectonic.ui:select:token"},displayName="Credential Mode"
+	CredentialMode CredentialMode `json:"credentialMode,omitempty"`
+
+	// Prefix is the path prefix of the objects written to the bucket or container.
+	// It allows several TempoStacks to share a bucket, each with its own prefix.
+	// If not set, the "prefix" field of the storage secret is used.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Prefix"
+	Prefix string `json:"prefix,omitempty"`
+}
+
+// ObjectStorageTLSSpec is the TLS configuration for reaching the object storage endpoint.
+type ObjectStorageTLSSpec struct {
+	// CA is the name of a ConfigMap containing a `ca.crt` key with a CA certificate.
+	// It needs to be in the same namespace as the TempoStack custom resource.
+	//
+	// +optional
+	// +kubebuilder:validation:optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:io.kubernetes:ConfigMap",displayName="CA ConfigMap Name"
+	CA string `json:"caName,omitempty"`
+}
+
+// MemberListSpec defines the configuration for the memberlist based hash ring.
+type MemberListSpec struct {
+	// EnableIPv6 enables IPv6 support for the memberlist based hash ring.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch",displayName="Enable IPv6"
+	EnableIPv6 *bool `json:"enableIPv6,omitempty"`
+
+	// Security defines the isolation and encryption of the memberlist gossip ring.
+	// Secure sets a cluster label derived from the namespace and name of the TempoStack,
+	// rejects gossip messages of other rings, and encrypts the gossip traffic if the grpcEncryption feature gate is enabled.
+	// TempoStacks created before operator version 0.7.0 are set to Legacy by the operator upgrade,
+	// and are switched over by setting the next step after the rollout of the previous step has finished:
+	// Legacy, LabelVerificationDisabled, ClusterLabelUnverified, ClusterLabel, Secure.
+	// Defaults to Secure.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Security",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:Legacy","urn:alm:descriptor:com.tectonic.ui:select:LabelVerificationDisabled","urn:alm:descriptor:com.tectonic.ui:select:ClusterLabelUnverified","urn:alm:descriptor:com.tectonic.ui:select:ClusterLabel","urn:alm:descriptor:com.tectonic.ui:select:Secure"}
+	Security MemberListSecurity `json:"security,omitempty"`
+}
+
+// HashRingSpec defines the hash ring configuration.
+type HashRingSpec struct {
+	// MemberList configuration spec
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Memberlist Config"
+	MemberList MemberListSpec `json:"memberlist,omitempty"`
+}
+
+// ReceiversTLSSpec is the TLS configuration for the receivers.
+type ReceiversTLSSpec struct {
+	Enabled bool `json:"enabled"`
+	// caName is the name of a ConfigMap containing a CA certificate.
+	// It needs to be in the same namespace as the Tempo custom resource.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:io.kubernetes:ConfigMap",displayName="CA ConfigMap Name"
+	CA string `json:"caName,omitempty"`
+
+	// certName is the name of a Secret containing a certificate and the private key
+	// It needs to be in the same namespace as the Tempo custom resource.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:io.kubernetes:Secret",displayName="Certificate Secret Name"
+	Cert string `json:"certName,omitempty"`
+
+	// minVersion is the name of a Secret containing a certificate and the private key
+	// It needs to be in the same namespace as the Tempo custom resource.
+	//
+	// +optional
+	// +kubebuilder:validation:optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Min TLS Version"
+	MinVersion string `json:"minVersion,omitempty"`
+}
+
+// TempoTemplateSpec defines the template of all requirements to configure
+// scheduling of all Tempo components to be deployed.
+type TempoTemplateSpec struct {
+	// Distributor defines the distributor component spec.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Distributor pods"
+	Distributor TempoDistributorSpec `json:"distributor,omitempty"`
+
+	// Ingester defines the ingester component spec.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Ingester pods"
+	Ingester TempoIngesterSpec `json:"ingester,omitempty"`
+
+	// Compactor defines the tempo compactor component spec.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Compactor pods"
+	Compactor TempoComponentSpec `json:"compactor,omitempty"`
+
+	// Querier defines the querier component spec.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Querier pods"
+	Querier TempoComponentSpec `json:"querier,omitempty"`
+
+	// TempoQueryFrontendSpec defines the query frontend spec.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Query Frontend pods"
+	QueryFrontend TempoQueryFrontendSpec `json:"queryFrontend,omitempty"`
+
+	// Gateway defines the tempo gateway spec.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Gateway pods"
+	Gateway TempoGatewaySpec `json:"gateway,omitempty"`
+
+	// MetricsGenerator defines the metrics-generator component spec.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Metrics Generator pods"
+	MetricsGenerator TempoMetricsGeneratorSpec `json:"metricsGenerator,omitempty"`
+}
+
+// TempoDistributorSpec defines the template of all requirements to configure
+// scheduling of Tempo distributor component to be deployed.
+type TempoDistributorSpec struct {
+	// TempoComponentSpec is embedded to extend this definition with further options.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	TempoComponentSpec `json:",inline"`
+
+	// TLS defines TLS configuration for distributor receivers
+	//
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="TLS"
+	TLS ReceiversTLSSpec `json:"tls,omitempty"`
+}
+
+// TempoIngesterSpec defines the template of all requirements to configure
+// scheduling of Tempo ingester component to be deployed.
+type TempoIngesterSpec struct {
+	// TempoComponentSpec is embedded to extend this definition with further options.
+	// The fields are inlined to keep the fields of the ingester spec unchanged.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	TempoComponentSpec `json:",inline"`
+
+	// ZoneAwareReplication defines the zone-aware replication of the ingesters.
+	// If enabled, the operator creates one ingester StatefulSet per zone, and the replicas of a trace
+	// are placed in different zones.
+	// Zone-aware replication cannot be enabled or disabled, and the zones cannot be renamed, added, removed
+	// or reordered after the TempoStack is created.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Zone-Aware Replication"
+	ZoneAwareReplication ZoneAwareReplicationSpec `json:"zoneAwareReplication,omitempty"`
+}
+
+// TempoComponentSpec defines specific schedule settings for tempo components.
+type TempoComponentSpec struct {
+	// Replicas represents the number of replicas to create for this component.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Component Replicas"
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// NodeSelector is the simplest recommended form of node selection constraint.
+	//
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Node Selector"
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations defines component specific pod tolerations.
+	//
+	// +optional
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Tolerations"
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Autoscaling defines a HorizontalPodAutoscaler for this component.
+	// Autoscaling is supported by the distributor, ingester, querier, query-frontend and compactor.
+	// If enabled, the replicas of the component are managed by the HorizontalPodAutoscaler.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Autoscaling"
+	Autoscaling AutoscalingSpec `json:"autoscaling,omitempty"`
+
+	// PodDisruptionBudget overrides the PodDisruptionBudget created by the operator for this component.
+	// By default, one pod of each component can be unavailable at a time.
+	// For the ingester, the maximum number of unavailable pods is derived from the replication factor.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Pod Disruption Budget"
+	PodDisruptionBudget PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+
+	// LogLevel defines the log level of this component.
+	// The log level of the query-frontend is also used by the tempo-query container.
+	// Defaults to info.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Log Level",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:debug","urn:alm:descriptor:com.tectonic.ui:select:info","urn:alm:descriptor:com.tectonic.ui:select:warn","urn:alm:descriptor:com.tectonic.ui:select:error"}
+	LogLevel LogLevel `json:"logLevel,omitempty"`
+
+	// Resources defines the compute resources of this component.
+	// If set, the resources take precedence over the share of the total resources (spec.resources.total).
+	// The remaining total resources are split across the components without explicit resources.
+	// The managed cache does not receive a share of the total resources.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resources",xDescriptors="urn:alm:descriptor:com.tectonic.ui:resourceRequirements"
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// PodTemplate defines overrides of the pod template of this component.
+	// The overrides are applied on top of the pod template created by the operator.
+	// PodTemplate is supported by the distributor, ingester, querier, query-frontend, compactor, gateway and metrics-generator.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Type=object
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Pod Template"
+	PodTemplate *PodTemplateSpec `json:"podTemplate,omitempty"`
+}
+
+// ZoneAwareReplicationSpec defines the zone-aware replication of the ingesters.
+type ZoneAwareReplicationSpec struct {
+	// Enabled defines if zone-aware replication is enabled.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enabled",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Zones defines the availability zones of the ingesters.
+	// The number of zones must be greater than or equal to the replication factor.
+	// The ingester replicas are distributed evenly across the zones.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Zones"
+	Zones []ZoneSpec `json:"zones,omitempty"`
+}
+
+// ZoneSpec defines an availability zone of the ingesters.
+type ZoneSpec struct {
+	// Name of the zone.
+	// The name is used in the name of the ingester StatefulSet of this zone.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Name"
+	Name string `json:"name"`
+
+	// NodeSelector schedules the ingesters of this zone on the nodes of the zone,
+	// for example topology.kubernetes.io/zone: us-east-1a.
+	// The node selector is merged with the node selector of the ingester.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Node Selector"
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+}
+
+// PodTemplateSpec defines overrides of the pod template of a component.
+// The overrides are applied as a strategic merge patch, i.e. the env vars, volumes and volume mounts
+// are merged with the ones created by the operator by their name (mount path for volume mounts).
+type PodTemplateSpec struct {
+	// Labels are added to the labels of the pods.
+	// The labels created by the operator can't be overridden.
+	//
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations are added to the annotations of the pods.
+	//
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Affinity replaces the default affinity of the pods.
+	//
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// TopologySpreadConstraints defines the topology spread constraints of the pods.
+	//
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// PriorityClassName defines the priority class of the pods.
+	//
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// Env defines additional environment variables of the main container.
+	//
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Volumes defines additional volumes of the pods.
+	//
+	// +optional
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+
+	// VolumeMounts defines additional volume mounts of the main container.
+	//
+	// +optional
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+
+	// Sidecars defines additional containers of the pods.
+	//
+	// +optional
+	Sidecars []corev1.Container `json:"sidecars,omitempty"`
+}
+
+// PodDisruptionBudgetSpec defines the PodDisruptionBudget settings of a component.
+// Only one of MinAvailable and MaxUnavailable can be set.
+type PodDisruptionBudgetSpec struct {
+	// MinAvailable is the number or percentage of pods which must be available after an eviction.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Minimum Available"
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// MaxUnavailable is the number or percentage of pods which can be unavailable after an eviction.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Maximum Unavailable"
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// AutoscalingSpec defines the HorizontalPodAutoscaler settings of a component.
+type AutoscalingSpec struct {
+	// Enabled defines if a HorizontalPodAutoscaler should be created for this component.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enabled",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled bool `json:"enabled,omitempty"`
+
+	// MinReplicas is the lower limit for the number of replicas of this component.
+	// Defaults to the replicas of the component.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Minimum Replicas",xDescriptors="urn:alm:descriptor:com.tectonic.ui:podCount"
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas is the upper limit for the number of replicas of this component.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Maximum Replicas",xDescriptors="urn:alm:descriptor:com.tectonic.ui:podCount"
+	MaxReplicas int32 `json:"maxReplicas,omitempty"`
+
+	// TargetCPUUtilization is the target average CPU utilization (in percent of the requested CPU) of all pods.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Target CPU Utilization"
+	TargetCPUUtilization *int32 `json:"targetCPUUtilization,omitempty"`
+
+	// TargetMemoryUtilization is the target average memory utilization (in percent of the requested memory) of all pods.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Target Memory Utilization"
+	TargetMemoryUtilization *int32 `json:"targetMemoryUtilization,omitempty"`
+
+	// Metrics defines additional metrics, for example custom or external metrics,
+	// which are used by the HorizontalPodAutoscaler to calculate the number of replicas.
+	// The metrics are validated by the HorizontalPodAutoscaler API (autoscaling/v2).
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Metrics",xDescriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	Metrics []autoscalingv2.MetricSpec `json:"metrics,omitempty"`
+}
+
+// TempoGatewaySpec extends TempoComponentSpec with gateway parameters.
+type TempoGatewaySpec struct {
+	// TempoComponentSpec is embedded to extend this definition with further options.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	TempoComponentSpec `json:",inline"`
+
+	Enabled bool `json:"enabled"`
+	// Ingress defines gateway Ingress options.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Jaeger gateway Ingress Settings"
+	Ingress IngressSpec `json:"ingress,omitempty"`
+
+	// OPA defines the opa-openshift container, which is deployed in the openshift tenant mode.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="OPA Settings"
+	OPA GatewayOPASpec `json:"opa,omitempty"`
+}
+
+// GatewayOPASpec defines the opa-openshift container of the gateway.
+type GatewayOPASpec struct {
+	// Resources defines the compute resources of the opa-openshift container.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resources",xDescriptors="urn:alm:descriptor:com.tectonic.ui:resourceRequirements"
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// TempoMetricsGeneratorSpec extends TempoComponentSpec with metrics-generator specific parameters.
+type TempoMetricsGeneratorSpec struct {
+	// TempoComponentSpec is embedded to extend this definition with further options.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	TempoComponentSpec `json:",inline"`
+
+	// Enabled defines if the metrics-generator should be deployed.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enabled",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled bool `json:"enabled"`
+
+	// Processors defines the processors enabled for all tenants.
+	// Defaults to span-metrics and service-graphs if the metrics-generator is enabled.
+	// The processors can be overridden per tenant in .spec.limits.perTenant.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Processors"
+	Processors []MetricsGeneratorProcessor `json:"processors,omitempty"`
+
+	// RemoteWrite defines the Prometheus remote write endpoint of the generated metrics.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Remote Write"
+	RemoteWrite MetricsGeneratorRemoteWriteSpec `json:"remoteWrite,omitempty"`
+}
+
+// MetricsGeneratorProcessor defines a processor of the metrics-generator.
+//
+// +kubebuilder:validation:Enum=span-metrics;service-graphs;local-blocks
+type MetricsGeneratorProcessor string
+
+const (
+	// MetricsGeneratorProcessorSpanMetrics generates RED metrics from spans.
+	MetricsGeneratorProcessorSpanMetrics MetricsGeneratorProcessor = "span-metrics"
+	// MetricsGeneratorProcessorServiceGraphs generates metrics describing the relationships between services.
+	MetricsGeneratorProcessorServiceGraphs MetricsGeneratorProcessor = "service-graphs"
+	// MetricsGeneratorProcessorLocalBlocks keeps recent traces in local blocks, required for TraceQL metrics.
+	MetricsGeneratorProcessorLocalBlocks MetricsGeneratorProcessor = "local-blocks"
+)
+
+// MetricsGeneratorRemoteWriteSpec defines the Prometheus remote write endpoint.
+type MetricsGeneratorRemoteWriteSpec struct {
+	// URL is the URL of the Prometheus remote write endpoint, e.g. http://prometheus:9090/api/v1/write.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="URL"
+	URL string `json:"url,omitempty"`
+
+	// Secret is the name of a Secret in the namespace of the TempoStack containing the CA certificate
+	// and the credentials of the remote write endpoint.
+	// Supported keys are ca.crt (CA certificate), username and password (basic auth) and token (bearer token).
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Secret",xDescriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	Secret string `json:"secret,omitempty"`
+}
+
+// TempoQueryFrontendSpec extends TempoComponentSpec with frontend specific parameters.
+type TempoQueryFrontendSpec struct {
+	// TempoComponentSpec is embedded to extend this definition with further options.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	TempoComponentSpec `json:",inline"`
+
+	// JaegerQuerySpec defines Jaeger Query specific options.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Jaeger Query Settings"
+	JaegerQuery JaegerQuerySpec `json:"jaegerQuery"`
+}
+
+// JaegerQuerySpec defines Jaeger Query options.
+type JaegerQuerySpec struct {
+	// Enabled is used to define if Jaeger Query component should be created.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enable Jaeger Query UI"
+	Enabled bool `json:"enabled"`
+
+	// Ingress defines Jaeger Query Ingress options.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Jaeger Query UI Ingress Settings"
+	Ingress IngressSpec `json:"ingress,omitempty"`
+
+	// MonitorTab defines monitor tab configuration.
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Jaeger Query UI Monitor Tab Settings"
+	MonitorTab JaegerQueryMonitor `json:"monitorTab"`
+
+	// Resources defines the compute resources of the tempo-query container.
+	// Defaults to the resources of the query-frontend container.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resources",xDescriptors="urn:alm:descriptor:com.tectonic.ui:resourceRequirements"
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// JaegerQueryMonitor defines configuration for the service monitoring tab in the Jaeger console.
+// The monitoring tab uses Prometheus to query span RED metrics.
+// This feature requires running OpenTelemetry collector with spanmetricsconnector -
+// https://github.com/open-telemetry/opentelemetry-collector-contrib/tree/main/connector/spanmetricsconnector
+// which derives span RED metrics from spans and exports the metrics to Prometheus.
+type JaegerQueryMonitor struct {
+	// Enabled enables monitoring tab in Jaeger console.
+	// PrometheusEndpoint needs to be set to enable the feature.
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enabled"
+	Enabled bool `json:"enabled"`
+
+	// PrometheusEndpoint configures endpoint to the Prometheus that contains span RED metrics.
+	// For instance on OpenShift this is set to https://thanos-querier.openshift-monitoring.svc.cluster.local:9091
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Prometheus endpoint"
+	PrometheusEndpoint string `json:"prometheusEndpoint"`
+}
+
+// IngressSpec defines Jaeger Query Ingress options.
+type IngressSpec struct {
+	// Type defines the type of Ingress for the Jaeger Query UI.
+	// Currently ingress, route and none are supported.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Type"
+	Type IngressType `json:"type,omitempty"`
+
+	// Annotations defines the annotations of the Ingress object.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Annotations"
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Host defines the hostname of the Ingress object.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Host"
+	Host string `json:"host,omitempty"`
+
+	// IngressClassName is the name of an IngressClass cluster resource. Ingress
+	// controller implementations use this field to know whether they should be
+	// serving this Ingress resource.
+	// +optional
+	IngressClassName *string `json:"ingressClassName,omitempty"`
+
+	// Route defines OpenShift Route specific options.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Route Configuration"
+	Route RouteSpec `json:"route,omitempty"`
+}
+
+// RouteSpec defines OpenShift Route specific options.
+type RouteSpec struct {
+	// Termination specifies the termination type. By default "edge" is used.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="TLS Termination Policy"
+	Termination TLSRouteTerminationType `json:"termination,omitempty"`
+}
+
+// LimitSpec defines Global and PerTenant rate limits.
+type LimitSpec struct {
+	// PerTenant is used to define rate limits per tenant.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Tenant Limits"
+	PerTenant map[string]RateLimitSpec `json:"perTenant,omitempty"`
+
+	// Global is used to define global rate limits.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Global Limit"
+	Global RateLimitSpec `json:"global"`
+}
+
+// RateLimitSpec defines rate limits for Ingestion and Query components.
+type RateLimitSpec struct {
+	// Ingestion is used to define ingestion rate limits.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Ingestion Limit"
+	Ingestion IngestionLimitSpec `json:"ingestion"`
+
+	// Query is used to define query rate limits.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Query Limit"
+	Query QueryLimit `json:"query"`
+
+	// MetricsGenerator is used to configure the metrics-generator processors.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Metrics Generator"
+	MetricsGenerator MetricsGeneratorLimitSpec `json:"metricsGenerator,omitempty"`
+}
+
+// MetricsGeneratorLimitSpec defines the metrics-generator processors of a tenant.
+type MetricsGeneratorLimitSpec struct {
+	// Processors overrides the processors defined in .spec.template.metricsGenerator.processors.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Processors"
+	Processors []MetricsGeneratorProcessor `json:"processors,omitempty"`
+
+	// Disabled disables all metrics-generator processors.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Disabled",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+// IngestionLimitSpec defines the limits applied at the ingestion path.
+type IngestionLimitSpec struct {
+	// IngestionBurstSizeBytes defines the burst size (bytes) used in ingestion.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:com.tectonic.ui:number",displayName="Ingestion Burst Size in Bytes"
+	IngestionBurstSizeBytes *int `json:"ingestionBurstSizeBytes,omitempty"`
+
+	// IngestionRateLimitBytes defines the Per-user ingestion rate limit (bytes) used in ingestion.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:com.tectonic.ui:number",displayName="Ingestion Rate Limit in Bytes"
+	IngestionRateLimitBytes *int `json:"ingestionRateLimitBytes,omitempty"`
+
+	// MaxBytesPerTrace defines the maximum number of bytes of an acceptable trace.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:com.tectonic.ui:number",displayName="Max Bytes per Trace"
+	MaxBytesPerTrace *int `json:"maxBytesPerTrace,omitempty"`
+
+	// MaxTracesPerUser defines the maximum number of traces a user can send.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:com.tectonic.ui:number",displayName="Max Traces per User"
+	MaxTracesPerUser *int `json:"maxTracesPerUser,omitempty"`
+}
+
+// QueryLimit defines query limits.
+type QueryLimit struct {
+	// MaxBytesPerTagValues defines the maximum size in bytes of a tag-values query.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:com.tectonic.ui:number",displayName="Max Tags per User"
+	MaxBytesPerTagValues *int `json:"maxBytesPerTagValues,omitempty"`
+
+	// MaxSearchDuration defines the maximum allowed time range for a search.
+	// If this value is not set, then spec.search.maxDuration is used.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max Search Duration per User"
+	MaxSearchDuration metav1.Duration `json:"maxSearchDuration"`
+}
+
+// RetentionSpec defines global and per tenant retention configurations.
+type RetentionSpec struct {
+	// PerTenant is used to configure retention per tenant.
+	// The key is the tenant ID, the retention is rendered in the per-tenant overrides of Tempo.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="PerTenant Retention"
+	PerTenant map[string]RetentionConfig `json:"perTenant,omitempty"`
+	// Global is used to configure global retention.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Global Retention"
+	Global RetentionConfig `json:"global"`
+}
+
+// RetentionConfig defines how long data should be provided.
+type RetentionConfig struct {
+	// Traces defines retention period. Supported parameter suffixes are "s", "m" and "h".
+	// example: 336h
+	// default: value is 48h.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:com.tectonic.ui:text",displayName="Trace Retention Period"
+	Traces metav1.Duration `json:"traces"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+//+kubebuilder:printcolumn:name="Tempo Version",type="string",JSONPath=".status.tempoVersion",description="Tempo Version"
+//+kubebuilder:printcolumn:name="Management",type="string",JSONPath=".spec.managementState",description="Management State"
+
+// TempoStack is the spec for Tempo deployments.
+//
+// +operator-sdk:csv:customresourcedefinitions:displayName="TempoStack",resources={{ConfigMap,v1},{ServiceAccount,v1},{Service,v1},{Secret,v1},{StatefulSet,v1},{Deployment,v1},{Ingress,v1},{Route,v1}}
+// +kubebuilder:resource:shortName=tempo;tempos
+type TempoStack struct {
+	Status            TempoStackStatus `json:"status,omitempty"`
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              TempoStackSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TempoStackList contains a list of TempoStack.
+type TempoStackList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TempoStack `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TempoStack{}, &TempoStackList{})
+}
@@ -0,0 +1,14 @@
+package v1beta1
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// SetupWebhookWithManager initializes the conversion webhook of the TempoStack.
+// The defaulting and validating webhooks are served by the v1alpha1 API, the API server
+// converts the TempoStack to v1alpha1 before calling the admission webhooks.
+func (r *TempoStack) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
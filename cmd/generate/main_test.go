@@ -37,7 +37,7 @@ func TestBuild(t *testing.T) {
 
 	objects, err := build(params)
 	require.NoError(t, err)
-	require.Equal(t, 14, len(objects))
+	require.Equal(t, 19, len(objects))
 }
 
 func TestYAMLEncoding(t *testing.T) {
@@ -18,6 +18,7 @@ import (
 
 	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
 	tempov1alpha1 "github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	tempov1beta1 "github.com/grafana/tempo-operator/apis/tempo/v1beta1"
 )
 
 var (
@@ -37,6 +38,7 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(tempov1alpha1.AddToScheme(scheme))
+	utilruntime.Must(tempov1beta1.AddToScheme(scheme))
 	utilruntime.Must(configv1alpha1.AddToScheme(scheme))
 	utilruntime.Must(routev1.Install(scheme))
 	utilruntime.Must(openshiftoperatorv1.Install(scheme))
@@ -50,10 +52,12 @@ func readConfig(cmd *cobra.Command, configFile string) error {
 	// default controller configuration
 	ctrlConfig := configv1alpha1.ProjectConfig{
 		DefaultImages: configv1alpha1.ImagesSpec{
-			Tempo:           os.Getenv(configv1alpha1.EnvRelatedImageTempo),
-			TempoQuery:      os.Getenv(configv1alpha1.EnvRelatedImageTempoQuery),
-			TempoGateway:    os.Getenv(configv1alpha1.EnvRelatedImageTempoGateway),
-			TempoGatewayOpa: os.Getenv(configv1alpha1.EnvRelatedImageTempoGatewayOpa),
+			Tempo:             os.Getenv(configv1alpha1.EnvRelatedImageTempo),
+			TempoQuery:        os.Getenv(configv1alpha1.EnvRelatedImageTempoQuery),
+			TempoGateway:      os.Getenv(configv1alpha1.EnvRelatedImageTempoGateway),
+			TempoGatewayOpa:   os.Getenv(configv1alpha1.EnvRelatedImageTempoGatewayOpa),
+			Memcached:         os.Getenv(configv1alpha1.EnvRelatedImageMemcached),
+			MemcachedExporter: os.Getenv(configv1alpha1.EnvRelatedImageMemcachedExporter),
 		},
 		Gates: configv1alpha1.FeatureGates{
 			TLSProfile: string(configv1.TLSProfileModernType),
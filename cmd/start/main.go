@@ -18,6 +18,7 @@ import (
 
 	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
 	tempov1alpha1 "github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	tempov1beta1 "github.com/grafana/tempo-operator/apis/tempo/v1beta1"
 	"github.com/grafana/tempo-operator/cmd"
 	controllers "github.com/grafana/tempo-operator/controllers/tempo"
 	"github.com/grafana/tempo-operator/internal/upgrade"
@@ -67,12 +68,30 @@ func start(c *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
+	if err = (&controllers.TempoMonolithicReconciler{
+		Client:     mgr.GetClient(),
+		Scheme:     mgr.GetScheme(),
+		Recorder:   mgr.GetEventRecorderFor("tempomonolithic-controller"),
+		CtrlConfig: ctrlConfig,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "TempoMonolithic")
+		os.Exit(1)
+	}
+
 	enableWebhooks := os.Getenv("ENABLE_WEBHOOKS") != "false"
 	if enableWebhooks {
 		if err = (&tempov1alpha1.TempoStack{}).SetupWebhookWithManager(mgr, ctrlConfig); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "TempoStack")
 			os.Exit(1)
 		}
+		if err = (&tempov1beta1.TempoStack{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create conversion webhook", "webhook", "TempoStack")
+			os.Exit(1)
+		}
+		if err = (&tempov1alpha1.TempoMonolithic{}).SetupWebhookWithManager(mgr, ctrlConfig); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "TempoMonolithic")
+			os.Exit(1)
+		}
 	}
 	//+kubebuilder:scaffold:builder
 
@@ -99,6 +118,8 @@ func start(c *cobra.Command, args []string) {
 		"default-tempo-query-image", rootCmdConfig.CtrlConfig.DefaultImages.TempoQuery,
 		"default-tempo-gateway-image", rootCmdConfig.CtrlConfig.DefaultImages.TempoGateway,
 		"default-tempo-gateway-opa-image", rootCmdConfig.CtrlConfig.DefaultImages.TempoGatewayOpa,
+		"default-memcached-image", rootCmdConfig.CtrlConfig.DefaultImages.Memcached,
+		"default-memcached-exporter-image", rootCmdConfig.CtrlConfig.DefaultImages.MemcachedExporter,
 		"go-version", version.GoVersion,
 		"go-arch", runtime.GOARCH,
 		"go-os", runtime.GOOS,
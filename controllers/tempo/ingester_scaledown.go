@@ -0,0 +1,254 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/handlers/ingester"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/status"
+)
+
+const (
+	// ingesterRingUnavailableAnnotation records on the ingester StatefulSet since when the ingester ring
+	// cannot be fetched during a scale-down.
+	ingesterRingUnavailableAnnotation = "tempo.grafana.com/ingester-ring-unavailable-since"
+	// ingesterRingTimeout is the time a scale-down waits for the ingester ring, before it is reported as failed.
+	ingesterRingTimeout = 10 * time.Minute
+)
+
+// scaleDownIngesters shuts down the ingesters which are removed by a scale-down of the ingester StatefulSets.
+// It returns a status.PendingError if some ingesters did not leave the ring yet, and a status.FailedError
+// if the ingester ring is unavailable for longer than ingesterRingTimeout.
+//
+// The ingesters are flushed and removed from the ring before the StatefulSet is shrunk, otherwise the
+// traces in the WAL of the removed ingesters would be lost. Until an ingester left the ring, the replicas
+// of the desired StatefulSet are kept high enough to keep its pod running.
+func (r *TempoStackReconciler) scaleDownIngesters(ctx context.Context, log logr.Logger, tempo v1alpha1.TempoStack, managedObjects []client.Object) error {
+	s := &ingesterScaleDown{reconciler: r, log: log, tempo: tempo}
+
+	pending := 0
+	var failedErr *status.FailedError
+	for _, obj := range managedObjects {
+		desired, ok := obj.(*appsv1.StatefulSet)
+		if !ok || desired.Labels["app.kubernetes.io/component"] != manifestutils.IngesterComponentName {
+			continue
+		}
+
+		// The remaining StatefulSets are processed after a failure, to keep their removed ingesters running as well.
+		n, err := s.scaleDown(ctx, desired)
+		if err != nil && !errors.As(err, &failedErr) {
+			return err
+		}
+		pending += n
+	}
+
+	if failedErr != nil {
+		return failedErr
+	}
+	if pending > 0 {
+		message := fmt.Sprintf("Waiting for %d ingesters to flush their data and leave the ring", pending)
+		if s.ringErr != nil {
+			message = fmt.Sprintf("%s: %s", message, s.ringErr)
+		}
+		return &status.PendingError{
+			Reason:  v1alpha1.ReasonIngesterScaleDownInProgress,
+			Message: message,
+		}
+	}
+	return nil
+}
+
+type ingesterScaleDown struct {
+	reconciler *TempoStackReconciler
+	log        logr.Logger
+	tempo      v1alpha1.TempoStack
+
+	client  *ingester.Client
+	ring    map[string]ingester.RingInstance
+	ringErr error
+}
+
+// scaleDown shuts down the ingesters of a StatefulSet whose ordinal is greater than or equal to the desired replicas.
+// The replicas of the desired StatefulSet are set to the highest ordinal which did not leave the ring yet, plus one.
+func (s *ingesterScaleDown) scaleDown(ctx context.Context, desired *appsv1.StatefulSet) (int, error) {
+	// The replicas are managed by the HorizontalPodAutoscaler if autoscaling is enabled.
+	// Autoscaled ingesters flush their data and leave the ring when they are stopped (see config.ingesterOptions).
+	if desired.Spec.Replicas == nil {
+		return 0, nil
+	}
+
+	existing := &appsv1.StatefulSet{}
+	err := s.reconciler.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	if apierrors.IsNotFound(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("could not fetch ingester statefulset: %w", err)
+	}
+
+	target := *desired.Spec.Replicas
+	current := ptr.Deref(existing.Spec.Replicas, 1)
+
+	replicas := target
+	pending := 0
+	for ordinal := current - 1; ordinal >= target; ordinal-- {
+		left, err := s.shutdown(ctx, fmt.Sprintf("%s-%d", existing.Name, ordinal))
+		if err != nil {
+			return 0, err
+		}
+		if !left {
+			pending++
+			if replicas == target {
+				replicas = ordinal + 1
+			}
+		}
+	}
+	desired.Spec.Replicas = ptr.To(replicas)
+
+	if pending == 0 && s.tempo.Spec.VolumeRetentionPolicy == v1alpha1.VolumeRetentionPolicyDelete {
+		err = s.deleteVolumeClaims(ctx, existing, target)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return pending, s.checkRingTimeout(ctx, existing, pending)
+}
+
+// checkRingTimeout records since when the ingester ring is unavailable during a scale-down of a StatefulSet,
+// and returns a status.FailedError if the ring is unavailable for longer than ingesterRingTimeout.
+// The removed ingesters are kept running in any case, because they cannot be shut down safely without the ring.
+func (s *ingesterScaleDown) checkRingTimeout(ctx context.Context, sts *appsv1.StatefulSet, pending int) error {
+	since, found := sts.Annotations[ingesterRingUnavailableAnnotation]
+	if pending == 0 || s.ringErr == nil {
+		if !found {
+			return nil
+		}
+		patch := client.MergeFrom(sts.DeepCopy())
+		delete(sts.Annotations, ingesterRingUnavailableAnnotation)
+		if err := s.reconciler.Patch(ctx, sts, patch); err != nil {
+			return fmt.Errorf("could not update ingester statefulset: %w", err)
+		}
+		return nil
+	}
+
+	sinceTime, err := time.Parse(time.RFC3339, since)
+	if !found || err != nil {
+		patch := client.MergeFrom(sts.DeepCopy())
+		if sts.Annotations == nil {
+			sts.Annotations = map[string]string{}
+		}
+		sts.Annotations[ingesterRingUnavailableAnnotation] = time.Now().UTC().Format(time.RFC3339)
+		if err := s.reconciler.Patch(ctx, sts, patch); err != nil {
+			return fmt.Errorf("could not update ingester statefulset: %w", err)
+		}
+		return nil
+	}
+
+	if time.Since(sinceTime) > ingesterRingTimeout {
+		return &status.FailedError{
+			Reason: v1alpha1.ReasonIngesterScaleDownFailed,
+			Message: fmt.Sprintf("The ingesters removed from %s cannot be shut down safely, because the ingester ring is unavailable since %s: %s",
+				sts.Name, since, s.ringErr),
+		}
+	}
+	return nil
+}
+
+// shutdown flushes an ingester and removes it from the ring, and returns true if the ingester left the ring.
+func (s *ingesterScaleDown) shutdown(ctx context.Context, podName string) (bool, error) {
+	pod := &corev1.Pod{}
+	err := s.reconciler.Get(ctx, types.NamespacedName{Namespace: s.tempo.Namespace, Name: podName}, pod)
+	if apierrors.IsNotFound(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("could not fetch ingester pod: %w", err)
+	}
+	if pod.Status.Phase != corev1.PodRunning || !pod.DeletionTimestamp.IsZero() {
+		return true, nil
+	}
+
+	if s.client == nil {
+		s.client, err = ingester.NewClient(ctx, s.reconciler.Client, s.tempo, s.reconciler.CtrlConfig.Gates)
+		if err != nil {
+			return false, err
+		}
+	}
+	if s.ring == nil && s.ringErr == nil {
+		s.ring, s.ringErr = s.client.RingInstances(ctx)
+		if s.ringErr != nil {
+			s.log.Error(s.ringErr, "could not fetch the ingester ring")
+		}
+	}
+	if s.ringErr != nil {
+		// The ingester cannot be removed safely if its state in the ring is unknown.
+		return false, nil
+	}
+
+	instance, ok := s.ring[podName]
+	if !ok {
+		return true, nil
+	}
+	if instance.State != ingester.RingStateActive {
+		// The ingester is leaving the ring already.
+		return false, nil
+	}
+
+	s.log.Info("shutting down ingester before scale-down", "pod", podName)
+	err = s.client.FlushAndShutdown(ctx, s.tempo, *pod)
+	if err != nil {
+		s.log.Error(err, "could not shut down ingester", "pod", podName)
+	}
+	return false, nil
+}
+
+// deleteVolumeClaims deletes the persistent volume claims of the removed ingesters of a StatefulSet.
+func (s *ingesterScaleDown) deleteVolumeClaims(ctx context.Context, sts *appsv1.StatefulSet, replicas int32) error {
+	for _, template := range sts.Spec.VolumeClaimTemplates {
+		claims, err := s.reconciler.getVolumeClaims(ctx, sts, template.Name)
+		if err != nil {
+			return err
+		}
+
+		prefix := fmt.Sprintf("%s-%s-", template.Name, sts.Name)
+		for i := range claims {
+			claim := &claims[i]
+			ordinal, err := strconv.Atoi(strings.TrimPrefix(claim.Name, prefix))
+			if err != nil || int32(ordinal) < replicas {
+				continue
+			}
+
+			// Keep the claim if the pod is still running, e.g. while the StatefulSet controller removes it.
+			pod := &corev1.Pod{}
+			err = s.reconciler.Get(ctx, types.NamespacedName{Namespace: sts.Namespace, Name: fmt.Sprintf("%s-%d", sts.Name, ordinal)}, pod)
+			if err == nil {
+				continue
+			}
+			if !apierrors.IsNotFound(err) {
+				return fmt.Errorf("could not fetch ingester pod: %w", err)
+			}
+
+			err = s.reconciler.Delete(ctx, claim)
+			if err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("could not delete persistent volume claim %s: %w", claim.Name, err)
+			}
+			s.log.Info("deleted persistent volume claim of removed ingester", "name", claim.Name)
+		}
+	}
+	return nil
+}
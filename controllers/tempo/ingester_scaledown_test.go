@@ -0,0 +1,188 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/version"
+)
+
+func setIngesterReplicas(t *testing.T, nsn types.NamespacedName, replicas int32, policy v1alpha1.VolumeRetentionPolicy) {
+	tempo := v1alpha1.TempoStack{}
+	err := k8sClient.Get(context.Background(), nsn, &tempo)
+	require.NoError(t, err)
+	tempo.Spec.Template.Ingester.Replicas = ptr.To(replicas)
+	tempo.Spec.VolumeRetentionPolicy = policy
+	err = k8sClient.Update(context.Background(), &tempo)
+	require.NoError(t, err)
+}
+
+func TestIngesterScaleDownDeletesVolumeClaims(t *testing.T) {
+	nsn := types.NamespacedName{Name: "ingester-scale-down-test", Namespace: "default"}
+	storageSecret := createSecret(t, nsn)
+	createTempoCR(t, nsn, storageSecret)
+	setIngesterReplicas(t, nsn, 3, v1alpha1.VolumeRetentionPolicyDelete)
+
+	reconciler := TempoStackReconciler{
+		Client:   k8sClient,
+		Scheme:   testScheme,
+		Recorder: record.NewFakeRecorder(1),
+		CtrlConfig: configv1alpha1.ProjectConfig{
+			Gates: configv1alpha1.FeatureGates{
+				TLSProfile: string(configv1alpha1.TLSProfileIntermediateType),
+			},
+		},
+		Version: version.Get(),
+	}
+	req := ctrl.Request{
+		NamespacedName: nsn,
+	}
+	_, err := reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		claim := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("data-tempo-%s-ingester-%d", nsn.Name, i),
+				Namespace: nsn.Namespace,
+				Labels:    manifestutils.ComponentLabels(manifestutils.IngesterComponentName, nsn.Name),
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: resource.MustParse("10Gi"),
+					},
+				},
+			},
+		}
+		err = k8sClient.Create(context.Background(), claim)
+		require.NoError(t, err)
+	}
+
+	// Scale down, the ingester pods do not exist and are therefore removed immediately
+	setIngesterReplicas(t, nsn, 1, v1alpha1.VolumeRetentionPolicyDelete)
+	result, err := reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+	assert.Zero(t, result.RequeueAfter)
+
+	sts := &appsv1.StatefulSet{}
+	err = k8sClient.Get(context.Background(), types.NamespacedName{Namespace: nsn.Namespace, Name: "tempo-" + nsn.Name + "-ingester"}, sts)
+	require.NoError(t, err)
+	assert.Equal(t, ptr.To(int32(1)), sts.Spec.Replicas)
+
+	// Verify that the claims of the removed ingesters got deleted
+	claim := &corev1.PersistentVolumeClaim{}
+	err = k8sClient.Get(context.Background(), types.NamespacedName{Namespace: nsn.Namespace, Name: "data-tempo-" + nsn.Name + "-ingester-0"}, claim)
+	require.NoError(t, err)
+	assert.Nil(t, claim.DeletionTimestamp)
+	for i := 1; i < 3; i++ {
+		claim := &corev1.PersistentVolumeClaim{}
+		err = k8sClient.Get(context.Background(), types.NamespacedName{Namespace: nsn.Namespace, Name: fmt.Sprintf("data-tempo-%s-ingester-%d", nsn.Name, i)}, claim)
+		if !apierrors.IsNotFound(err) {
+			require.NoError(t, err)
+			assert.NotNil(t, claim.DeletionTimestamp)
+		}
+	}
+}
+
+func TestIngesterScaleDownRingUnavailable(t *testing.T) {
+	nsn := types.NamespacedName{Name: "ingester-scale-down-ring-test", Namespace: "default"}
+	storageSecret := createSecret(t, nsn)
+	createTempoCR(t, nsn, storageSecret)
+	setIngesterReplicas(t, nsn, 3, v1alpha1.VolumeRetentionPolicyRetain)
+
+	reconciler := TempoStackReconciler{
+		Client:   k8sClient,
+		Scheme:   testScheme,
+		Recorder: record.NewFakeRecorder(1),
+		CtrlConfig: configv1alpha1.ProjectConfig{
+			Gates: configv1alpha1.FeatureGates{
+				TLSProfile: string(configv1alpha1.TLSProfileIntermediateType),
+			},
+		},
+		Version: version.Get(),
+	}
+	req := ctrl.Request{
+		NamespacedName: nsn,
+	}
+	_, err := reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	// A running ingester, which is removed by the scale-down
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("tempo-%s-ingester-2", nsn.Name),
+			Namespace: nsn.Namespace,
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "tempo", Image: "docker.io/grafana/tempo:1.5.0"}},
+		},
+	}
+	err = k8sClient.Create(context.Background(), pod)
+	require.NoError(t, err)
+	pod.Status = corev1.PodStatus{Phase: corev1.PodRunning, PodIP: "10.0.0.2"}
+	err = k8sClient.Status().Update(context.Background(), pod)
+	require.NoError(t, err)
+
+	// Scale down, the ingester ring of the distributor is not reachable
+	setIngesterReplicas(t, nsn, 1, v1alpha1.VolumeRetentionPolicyRetain)
+	result, err := reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+	assert.NotZero(t, result.RequeueAfter)
+
+	stsNsn := types.NamespacedName{Namespace: nsn.Namespace, Name: "tempo-" + nsn.Name + "-ingester"}
+	sts := &appsv1.StatefulSet{}
+	err = k8sClient.Get(context.Background(), stsNsn, sts)
+	require.NoError(t, err)
+	assert.Equal(t, ptr.To(int32(3)), sts.Spec.Replicas)
+	assert.Contains(t, sts.Annotations, ingesterRingUnavailableAnnotation)
+
+	tempo := v1alpha1.TempoStack{}
+	err = k8sClient.Get(context.Background(), nsn, &tempo)
+	require.NoError(t, err)
+	condition := meta.FindStatusCondition(tempo.Status.Conditions, string(v1alpha1.ConditionPending))
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, string(v1alpha1.ReasonIngesterScaleDownInProgress), condition.Reason)
+	assert.Contains(t, condition.Message, "Waiting for 1 ingesters to flush their data and leave the ring: could not fetch the ingester ring")
+
+	// The ingester ring is unavailable for longer than the timeout
+	sts.Annotations[ingesterRingUnavailableAnnotation] = time.Now().Add(-ingesterRingTimeout - time.Minute).UTC().Format(time.RFC3339)
+	err = k8sClient.Update(context.Background(), sts)
+	require.NoError(t, err)
+
+	_, err = reconciler.Reconcile(context.Background(), req)
+	require.Error(t, err)
+
+	err = k8sClient.Get(context.Background(), stsNsn, sts)
+	require.NoError(t, err)
+	assert.Equal(t, ptr.To(int32(3)), sts.Spec.Replicas)
+
+	err = k8sClient.Get(context.Background(), nsn, &tempo)
+	require.NoError(t, err)
+	condition = meta.FindStatusCondition(tempo.Status.Conditions, string(v1alpha1.ConditionFailed))
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, string(v1alpha1.ReasonIngesterScaleDownFailed), condition.Reason)
+	assert.Contains(t, condition.Message, "cannot be shut down safely, because the ingester ring is unavailable since")
+	assert.Contains(t, condition.Message, "could not fetch the ingester ring")
+}
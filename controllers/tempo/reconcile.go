@@ -0,0 +1,225 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	grafanav1 "github.com/grafana-operator/grafana-operator/v5/api/v1beta1"
+	routev1 "github.com/openshift/api/route/v1"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests"
+)
+
+// reconcileManagedObjects creates or updates all managed objects of an owner (e.g. a TempoStack instance),
+// and prunes the objects in pruneObjects which are not managed anymore.
+func reconcileManagedObjects(
+	ctx context.Context,
+	log logr.Logger,
+	k8sclient client.Client,
+	owner client.Object,
+	scheme *runtime.Scheme,
+	managedObjects []client.Object,
+	pruneObjects map[types.UID]client.Object,
+) error {
+	ownerKind := "object"
+	if gvk, err := apiutil.GVKForObject(owner, scheme); err == nil {
+		ownerKind = gvk.Kind
+	}
+	ownerName := client.ObjectKeyFromObject(owner)
+
+	errs := []error{}
+	for _, obj := range managedObjects {
+		l := log.WithValues(
+			"object_name", obj.GetName(),
+			"object_kind", obj.GetObjectKind(),
+		)
+
+		if isNamespaceScoped(obj) {
+			obj.SetNamespace(owner.GetNamespace())
+			if err := ctrl.SetControllerReference(owner, obj, scheme); err != nil {
+				l.Error(err, "failed to set controller owner reference to resource")
+				errs = append(errs, err)
+				continue
+			}
+		}
+
+		desired := obj.DeepCopyObject().(client.Object)
+		mutateFn := manifests.MutateFuncFor(obj, desired)
+
+		op, err := ctrl.CreateOrUpdate(ctx, k8sclient, obj, mutateFn)
+		if err != nil {
+			l.Error(err, "failed to configure resource")
+			errs = append(errs, err)
+			continue
+		}
+
+		l.V(1).Info(fmt.Sprintf("resource has been %s", op))
+
+		// This object is still managed by the operator, remove it from the list of objects to prune
+		delete(pruneObjects, obj.GetUID())
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to create objects for %s %s: %w", ownerKind, ownerName, errors.Join(errs...))
+	}
+
+	// Prune owned objects in the cluster which are not managed anymore.
+	pruneErrs := []error{}
+	for _, obj := range pruneObjects {
+		l := log.WithValues(
+			"object_name", obj.GetName(),
+			"object_kind", obj.GetObjectKind(),
+		)
+		l.Info("pruning unmanaged resource")
+
+		err := k8sclient.Delete(ctx, obj)
+		if err != nil {
+			l.Error(err, "failed to delete resource")
+			pruneErrs = append(pruneErrs, err)
+		}
+	}
+	if len(pruneErrs) > 0 {
+		return fmt.Errorf("failed to prune objects of %s %s: %w", ownerKind, ownerName, errors.Join(pruneErrs...))
+	}
+
+	return nil
+}
+
+// findObjectsOwnedByTempoOperator lists all objects in the namespace of the owner matching the given labels,
+// which can be conditionally created by the operator.
+// Only objects controlled by the owner are returned, objects created by users with the same labels are ignored.
+func findObjectsOwnedByTempoOperator(ctx context.Context, k8sclient client.Client, gates configv1alpha1.FeatureGates, owner client.Object, selector map[string]string) (map[types.UID]client.Object, error) {
+	ownedObjects := map[types.UID]client.Object{}
+	addOwnedObject := func(obj client.Object) {
+		if metav1.IsControlledBy(obj, owner) {
+			ownedObjects[obj.GetUID()] = obj
+		}
+	}
+	listOps := &client.ListOptions{
+		Namespace:     owner.GetNamespace(),
+		LabelSelector: labels.SelectorFromSet(selector),
+	}
+
+	// Add all resources where the operator can conditionally create an object.
+	// For example, Ingress and Route can be enabled or disabled in the CR.
+
+	// Optional components, for example the metrics-generator and the managed memcached.
+	deploymentList := &appsv1.DeploymentList{}
+	err := k8sclient.List(ctx, deploymentList, listOps)
+	if err != nil {
+		return nil, fmt.Errorf("error listing deployments: %w", err)
+	}
+	for i := range deploymentList.Items {
+		addOwnedObject(&deploymentList.Items[i])
+	}
+
+	statefulSetList := &appsv1.StatefulSetList{}
+	err = k8sclient.List(ctx, statefulSetList, listOps)
+	if err != nil {
+		return nil, fmt.Errorf("error listing statefulsets: %w", err)
+	}
+	for i := range statefulSetList.Items {
+		// StatefulSets with persistent volumes (e.g. the ingesters) are never pruned, because deleting them
+		// would stop the pods without flushing the data on the volumes (e.g. the write-ahead log).
+		if len(statefulSetList.Items[i].Spec.VolumeClaimTemplates) > 0 {
+			continue
+		}
+		addOwnedObject(&statefulSetList.Items[i])
+	}
+
+	serviceList := &corev1.ServiceList{}
+	err = k8sclient.List(ctx, serviceList, listOps)
+	if err != nil {
+		return nil, fmt.Errorf("error listing services: %w", err)
+	}
+	for i := range serviceList.Items {
+		addOwnedObject(&serviceList.Items[i])
+	}
+
+	hpaList := &autoscalingv2.HorizontalPodAutoscalerList{}
+	err = k8sclient.List(ctx, hpaList, listOps)
+	if err != nil {
+		return nil, fmt.Errorf("error listing horizontal pod autoscalers: %w", err)
+	}
+	for i := range hpaList.Items {
+		addOwnedObject(&hpaList.Items[i])
+	}
+
+	pdbList := &policyv1.PodDisruptionBudgetList{}
+	err = k8sclient.List(ctx, pdbList, listOps)
+	if err != nil {
+		return nil, fmt.Errorf("error listing pod disruption budgets: %w", err)
+	}
+	for i := range pdbList.Items {
+		addOwnedObject(&pdbList.Items[i])
+	}
+
+	ingressList := &networkingv1.IngressList{}
+	err = k8sclient.List(ctx, ingressList, listOps)
+	if err != nil {
+		return nil, fmt.Errorf("error listing ingress: %w", err)
+	}
+	for i := range ingressList.Items {
+		addOwnedObject(&ingressList.Items[i])
+	}
+
+	if gates.PrometheusOperator {
+		servicemonitorList := &monitoringv1.ServiceMonitorList{}
+		err := k8sclient.List(ctx, servicemonitorList, listOps)
+		if err != nil {
+			return nil, fmt.Errorf("error listing service monitors: %w", err)
+		}
+		for i := range servicemonitorList.Items {
+			addOwnedObject(servicemonitorList.Items[i])
+		}
+
+		prometheusRulesList := &monitoringv1.PrometheusRuleList{}
+		err = k8sclient.List(ctx, prometheusRulesList, listOps)
+		if err != nil {
+			return nil, fmt.Errorf("error listing prometheus rules: %w", err)
+		}
+		for i := range prometheusRulesList.Items {
+			addOwnedObject(prometheusRulesList.Items[i])
+		}
+	}
+
+	if gates.OpenShift.OpenShiftRoute {
+		routesList := &routev1.RouteList{}
+		err := k8sclient.List(ctx, routesList, listOps)
+		if err != nil {
+			return nil, fmt.Errorf("error listing routes: %w", err)
+		}
+		for i := range routesList.Items {
+			addOwnedObject(&routesList.Items[i])
+		}
+	}
+
+	if gates.GrafanaOperator {
+		datasourceList := &grafanav1.GrafanaDatasourceList{}
+		err := k8sclient.List(ctx, datasourceList, listOps)
+		if err != nil {
+			return nil, fmt.Errorf("error listing datasources: %w", err)
+		}
+		for i := range datasourceList.Items {
+			addOwnedObject(&datasourceList.Items[i])
+		}
+	}
+
+	return ownedObjects, nil
+}
@@ -0,0 +1,153 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func TestPruneOnlyOwnedObjects(t *testing.T) {
+	nsn := types.NamespacedName{Name: "prune-owned-test", Namespace: "default"}
+	tempo := &v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      nsn.Name,
+			Namespace: nsn.Namespace,
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Storage: v1alpha1.ObjectStorageSpec{
+				Secret: v1alpha1.ObjectStorageSecretSpec{
+					Name: "storage-secret",
+					Type: "s3",
+				},
+			},
+		},
+	}
+	err := k8sClient.Create(context.Background(), tempo)
+	require.NoError(t, err)
+
+	service := func(name string) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: nsn.Namespace,
+				Labels:    manifestutils.CommonLabels(nsn.Name),
+			},
+			Spec: corev1.ServiceSpec{
+				Ports: []corev1.ServicePort{{Name: "http", Port: 8080}},
+			},
+		}
+	}
+
+	// an object created by the operator, which is not managed anymore
+	owned := service("tempo-prune-owned-test-owned")
+	require.NoError(t, ctrl.SetControllerReference(tempo, owned, testScheme))
+	err = k8sClient.Create(context.Background(), owned)
+	require.NoError(t, err)
+
+	// an object created by a user with the same labels
+	unowned := service("tempo-prune-owned-test-unowned")
+	err = k8sClient.Create(context.Background(), unowned)
+	require.NoError(t, err)
+
+	pruneObjects, err := findObjectsOwnedByTempoOperator(context.Background(), k8sClient, configv1alpha1.FeatureGates{}, tempo, manifestutils.CommonLabels(nsn.Name))
+	require.NoError(t, err)
+	names := []string{}
+	for _, obj := range pruneObjects {
+		names = append(names, obj.GetName())
+	}
+	assert.Equal(t, []string{owned.Name}, names)
+
+	err = reconcileManagedObjects(context.Background(), logr.Discard(), k8sClient, tempo, testScheme, []client.Object{}, pruneObjects)
+	require.NoError(t, err)
+
+	err = k8sClient.Get(context.Background(), client.ObjectKeyFromObject(owned), &corev1.Service{})
+	require.Error(t, err)
+	require.True(t, apierrors.IsNotFound(err))
+
+	err = k8sClient.Get(context.Background(), client.ObjectKeyFromObject(unowned), &corev1.Service{})
+	require.NoError(t, err)
+}
+
+func TestPruneSkipsStatefulSetsWithVolumes(t *testing.T) {
+	nsn := types.NamespacedName{Name: "prune-volumes-test", Namespace: "default"}
+	tempo := &v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      nsn.Name,
+			Namespace: nsn.Namespace,
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Storage: v1alpha1.ObjectStorageSpec{
+				Secret: v1alpha1.ObjectStorageSecretSpec{
+					Name: "storage-secret",
+					Type: "s3",
+				},
+			},
+		},
+	}
+	err := k8sClient.Create(context.Background(), tempo)
+	require.NoError(t, err)
+
+	statefulSet := func(name string, volumeClaimTemplates []corev1.PersistentVolumeClaim) *appsv1.StatefulSet {
+		labels := manifestutils.CommonLabels(nsn.Name)
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: nsn.Namespace,
+				Labels:    labels,
+			},
+			Spec: appsv1.StatefulSetSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: labels},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: labels},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "test", Image: "test"}},
+					},
+				},
+				VolumeClaimTemplates: volumeClaimTemplates,
+			},
+		}
+	}
+
+	// a StatefulSet without volumes, e.g. the managed memcached
+	withoutVolumes := statefulSet("tempo-prune-volumes-test-memcached", nil)
+	require.NoError(t, ctrl.SetControllerReference(tempo, withoutVolumes, testScheme))
+	err = k8sClient.Create(context.Background(), withoutVolumes)
+	require.NoError(t, err)
+
+	// a StatefulSet with volumes, e.g. the ingesters
+	withVolumes := statefulSet("tempo-prune-volumes-test-ingester", []corev1.PersistentVolumeClaim{{
+		ObjectMeta: metav1.ObjectMeta{Name: "data"},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+			},
+		},
+	}})
+	require.NoError(t, ctrl.SetControllerReference(tempo, withVolumes, testScheme))
+	err = k8sClient.Create(context.Background(), withVolumes)
+	require.NoError(t, err)
+
+	pruneObjects, err := findObjectsOwnedByTempoOperator(context.Background(), k8sClient, configv1alpha1.FeatureGates{}, tempo, manifestutils.CommonLabels(nsn.Name))
+	require.NoError(t, err)
+	names := []string{}
+	for _, obj := range pruneObjects {
+		names = append(names, obj.GetName())
+	}
+	assert.Equal(t, []string{withoutVolumes.Name}, names)
+}
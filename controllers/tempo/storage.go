@@ -1,44 +1,167 @@
 package controllers
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
 	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
 )
 
 // GetAzureParams extracts Azure Storage params from the storage secret.
-func GetAzureParams(tempo v1alpha1.TempoStack, storageSecret *corev1.Secret) *manifestutils.AzureStorage {
-	return &manifestutils.AzureStorage{
-		Container: string(storageSecret.Data["container"]),
+func GetAzureParams(storage v1alpha1.ObjectStorageSpec, storageSecret *corev1.Secret) *manifestutils.AzureStorage {
+	azure := &manifestutils.AzureStorage{
+		Container:      string(storageSecret.Data["container"]),
+		Prefix:         v1alpha1.ObjectStoragePrefix(storage, *storageSecret),
+		EndpointSuffix: string(storageSecret.Data["endpoint_suffix"]),
 	}
+	// Tempo addresses the account with the endpoint suffix, either as subdomain over HTTPS (Azure clouds)
+	// or as path over plain HTTP (if the suffix does not start with "blob.core", e.g. Azurite).
+	// The webhook ensures that the scheme of the endpoint matches the scheme used by Tempo.
+	if endpoint := string(storageSecret.Data["endpoint"]); endpoint != "" {
+		azure.EndpointSuffix = strings.TrimPrefix(strings.TrimPrefix(endpoint, "https://"), "http://")
+	}
+
+	if storage.CredentialMode == v1alpha1.CredentialModeToken {
+		azure.UseFederatedToken = true
+		azure.ClientID = string(storageSecret.Data["client_id"])
+		azure.TenantID = string(storageSecret.Data["tenant_id"])
+	}
+
+	return azure
 }
 
 // GetGCSParams extracts GCS params from the storage secret.
-func GetGCSParams(tempo v1alpha1.TempoStack, storageSecret *corev1.Secret) *manifestutils.GCS {
-	return &manifestutils.GCS{
-		Bucket: string(storageSecret.Data["bucketname"]),
+func GetGCSParams(storage v1alpha1.ObjectStorageSpec, storageSecret *corev1.Secret) *manifestutils.GCS {
+	gcs := &manifestutils.GCS{
+		Bucket:   string(storageSecret.Data["bucketname"]),
+		Prefix:   v1alpha1.ObjectStoragePrefix(storage, *storageSecret),
+		Endpoint: string(storageSecret.Data["endpoint"]),
 	}
+
+	if storage.CredentialMode == v1alpha1.CredentialModeToken {
+		gcs.IAMServiceAccount = string(storageSecret.Data["iam_service_account"])
+		// The credential configuration is validated by the webhook and before extracting the storage params.
+		credentialConfig := struct {
+			Audience string `json:"audience"`
+		}{}
+		if err := json.Unmarshal(storageSecret.Data["key.json"], &credentialConfig); err == nil {
+			gcs.Audience = credentialConfig.Audience
+		}
+	}
+
+	return gcs
 }
 
 // GetS3Params extracts S3 params from the storage secret.
-func GetS3Params(tempo v1alpha1.TempoStack, storageSecret *corev1.Secret) *manifestutils.S3 {
+func GetS3Params(storage v1alpha1.ObjectStorageSpec, storageSecret *corev1.Secret) *manifestutils.S3 {
 	endpoint := string(storageSecret.Data["endpoint"])
 	insecure := !strings.HasPrefix(endpoint, "https://")
 	endpoint = strings.TrimPrefix(endpoint, "https://")
 	endpoint = strings.TrimPrefix(endpoint, "http://")
 
 	caPath := ""
-	if tempo.Spec.Storage.TLS.CA != "" {
+	if storage.TLS.CA != "" {
 		caPath = manifestutils.TempoStorageTLSCAPath()
 	}
 
-	return &manifestutils.S3{
+	s3 := &manifestutils.S3{
 		Endpoint:  endpoint,
 		Bucket:    string(storageSecret.Data["bucket"]),
+		Prefix:    v1alpha1.ObjectStoragePrefix(storage, *storageSecret),
 		Insecure:  insecure,
 		TLSCAPath: caPath,
+		Region:    string(storageSecret.Data["region"]),
+	}
+	// The value is validated by the webhook and before the reconciliation.
+	s3.ForcePathStyle, _ = strconv.ParseBool(string(storageSecret.Data["forcepathstyle"]))
+
+	if storage.CredentialMode == v1alpha1.CredentialModeToken {
+		s3.RoleARN = string(storageSecret.Data["role_arn"])
+		// The endpoint is optional in the token mode, default to the regional AWS S3 endpoint.
+		if s3.Endpoint == "" {
+			s3.Endpoint = fmt.Sprintf("s3.%s.amazonaws.com", s3.Region)
+			s3.Insecure = false
+		}
+	}
+
+	return s3
+}
+
+// getStorageParams fetches and validates the storage secret and the CA ConfigMap of an object storage
+// and extracts the parameters required to render the Tempo configuration.
+func getStorageParams(ctx context.Context, c client.Client, namespace string, storage v1alpha1.ObjectStorageSpec, secretPath *field.Path) (manifestutils.StorageParams, error) {
+	storageSecret := &corev1.Secret{}
+	err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: storage.Secret.Name}, storageSecret)
+	if err != nil {
+		return manifestutils.StorageParams{}, fmt.Errorf("could not fetch storage secret: %w", err)
+	}
+
+	fieldErrs := v1alpha1.ValidateObjectStorageSecret(secretPath, storage, *storageSecret)
+	if len(fieldErrs) > 0 {
+		return manifestutils.StorageParams{}, fmt.Errorf("invalid storage secret: %s", listErrors(fieldErrs))
+	}
+
+	var caConfigMap *corev1.ConfigMap
+	if storage.TLS.CA != "" {
+		caConfigMap = &corev1.ConfigMap{}
+		err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: storage.TLS.CA}, caConfigMap)
+		if err != nil {
+			return manifestutils.StorageParams{}, fmt.Errorf("could not fetch CA config map: %w", err)
+		}
+
+		fieldErrs := v1alpha1.ValidateStorageCAConfigMap(*caConfigMap)
+		if len(fieldErrs) > 0 {
+			return manifestutils.StorageParams{}, fmt.Errorf("invalid CA config map: %s", listErrors(fieldErrs))
+		}
+	}
+
+	params := manifestutils.StorageParams{
+		CredentialsHash: storageCredentialsHash(storageSecret, caConfigMap),
+	}
+	switch storage.Secret.Type {
+	case v1alpha1.ObjectStorageSecretAzure:
+		params.AzureStorage = GetAzureParams(storage, storageSecret)
+	case v1alpha1.ObjectStorageSecretGCS:
+		params.GCS = GetGCSParams(storage, storageSecret)
+	case v1alpha1.ObjectStorageSecretS3:
+		params.S3 = GetS3Params(storage, storageSecret)
+	default:
+		return manifestutils.StorageParams{}, fmt.Errorf("storage secret type is not recognized")
+	}
+
+	return params, nil
+}
+
+// storageCredentialsHash returns the hash of the content of the storage secret and the optional storage CA ConfigMap.
+func storageCredentialsHash(storageSecret *corev1.Secret, caConfigMap *corev1.ConfigMap) string {
+	h := sha256.New()
+	for _, key := range sortedKeys(storageSecret.Data) {
+		fmt.Fprintf(h, "%s=%s\n", key, storageSecret.Data[key])
+	}
+	if caConfigMap != nil {
+		for _, key := range sortedKeys(caConfigMap.Data) {
+			fmt.Fprintf(h, "%s=%s\n", key, caConfigMap.Data[key])
+		}
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
 	}
+	sort.Strings(keys)
+	return keys
 }
@@ -0,0 +1,221 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/handlers/storage"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+const (
+	// storageCheckRetryInterval is the interval after which a failed storage check is repeated,
+	// even if the storage secret and the CA ConfigMap did not change.
+	storageCheckRetryInterval = 5 * time.Minute
+
+	messageStorageReachable = "The object storage is reachable"
+)
+
+// storageCheckResult is the result of the last storage check of a TempoStack.
+type storageCheckResult struct {
+	// key identifies the storage configuration and credentials which were checked.
+	key       string
+	condition metav1.Condition
+	checkedAt time.Time
+}
+
+// storageChecks caches the results of the storage checks, to check the object storage only
+// if the storage configuration or credentials changed, and not in every reconciliation.
+// The checks run in the background, because an unreachable object storage blocks a check until it times out.
+type storageChecks struct {
+	mu      sync.Mutex
+	results map[types.NamespacedName]storageCheckResult
+	// running contains the key of the storage configuration which is currently checked.
+	running map[types.NamespacedName]string
+}
+
+func (s *storageChecks) get(name types.NamespacedName) (storageCheckResult, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result, ok := s.results[name]
+	return result, ok
+}
+
+func (s *storageChecks) set(name types.NamespacedName, result storageCheckResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.results == nil {
+		s.results = map[types.NamespacedName]storageCheckResult{}
+	}
+	s.results[name] = result
+	delete(s.running, name)
+}
+
+// start marks a storage check as running, and returns false if the same storage configuration is already checked.
+func (s *storageChecks) start(name types.NamespacedName, key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.running == nil {
+		s.running = map[types.NamespacedName]string{}
+	}
+	if running, ok := s.running[name]; ok && running == key {
+		return false
+	}
+	s.running[name] = key
+	return true
+}
+
+// finish stores the result of a storage check, unless the TempoStack was deleted
+// or its storage configuration changed while the check was running.
+func (s *storageChecks) finish(name types.NamespacedName, result storageCheckResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if running, ok := s.running[name]; !ok || running != result.key {
+		return
+	}
+	if s.results == nil {
+		s.results = map[types.NamespacedName]storageCheckResult{}
+	}
+	s.results[name] = result
+	delete(s.running, name)
+}
+
+func (s *storageChecks) isRunning(name types.NamespacedName) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.running[name]
+	return ok
+}
+
+func (s *storageChecks) forget(name types.NamespacedName) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.results, name)
+	delete(s.running, name)
+}
+
+// storageCheckKey returns a key which changes if the storage configuration, the storage secret or the CA ConfigMap changes.
+func storageCheckKey(storageSpec v1alpha1.ObjectStorageSpec, storageParams manifestutils.StorageParams) (string, error) {
+	// The storage params contain the hash of the storage secret and the CA ConfigMap.
+	key, err := json.Marshal(struct {
+		Spec   v1alpha1.ObjectStorageSpec
+		Params manifestutils.StorageParams
+	}{storageSpec, storageParams})
+	return string(key), err
+}
+
+// checkStorage starts a background check if the object storage of the TempoStack is reachable, if the storageCheck feature gate is enabled.
+// The check is repeated if the storage configuration or credentials change, or if the last check failed
+// more than storageCheckRetryInterval ago. The result is reported in the next reconciliation after the check finished.
+func (r *TempoStackReconciler) checkStorage(log logr.Logger, tempo v1alpha1.TempoStack, storageParams manifestutils.StorageParams) error {
+	if !r.CtrlConfig.Gates.StorageCheck {
+		return nil
+	}
+
+	name := types.NamespacedName{Namespace: tempo.Namespace, Name: tempo.Name}
+	key, err := storageCheckKey(tempo.Spec.Storage, storageParams)
+	if err != nil {
+		return fmt.Errorf("could not compute storage check key: %w", err)
+	}
+
+	last, ok := r.storageChecks.get(name)
+	if ok && last.key == key && (last.condition.Status != metav1.ConditionFalse || time.Since(last.checkedAt) < storageCheckRetryInterval) {
+		return nil
+	}
+	if !r.storageChecks.start(name, key) {
+		return nil
+	}
+
+	// The check must not be canceled when the reconciliation finishes, storage.Check applies its own timeout.
+	go func() {
+		r.storageChecks.finish(name, storageCheckResult{
+			key:       key,
+			condition: r.storageCheckCondition(context.Background(), log, tempo, storageParams),
+			checkedAt: time.Now(),
+		})
+	}()
+	return nil
+}
+
+// storageCheckCondition checks the object storage and returns the resulting StorageReachable condition.
+func (r *TempoStackReconciler) storageCheckCondition(ctx context.Context, log logr.Logger, tempo v1alpha1.TempoStack, storageParams manifestutils.StorageParams) metav1.Condition {
+	condition := metav1.Condition{
+		Status:  metav1.ConditionTrue,
+		Reason:  string(v1alpha1.ReasonStorageCheckSucceeded),
+		Message: messageStorageReachable,
+	}
+	err := r.runStorageCheck(ctx, tempo.Namespace, tempo.Spec.Storage, storageParams)
+	if errors.Is(err, storage.ErrNotSupported) {
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = string(v1alpha1.ReasonStorageCheckNotSupported)
+		condition.Message = err.Error()
+	} else if err != nil {
+		log.Info("object storage is not reachable", "error", err.Error())
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = string(v1alpha1.ReasonStorageCheckFailed)
+		condition.Message = err.Error()
+	}
+	return condition
+}
+
+// invalidStorageConfig reports the StorageReachable condition as false if the storage configuration is invalid.
+func (r *TempoStackReconciler) invalidStorageConfig(tempo v1alpha1.TempoStack, message string) {
+	if !r.CtrlConfig.Gates.StorageCheck {
+		return
+	}
+
+	r.storageChecks.set(types.NamespacedName{Namespace: tempo.Namespace, Name: tempo.Name}, storageCheckResult{
+		condition: metav1.Condition{
+			Status:  metav1.ConditionFalse,
+			Reason:  string(v1alpha1.ReasonInvalidStorageConfig),
+			Message: message,
+		},
+		checkedAt: time.Now(),
+	})
+}
+
+// runStorageCheck fetches the storage secret and the optional CA ConfigMap, which were already validated
+// while extracting the storage params, and checks the object storage.
+func (r *TempoStackReconciler) runStorageCheck(ctx context.Context, namespace string, storageSpec v1alpha1.ObjectStorageSpec, storageParams manifestutils.StorageParams) error {
+	storageSecret := corev1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: storageSpec.Secret.Name}, &storageSecret)
+	if err != nil {
+		return fmt.Errorf("could not fetch storage secret: %w", err)
+	}
+
+	var caConfigMap *corev1.ConfigMap
+	if storageSpec.TLS.CA != "" {
+		caConfigMap = &corev1.ConfigMap{}
+		err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: storageSpec.TLS.CA}, caConfigMap)
+		if err != nil {
+			return fmt.Errorf("could not fetch CA config map: %w", err)
+		}
+	}
+
+	return storage.Check(ctx, storageSpec, storageParams, storageSecret, caConfigMap)
+}
+
+// storageCheckRunning returns true if a storage check of the TempoStack is running in the background.
+func (r *TempoStackReconciler) storageCheckRunning(tempo v1alpha1.TempoStack) bool {
+	return r.CtrlConfig.Gates.StorageCheck && r.storageChecks.isRunning(types.NamespacedName{Namespace: tempo.Namespace, Name: tempo.Name})
+}
+
+// storageReachableCondition returns the StorageReachable condition of the last storage check of the TempoStack.
+func (r *TempoStackReconciler) storageReachableCondition(tempo v1alpha1.TempoStack) (metav1.Condition, bool) {
+	if !r.CtrlConfig.Gates.StorageCheck {
+		return metav1.Condition{}, false
+	}
+
+	result, ok := r.storageChecks.get(types.NamespacedName{Namespace: tempo.Namespace, Name: tempo.Name})
+	return result.condition, ok
+}
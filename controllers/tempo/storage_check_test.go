@@ -0,0 +1,163 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func TestStorageCheckKey(t *testing.T) {
+	storageSpec := v1alpha1.ObjectStorageSpec{
+		Secret: v1alpha1.ObjectStorageSecretSpec{Name: "storage-secret", Type: v1alpha1.ObjectStorageSecretS3},
+	}
+	params := manifestutils.StorageParams{
+		CredentialsHash: "hash",
+		S3:              &manifestutils.S3{Bucket: "bucket"},
+	}
+
+	key, err := storageCheckKey(storageSpec, params)
+	require.NoError(t, err)
+
+	sameKey, err := storageCheckKey(storageSpec, manifestutils.StorageParams{
+		CredentialsHash: "hash",
+		S3:              &manifestutils.S3{Bucket: "bucket"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, key, sameKey)
+
+	changedCredentials, err := storageCheckKey(storageSpec, manifestutils.StorageParams{
+		CredentialsHash: "new-hash",
+		S3:              &manifestutils.S3{Bucket: "bucket"},
+	})
+	require.NoError(t, err)
+	assert.NotEqual(t, key, changedCredentials)
+
+	storageSpec.TLS.CA = "storage-ca"
+	changedCA, err := storageCheckKey(storageSpec, params)
+	require.NoError(t, err)
+	assert.NotEqual(t, key, changedCA)
+}
+
+func TestCheckStorageCached(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "simplest", Namespace: "default"},
+	}
+	params := manifestutils.StorageParams{CredentialsHash: "hash"}
+	key, err := storageCheckKey(tempo.Spec.Storage, params)
+	require.NoError(t, err)
+
+	condition := metav1.Condition{
+		Status: metav1.ConditionTrue,
+		Reason: string(v1alpha1.ReasonStorageCheckSucceeded),
+	}
+	reconciler := TempoStackReconciler{
+		CtrlConfig: configv1alpha1.ProjectConfig{
+			Gates: configv1alpha1.FeatureGates{StorageCheck: true},
+		},
+	}
+	reconciler.storageChecks.set(types.NamespacedName{Namespace: "default", Name: "simplest"}, storageCheckResult{
+		key:       key,
+		condition: condition,
+		checkedAt: time.Now().Add(-time.Hour),
+	})
+
+	// The reconciler has no client, therefore the check would fail if the storage was checked again.
+	err = reconciler.checkStorage(logr.Discard(), tempo, params)
+	require.NoError(t, err)
+	cond, ok := reconciler.storageReachableCondition(tempo)
+	require.True(t, ok)
+	assert.Equal(t, condition, cond)
+
+	reconciler.storageChecks.forget(types.NamespacedName{Namespace: "default", Name: "simplest"})
+	_, ok = reconciler.storageReachableCondition(tempo)
+	assert.False(t, ok)
+}
+
+func TestStorageReachableConditionDisabled(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "simplest", Namespace: "default"},
+	}
+	reconciler := TempoStackReconciler{}
+	reconciler.invalidStorageConfig(tempo, "invalid storage secret")
+
+	_, ok := reconciler.storageReachableCondition(tempo)
+	assert.False(t, ok)
+}
+
+// storageSecretNotFoundFake is a client without any storage secrets.
+type storageSecretNotFoundFake struct {
+	client.Client
+}
+
+func (*storageSecretNotFoundFake) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return apierrors.NewNotFound(corev1.Resource("secrets"), key.Name)
+}
+
+func TestCheckStorageInBackground(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "simplest", Namespace: "default"},
+		Spec: v1alpha1.TempoStackSpec{
+			Storage: v1alpha1.ObjectStorageSpec{
+				Secret: v1alpha1.ObjectStorageSecretSpec{Name: "storage-secret", Type: v1alpha1.ObjectStorageSecretS3},
+			},
+		},
+	}
+	reconciler := TempoStackReconciler{
+		Client: &storageSecretNotFoundFake{},
+		CtrlConfig: configv1alpha1.ProjectConfig{
+			Gates: configv1alpha1.FeatureGates{StorageCheck: true},
+		},
+	}
+
+	err := reconciler.checkStorage(logr.Discard(), tempo, manifestutils.StorageParams{CredentialsHash: "hash"})
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		return !reconciler.storageCheckRunning(tempo)
+	}, 5*time.Second, 10*time.Millisecond)
+	cond, ok := reconciler.storageReachableCondition(tempo)
+	require.True(t, ok)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, string(v1alpha1.ReasonStorageCheckFailed), cond.Reason)
+}
+
+func TestStorageChecksFinish(t *testing.T) {
+	name := types.NamespacedName{Namespace: "default", Name: "simplest"}
+	checks := storageChecks{}
+
+	require.True(t, checks.start(name, "key"))
+	assert.False(t, checks.start(name, "key"))
+	assert.True(t, checks.isRunning(name))
+
+	// the storage configuration changed while the check was running
+	require.True(t, checks.start(name, "new-key"))
+	checks.finish(name, storageCheckResult{key: "key"})
+	_, ok := checks.get(name)
+	assert.False(t, ok)
+
+	checks.finish(name, storageCheckResult{key: "new-key"})
+	result, ok := checks.get(name)
+	require.True(t, ok)
+	assert.Equal(t, "new-key", result.key)
+	assert.False(t, checks.isRunning(name))
+
+	// the TempoStack was deleted while the check was running
+	require.True(t, checks.start(name, "key"))
+	checks.forget(name)
+	checks.finish(name, storageCheckResult{key: "key"})
+	_, ok = checks.get(name)
+	assert.False(t, ok)
+}
@@ -16,7 +16,7 @@ func TestGetS3ParamsInsecure(t *testing.T) {
 			"bucket":   []byte("testbucket"),
 		},
 	}
-	s3 := GetS3Params(v1alpha1.TempoStack{}, storageSecret)
+	s3 := GetS3Params(v1alpha1.ObjectStorageSpec{}, storageSecret)
 	assert.Equal(t, "minio:9000", s3.Endpoint)
 	assert.True(t, s3.Insecure)
 	assert.Equal(t, "testbucket", s3.Bucket)
@@ -29,8 +29,157 @@ func TestGetS3ParamsSecure(t *testing.T) {
 			"bucket":   []byte("testbucket"),
 		},
 	}
-	s3 := GetS3Params(v1alpha1.TempoStack{}, storageSecret)
+	s3 := GetS3Params(v1alpha1.ObjectStorageSpec{}, storageSecret)
 	assert.Equal(t, "minio:9000", s3.Endpoint)
 	assert.False(t, s3.Insecure)
 	assert.Equal(t, "testbucket", s3.Bucket)
 }
+
+func TestGetS3ParamsToken(t *testing.T) {
+	storageSecret := &corev1.Secret{
+		Data: map[string][]byte{
+			"bucket":   []byte("testbucket"),
+			"region":   []byte("eu-central-1"),
+			"role_arn": []byte("arn:aws:iam::123456789012:role/tempo"),
+		},
+	}
+	s3 := GetS3Params(v1alpha1.ObjectStorageSpec{CredentialMode: v1alpha1.CredentialModeToken}, storageSecret)
+	assert.Equal(t, "s3.eu-central-1.amazonaws.com", s3.Endpoint)
+	assert.False(t, s3.Insecure)
+	assert.Equal(t, "testbucket", s3.Bucket)
+	assert.Equal(t, "eu-central-1", s3.Region)
+	assert.Equal(t, "arn:aws:iam::123456789012:role/tempo", s3.RoleARN)
+}
+
+func TestGetS3ParamsOptions(t *testing.T) {
+	storageSecret := &corev1.Secret{
+		Data: map[string][]byte{
+			"endpoint":       []byte("https://minio:9000"),
+			"bucket":         []byte("testbucket"),
+			"region":         []byte("eu-central-1"),
+			"forcepathstyle": []byte("true"),
+		},
+	}
+	s3 := GetS3Params(v1alpha1.ObjectStorageSpec{}, storageSecret)
+	assert.Equal(t, "minio:9000", s3.Endpoint)
+	assert.Equal(t, "eu-central-1", s3.Region)
+	assert.True(t, s3.ForcePathStyle)
+
+	storageSecret.Data = map[string][]byte{
+		"endpoint": []byte("https://minio:9000"),
+		"bucket":   []byte("testbucket"),
+	}
+	s3 = GetS3Params(v1alpha1.ObjectStorageSpec{}, storageSecret)
+	assert.False(t, s3.ForcePathStyle)
+}
+
+func TestGetAzureParamsToken(t *testing.T) {
+	storageSecret := &corev1.Secret{
+		Data: map[string][]byte{
+			"container":       []byte("container"),
+			"account_name":    []byte("account"),
+			"client_id":       []byte("client"),
+			"tenant_id":       []byte("tenant"),
+			"subscription_id": []byte("subscription"),
+		},
+	}
+	azure := GetAzureParams(v1alpha1.ObjectStorageSpec{CredentialMode: v1alpha1.CredentialModeToken}, storageSecret)
+	assert.Equal(t, "container", azure.Container)
+	assert.True(t, azure.UseFederatedToken)
+	assert.Equal(t, "client", azure.ClientID)
+	assert.Equal(t, "tenant", azure.TenantID)
+}
+
+func TestGetAzureParamsEndpoint(t *testing.T) {
+	storageSecret := &corev1.Secret{
+		Data: map[string][]byte{
+			"container":    []byte("container"),
+			"account_name": []byte("devstoreaccount1"),
+			"account_key":  []byte("key"),
+			"endpoint":     []byte("http://azurite:10000"),
+		},
+	}
+	azure := GetAzureParams(v1alpha1.ObjectStorageSpec{}, storageSecret)
+	assert.Equal(t, "azurite:10000", azure.EndpointSuffix)
+
+	storageSecret.Data = map[string][]byte{
+		"container":       []byte("container"),
+		"account_name":    []byte("account"),
+		"account_key":     []byte("key"),
+		"endpoint_suffix": []byte("blob.core.chinacloudapi.cn"),
+	}
+	azure = GetAzureParams(v1alpha1.ObjectStorageSpec{}, storageSecret)
+	assert.Equal(t, "blob.core.chinacloudapi.cn", azure.EndpointSuffix)
+}
+
+func TestGetGCSParamsEndpoint(t *testing.T) {
+	storageSecret := &corev1.Secret{
+		Data: map[string][]byte{
+			"bucketname": []byte("bucket"),
+			"key.json":   []byte("{}"),
+			"endpoint":   []byte("https://fake-gcs-server:4443/storage/v1/"),
+		},
+	}
+	gcs := GetGCSParams(v1alpha1.ObjectStorageSpec{}, storageSecret)
+	assert.Equal(t, "https://fake-gcs-server:4443/storage/v1/", gcs.Endpoint)
+}
+
+func TestGetGCSParamsToken(t *testing.T) {
+	storageSecret := &corev1.Secret{
+		Data: map[string][]byte{
+			"bucketname":          []byte("bucket"),
+			"iam_service_account": []byte("tempo@project.iam.gserviceaccount.com"),
+			"key.json":            []byte(`{"type":"external_account","audience":"//iam.googleapis.com/projects/1/locations/global/workloadIdentityPools/pool/providers/provider"}`),
+		},
+	}
+	gcs := GetGCSParams(v1alpha1.ObjectStorageSpec{CredentialMode: v1alpha1.CredentialModeToken}, storageSecret)
+	assert.Equal(t, "bucket", gcs.Bucket)
+	assert.Equal(t, "tempo@project.iam.gserviceaccount.com", gcs.IAMServiceAccount)
+	assert.Equal(t, "//iam.googleapis.com/projects/1/locations/global/workloadIdentityPools/pool/providers/provider", gcs.Audience)
+}
+
+func TestStorageCredentialsHash(t *testing.T) {
+	storageSecret := &corev1.Secret{
+		Data: map[string][]byte{
+			"endpoint":          []byte("http://minio:9000"),
+			"bucket":            []byte("testbucket"),
+			"access_key_id":     []byte("id"),
+			"access_key_secret": []byte("secret"),
+		},
+	}
+	hash := storageCredentialsHash(storageSecret, nil)
+	assert.NotEmpty(t, hash)
+	assert.Equal(t, hash, storageCredentialsHash(storageSecret.DeepCopy(), nil))
+
+	// Rotating the access key changes the hash
+	rotated := storageSecret.DeepCopy()
+	rotated.Data["access_key_secret"] = []byte("rotated")
+	assert.NotEqual(t, hash, storageCredentialsHash(rotated, nil))
+
+	// Changing the CA certificate changes the hash
+	caConfigMap := &corev1.ConfigMap{
+		Data: map[string]string{
+			"ca.crt": "ca",
+		},
+	}
+	caHash := storageCredentialsHash(storageSecret, caConfigMap)
+	assert.NotEqual(t, hash, caHash)
+	caConfigMap.Data["ca.crt"] = "rotated"
+	assert.NotEqual(t, caHash, storageCredentialsHash(storageSecret, caConfigMap))
+}
+
+func TestGetS3ParamsPrefix(t *testing.T) {
+	storageSecret := &corev1.Secret{
+		Data: map[string][]byte{
+			"endpoint": []byte("http://minio:9000"),
+			"bucket":   []byte("testbucket"),
+			"prefix":   []byte("/team-a/"),
+		},
+	}
+	s3 := GetS3Params(v1alpha1.ObjectStorageSpec{}, storageSecret)
+	assert.Equal(t, "team-a", s3.Prefix)
+
+	// The prefix of the storage spec takes precedence over the prefix of the storage secret
+	s3 = GetS3Params(v1alpha1.ObjectStorageSpec{Prefix: "team-b"}, storageSecret)
+	assert.Equal(t, "team-b", s3.Prefix)
+}
@@ -0,0 +1,283 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	routev1 "github.com/openshift/api/route/v1"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/monolithic"
+	"github.com/grafana/tempo-operator/internal/status"
+)
+
+const (
+	monolithicStorageSecretField = ".spec.storage.traces.secret" // nolint #nosec
+	monolithicStorageCAField     = ".spec.storage.traces.tls.caName"
+)
+
+// TempoMonolithicReconciler reconciles a TempoMonolithic object.
+type TempoMonolithicReconciler struct {
+	client.Client
+	Scheme     *runtime.Scheme
+	Recorder   record.EventRecorder
+	CtrlConfig configv1alpha1.ProjectConfig
+}
+
+//+kubebuilder:rbac:groups=tempo.grafana.com,resources=tempomonolithics,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=tempo.grafana.com,resources=tempomonolithics/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=tempo.grafana.com,resources=tempomonolithics/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *TempoMonolithicReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx).WithName("tempomonolithic-reconcile").WithValues("tempo", req.NamespacedName)
+
+	log.V(1).Info("starting reconcile loop")
+	defer log.V(1).Info("finished reconcile loop")
+
+	tempo := v1alpha1.TempoMonolithic{}
+	if err := r.Get(ctx, req.NamespacedName, &tempo); err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "unable to fetch TempoMonolithic")
+			return ctrl.Result{}, fmt.Errorf("could not fetch tempo: %w", err)
+		}
+
+		// we'll ignore not-found errors, since they can't be fixed by an immediate
+		// requeue (we'll need to wait for a new notification), and we can get them
+		// on deleted requests.
+		return ctrl.Result{}, nil
+	}
+
+	if tempo.Spec.ManagementState != v1alpha1.ManagementStateManaged {
+		log.Info("Skipping reconciliation for unmanaged TempoMonolithic resource", "name", req.String())
+		// Stop requeueing for unmanaged TempoMonolithic custom resources
+		return ctrl.Result{}, nil
+	}
+
+	err := r.createOrUpdate(ctx, log, tempo)
+	if err != nil {
+		return r.handleReconcileStatus(ctx, log, tempo, err)
+	}
+
+	// Update the status also in case of no reconciliation errors.
+	return r.handleReconcileStatus(ctx, log, tempo, nil)
+}
+
+func (r *TempoMonolithicReconciler) getStorageConfig(ctx context.Context, tempo v1alpha1.TempoMonolithic) (manifestutils.StorageParams, error) {
+	objectStorage, ok := v1alpha1.MonolithicObjectStorage(tempo.Spec.Storage.Traces)
+	if !ok {
+		// in-memory or persistent volume storage
+		return manifestutils.StorageParams{}, nil
+	}
+
+	backend := string(tempo.Spec.Storage.Traces.Backend)
+	secretPath := field.NewPath("spec").Child("storage").Child("traces").Child(backend).Child("secret")
+	return getStorageParams(ctx, r.Client, tempo.Namespace, objectStorage, secretPath)
+}
+
+func (r *TempoMonolithicReconciler) createOrUpdate(ctx context.Context, log logr.Logger, tempo v1alpha1.TempoMonolithic) error {
+	storageParams, err := r.getStorageConfig(ctx, tempo)
+	if err != nil {
+		return &status.ConfigurationError{
+			Reason:  v1alpha1.ReasonInvalidStorageConfig,
+			Message: err.Error(),
+		}
+	}
+
+	// Collect all objects owned by the operator, to be able to prune objects
+	// which exist in the cluster but are not managed by the operator anymore.
+	pruneObjects, err := findObjectsOwnedByTempoOperator(ctx, r.Client, r.CtrlConfig.Gates, &tempo, manifestutils.CommonMonolithicLabels(tempo.Name))
+	if err != nil {
+		return err
+	}
+
+	managedObjects, err := monolithic.BuildAll(monolithic.Options{
+		CtrlConfig:    r.CtrlConfig,
+		Tempo:         tempo,
+		StorageParams: storageParams,
+	})
+	if err != nil {
+		return fmt.Errorf("error building manifests: %w", err)
+	}
+
+	return reconcileManagedObjects(ctx, log, r.Client, &tempo, r.Scheme, managedObjects, pruneObjects)
+}
+
+// handleReconcileStatus updates the status of the TempoMonolithic instance and sets an appropriate status condition:
+//
+//   - No error: Only update the status of the pods
+//
+//   - For ConfigurationError: Set the status condition to ConfigurationError.
+//     Return a reconcile.TerminalError to indicate that human intervention is required
+//     to resolve this error, and that the reconciliation request should not be requeued.
+//
+//   - For any other error: Set the status condition to Failed,
+//     the Reason to "FailedReconciliation" and the message to the error message.
+func (r *TempoMonolithicReconciler) handleReconcileStatus(ctx context.Context, log logr.Logger, tempo v1alpha1.TempoMonolithic, reconcileError error) (ctrl.Result, error) {
+	newStatus, rerr := status.GetMonolithicStatus(ctx, r, tempo)
+	if rerr != nil {
+		log.Error(rerr, "could not get status")
+		newStatus = tempo.Status
+	}
+
+	var configurationError *status.ConfigurationError
+	if reconcileError == nil {
+		// No error.
+	} else if errors.As(reconcileError, &configurationError) {
+		// Handle configuration error
+		newStatus.Conditions = status.UpdateMonolithicCondition(tempo, metav1.Condition{
+			Type:    string(v1alpha1.ConditionConfigurationError),
+			Reason:  string(configurationError.Reason),
+			Message: configurationError.Message,
+		})
+
+		// wrap error in reconcile.TerminalError to indicate human intervention is required
+		// and the request should not be requeued.
+		reconcileError = reconcile.TerminalError(configurationError)
+	} else {
+		// Handle all other errors (e.g. permission errors, etc.)
+		newStatus.Conditions = status.UpdateMonolithicCondition(tempo, metav1.Condition{
+			Type:    string(v1alpha1.ConditionFailed),
+			Reason:  string(v1alpha1.ReasonFailedReconciliation),
+			Message: reconcileError.Error(),
+		})
+	}
+
+	rerr = status.RefreshMonolithic(ctx, r, tempo, &newStatus)
+	if rerr != nil {
+		return ctrl.Result{}, rerr
+	}
+
+	return ctrl.Result{}, reconcileError
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *TempoMonolithicReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Add an index to the storage secret of the TempoMonolithic CRD.
+	// If the content of any secret in the cluster changes, the watcher can identify related TempoMonolithic CRs
+	// and reconcile them (i.e. update the tempo configuration file and restart the pods)
+	err := mgr.GetFieldIndexer().IndexField(context.Background(), &v1alpha1.TempoMonolithic{}, monolithicStorageSecretField, func(rawObj client.Object) []string {
+		tempo := rawObj.(*v1alpha1.TempoMonolithic)
+		objectStorage, ok := v1alpha1.MonolithicObjectStorage(tempo.Spec.Storage.Traces)
+		if !ok || objectStorage.Secret.Name == "" {
+			return nil
+		}
+		return []string{objectStorage.Secret.Name}
+	})
+	if err != nil {
+		return err
+	}
+
+	// The storage CA ConfigMap is indexed as well, to roll out the pods if the CA certificate changes.
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), &v1alpha1.TempoMonolithic{}, monolithicStorageCAField, func(rawObj client.Object) []string {
+		tempo := rawObj.(*v1alpha1.TempoMonolithic)
+		objectStorage, ok := v1alpha1.MonolithicObjectStorage(tempo.Spec.Storage.Traces)
+		if !ok || objectStorage.TLS.CA == "" {
+			return nil
+		}
+		return []string{objectStorage.TLS.CA}
+	})
+	if err != nil {
+		return err
+	}
+
+	builder := ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.TempoMonolithic{}).
+		Owns(&corev1.ConfigMap{}).
+		Owns(&corev1.Service{}).
+		Owns(&appsv1.StatefulSet{}).
+		Owns(&networkingv1.Ingress{}).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.findTempoMonolithicForStorageSecret),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
+		Watches(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.findTempoMonolithicForStorageCA),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		)
+
+	if r.CtrlConfig.Gates.PrometheusOperator {
+		builder = builder.Owns(&monitoringv1.ServiceMonitor{})
+	}
+
+	if r.CtrlConfig.Gates.OpenShift.OpenShiftRoute {
+		builder = builder.Owns(&routev1.Route{})
+	}
+
+	return builder.Complete(r)
+}
+
+func (r *TempoMonolithicReconciler) findTempoMonolithicForStorageSecret(ctx context.Context, secret client.Object) []reconcile.Request {
+	return r.findTempoMonolithicsByField(ctx, monolithicStorageSecretField, secret)
+}
+
+func (r *TempoMonolithicReconciler) findTempoMonolithicForStorageCA(ctx context.Context, configMap client.Object) []reconcile.Request {
+	return r.findTempoMonolithicsByField(ctx, monolithicStorageCAField, configMap)
+}
+
+// findTempoMonolithicsByField returns a reconcile request for each TempoMonolithic in the namespace of the object,
+// which references the object in the indexed field.
+func (r *TempoMonolithicReconciler) findTempoMonolithicsByField(ctx context.Context, field string, obj client.Object) []reconcile.Request {
+	tempos := &v1alpha1.TempoMonolithicList{}
+	listOps := &client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(field, obj.GetName()),
+		Namespace:     obj.GetNamespace(),
+	}
+	err := r.List(ctx, tempos, listOps)
+	if err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, len(tempos.Items))
+	for i, item := range tempos.Items {
+		requests[i] = reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      item.GetName(),
+				Namespace: item.GetNamespace(),
+			},
+		}
+	}
+	return requests
+}
+
+// GetMonolithicPods is used for fetching the pod status and refreshing the status of the CR.
+func (r *TempoMonolithicReconciler) GetMonolithicPods(ctx context.Context, tempo v1alpha1.TempoMonolithic) (*corev1.PodList, error) {
+	pods := &corev1.PodList{}
+
+	opts := []client.ListOption{
+		client.MatchingLabels(manifestutils.CommonMonolithicLabels(tempo.Name)),
+		client.InNamespace(tempo.Namespace),
+	}
+	err := r.Client.List(ctx, pods, opts...)
+	return pods, err
+}
+
+// PatchMonolithicStatus patches the status field of the CR.
+func (r *TempoMonolithicReconciler) PatchMonolithicStatus(ctx context.Context, changed, original *v1alpha1.TempoMonolithic) error {
+	statusPatch := client.MergeFrom(original)
+	return r.Client.Status().Patch(ctx, changed, statusPatch)
+}
@@ -4,13 +4,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	routev1 "github.com/openshift/api/route/v1"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
@@ -34,7 +37,9 @@ import (
 )
 
 const (
-	storageSecretField = ".spec.storage.secret.name" // nolint #nosec
+	storageSecretField     = ".spec.storage.secret.name" // nolint #nosec
+	storageCAField         = ".spec.storage.tls.caName"
+	pendingRequeueInterval = 10 * time.Second
 )
 
 // TempoStackReconciler reconciles a TempoStack object.
@@ -44,11 +49,15 @@ type TempoStackReconciler struct {
 	Recorder   record.EventRecorder
 	CtrlConfig configv1alpha1.ProjectConfig
 	Version    version.Version
+
+	storageChecks storageChecks
 }
 
 // +kubebuilder:rbac:groups="",resources=services;configmaps;serviceaccounts;secrets;pods,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=deployments/finalizers,verbs=update
+// +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterrolebindings;clusterroles,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=route.openshift.io,resources=routes;routes/custom-host,verbs=get;list;watch;create;update;delete
@@ -79,6 +88,7 @@ func (r *TempoStackReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		// we'll ignore not-found errors, since they can't be fixed by an immediate
 		// requeue (we'll need to wait for a new notification), and we can get them
 		// on deleted requests.
+		r.storageChecks.forget(req.NamespacedName)
 		return ctrl.Result{}, nil
 	}
 
@@ -104,6 +114,10 @@ func (r *TempoStackReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		if err != nil {
 			return r.handleReconcileStatus(ctx, log, tempo, err)
 		}
+
+		// The upgrade modifies the instance in the cluster, e.g. the memberlist security of existing TempoStacks.
+		// Reconcile the upgraded instance, instead of rolling out the manifests of the outdated instance.
+		return ctrl.Result{Requeue: true}, nil
 	}
 
 	if r.CtrlConfig.Gates.BuiltInCertManagement.Enabled {
@@ -130,6 +144,12 @@ func (r *TempoStackReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 //     Return a reconcile.TerminalError to indicate that human intervention is required
 //     to resolve this error, and that the reconciliation request should not be requeued.
 //
+//   - For PendingError: Set the status condition to Pending.
+//     Requeue the reconciliation request to refresh the status once the pending step is complete.
+//
+//   - For FailedError: Set the status condition to Failed with the reason and message of the error.
+//     The reconciliation request is requeued with a backoff.
+//
 //   - For any other error: Set the status condition to Failed,
 //     the Reason to "FailedReconciliation" and the message to the error message.
 func (r *TempoStackReconciler) handleReconcileStatus(ctx context.Context, log logr.Logger, tempo v1alpha1.TempoStack, reconcileError error) (ctrl.Result, error) {
@@ -139,7 +159,10 @@ func (r *TempoStackReconciler) handleReconcileStatus(ctx context.Context, log lo
 		log.Error(rerr, "could not get components status")
 	}
 
+	result := ctrl.Result{}
 	var configurationError *status.ConfigurationError
+	var pendingError *status.PendingError
+	var failedError *status.FailedError
 	if reconcileError == nil {
 		// No error.
 	} else if errors.As(reconcileError, &configurationError) {
@@ -153,6 +176,24 @@ func (r *TempoStackReconciler) handleReconcileStatus(ctx context.Context, log lo
 		// wrap error in reconcile.TerminalError to indicate human intervention is required
 		// and the request should not be requeued.
 		reconcileError = reconcile.TerminalError(configurationError)
+	} else if errors.As(reconcileError, &pendingError) {
+		// Handle pending reconciliation steps
+		newStatus.Conditions = status.UpdateCondition(tempo, metav1.Condition{
+			Type:    string(v1alpha1.ConditionPending),
+			Reason:  string(pendingError.Reason),
+			Message: pendingError.Message,
+		})
+
+		// the state of the pending step is not watched, therefore requeue the request after some time.
+		result = ctrl.Result{RequeueAfter: pendingRequeueInterval}
+		reconcileError = nil
+	} else if errors.As(reconcileError, &failedError) {
+		// Handle failed reconciliation steps with a specific reason, the request is requeued with a backoff
+		newStatus.Conditions = status.UpdateCondition(tempo, metav1.Condition{
+			Type:    string(v1alpha1.ConditionFailed),
+			Reason:  string(failedError.Reason),
+			Message: failedError.Message,
+		})
 	} else {
 		// Handle all other errors (e.g. permission errors, etc.)
 		newStatus.Conditions = status.UpdateCondition(tempo, metav1.Condition{
@@ -162,6 +203,20 @@ func (r *TempoStackReconciler) handleReconcileStatus(ctx context.Context, log lo
 		})
 	}
 
+	if condition, ok := r.storageReachableCondition(tempo); ok {
+		newStatus.Conditions = status.StorageReachableCondition(newStatus.Conditions, condition)
+
+		// the object storage is not watched, therefore repeat a failed storage check after some time.
+		if condition.Status == metav1.ConditionFalse && reconcileError == nil && result.RequeueAfter == 0 {
+			result = ctrl.Result{RequeueAfter: storageCheckRetryInterval}
+		}
+	}
+
+	// the storage check runs in the background, therefore requeue the request to pick up its result.
+	if r.storageCheckRunning(tempo) && reconcileError == nil && result.RequeueAfter == 0 {
+		result = ctrl.Result{RequeueAfter: pendingRequeueInterval}
+	}
+
 	// Refresh status
 	rerr = status.Refresh(ctx, r, tempo, &newStatus)
 	if rerr != nil {
@@ -171,7 +226,7 @@ func (r *TempoStackReconciler) handleReconcileStatus(ctx context.Context, log lo
 	// Note: controller-runtime will always reconcile if this function returns any error except TerminalError.
 	// Result.Requeue and Result.RequeueAfter are only respected if err == nil
 	// https://github.com/kubernetes-sigs/controller-runtime/blob/v0.15.0/pkg/internal/controller/controller.go#L315-L341
-	return ctrl.Result{}, reconcileError
+	return result, reconcileError
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -190,6 +245,18 @@ func (r *TempoStackReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
+	// The storage CA ConfigMap is indexed as well, to roll out the pods if the CA certificate changes.
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), &v1alpha1.TempoStack{}, storageCAField, func(rawObj client.Object) []string {
+		tempostacks := rawObj.(*v1alpha1.TempoStack)
+		if tempostacks.Spec.Storage.TLS.CA == "" {
+			return nil
+		}
+		return []string{tempostacks.Spec.Storage.TLS.CA}
+	})
+	if err != nil {
+		return err
+	}
+
 	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.TempoStack{}).
 		Owns(&corev1.ConfigMap{}).
@@ -199,10 +266,17 @@ func (r *TempoStackReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&appsv1.StatefulSet{}).
 		Owns(&appsv1.Deployment{}).
 		Owns(&networkingv1.Ingress{}).
+		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
 		Watches(
 			&corev1.Secret{},
 			handler.EnqueueRequestsFromMapFunc(r.findTempoStackForStorageSecret),
 			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
+		Watches(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.findTempoStackForStorageCA),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
 		)
 
 	if r.CtrlConfig.Gates.PrometheusOperator {
@@ -218,10 +292,20 @@ func (r *TempoStackReconciler) SetupWithManager(mgr ctrl.Manager) error {
 }
 
 func (r *TempoStackReconciler) findTempoStackForStorageSecret(ctx context.Context, secret client.Object) []reconcile.Request {
+	return r.findTempoStacksByField(ctx, storageSecretField, secret)
+}
+
+func (r *TempoStackReconciler) findTempoStackForStorageCA(ctx context.Context, configMap client.Object) []reconcile.Request {
+	return r.findTempoStacksByField(ctx, storageCAField, configMap)
+}
+
+// findTempoStacksByField returns a reconcile request for each TempoStack in the namespace of the object,
+// which references the object in the indexed field.
+func (r *TempoStackReconciler) findTempoStacksByField(ctx context.Context, field string, obj client.Object) []reconcile.Request {
 	tempostacks := &v1alpha1.TempoStackList{}
 	listOps := &client.ListOptions{
-		FieldSelector: fields.OneTermEqualSelector(storageSecretField, secret.GetName()),
-		Namespace:     secret.GetNamespace(),
+		FieldSelector: fields.OneTermEqualSelector(field, obj.GetName()),
+		Namespace:     obj.GetNamespace(),
 	}
 	err := r.List(ctx, tempostacks, listOps)
 	if err != nil {
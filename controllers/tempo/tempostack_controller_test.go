@@ -923,7 +923,12 @@ func TestUpgrade(t *testing.T) {
 	// Bump operator version
 	reconciler.Version.OperatorVersion = "0.0.1"
 
-	// Reconcile should perform upgrade now
+	// Reconcile should perform upgrade now, and requeue the upgraded instance
+	reconcile, err = reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, true, reconcile.Requeue)
+
+	// Reconcile the upgraded instance
 	reconcile, err = reconciler.Reconcile(context.Background(), req)
 	require.NoError(t, err)
 	assert.Equal(t, false, reconcile.Requeue)
@@ -7,13 +7,8 @@ import (
 	"strings"
 
 	"github.com/go-logr/logr"
-	grafanav1 "github.com/grafana-operator/grafana-operator/v5/api/v1beta1"
-	routev1 "github.com/openshift/api/route/v1"
-	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	corev1 "k8s.io/api/core/v1"
-	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
-	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -36,43 +31,43 @@ func listErrors(fieldErrs field.ErrorList) string {
 }
 
 func (r *TempoStackReconciler) getStorageConfig(ctx context.Context, tempo v1alpha1.TempoStack) (manifestutils.StorageParams, error) {
-	storageSecret := &corev1.Secret{}
-	err := r.Get(ctx, types.NamespacedName{Namespace: tempo.Namespace, Name: tempo.Spec.Storage.Secret.Name}, storageSecret)
-	if err != nil {
-		return manifestutils.StorageParams{}, fmt.Errorf("could not fetch storage secret: %w", err)
+	secretPath := field.NewPath("spec").Child("storage").Child("secret")
+	return getStorageParams(ctx, r.Client, tempo.Namespace, tempo.Spec.Storage, secretPath)
+}
+
+// getRemoteWriteParams fetches the optional remote write secret of the metrics-generator
+// and returns which credentials are available.
+func (r *TempoStackReconciler) getRemoteWriteParams(ctx context.Context, tempo v1alpha1.TempoStack) (manifestutils.RemoteWriteParams, error) {
+	spec := tempo.Spec.Template.MetricsGenerator
+	if !spec.Enabled || spec.RemoteWrite.Secret == "" {
+		return manifestutils.RemoteWriteParams{}, nil
 	}
 
-	fieldErrs := v1alpha1.ValidateStorageSecret(tempo, *storageSecret)
-	if len(fieldErrs) > 0 {
-		return manifestutils.StorageParams{}, fmt.Errorf("invalid storage secret: %s", listErrors(fieldErrs))
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: tempo.Namespace, Name: spec.RemoteWrite.Secret}, secret)
+	if err != nil {
+		return manifestutils.RemoteWriteParams{}, fmt.Errorf("could not fetch remote write secret: %w", err)
 	}
 
-	if tempo.Spec.Storage.TLS.CA != "" {
-		caConfigMap := &corev1.ConfigMap{}
-		err := r.Get(ctx, types.NamespacedName{Namespace: tempo.Namespace, Name: tempo.Spec.Storage.TLS.CA}, caConfigMap)
-		if err != nil {
-			return manifestutils.StorageParams{}, fmt.Errorf("could not fetch CA config map: %w", err)
-		}
+	_, hasUsername := secret.Data[manifestutils.RemoteWriteUsernameKey]
+	_, hasPassword := secret.Data[manifestutils.RemoteWritePasswordKey]
+	_, hasToken := secret.Data[manifestutils.RemoteWriteTokenKey]
+	_, hasCA := secret.Data[manifestutils.RemoteWriteCAKey]
 
-		fieldErrs := v1alpha1.ValidateStorageCAConfigMap(*caConfigMap)
-		if len(fieldErrs) > 0 {
-			return manifestutils.StorageParams{}, fmt.Errorf("invalid CA config map: %s", listErrors(fieldErrs))
-		}
+	if hasUsername != hasPassword {
+		return manifestutils.RemoteWriteParams{}, fmt.Errorf("invalid remote write secret: %s and %s must be set together",
+			manifestutils.RemoteWriteUsernameKey, manifestutils.RemoteWritePasswordKey)
 	}
-
-	params := manifestutils.StorageParams{}
-	switch tempo.Spec.Storage.Secret.Type {
-	case v1alpha1.ObjectStorageSecretAzure:
-		params.AzureStorage = GetAzureParams(tempo, storageSecret)
-	case v1alpha1.ObjectStorageSecretGCS:
-		params.GCS = GetGCSParams(tempo, storageSecret)
-	case v1alpha1.ObjectStorageSecretS3:
-		params.S3 = GetS3Params(tempo, storageSecret)
-	default:
-		return manifestutils.StorageParams{}, fmt.Errorf("storage secret type is not recognized")
+	if hasPassword && hasToken {
+		return manifestutils.RemoteWriteParams{}, fmt.Errorf("invalid remote write secret: basic auth and %s are mutually exclusive",
+			manifestutils.RemoteWriteTokenKey)
 	}
 
-	return params, nil
+	return manifestutils.RemoteWriteParams{
+		CA:          hasCA,
+		BasicAuth:   hasPassword,
+		BearerToken: hasToken,
+	}, nil
 }
 
 func isNamespaceScoped(obj client.Object) bool {
@@ -87,12 +82,26 @@ func isNamespaceScoped(obj client.Object) bool {
 func (r *TempoStackReconciler) createOrUpdate(ctx context.Context, log logr.Logger, req ctrl.Request, tempo v1alpha1.TempoStack) error {
 	storageConfig, err := r.getStorageConfig(ctx, tempo)
 	if err != nil {
+		r.invalidStorageConfig(tempo, err.Error())
 		return &status.ConfigurationError{
 			Reason:  v1alpha1.ReasonInvalidStorageConfig,
 			Message: err.Error(),
 		}
 	}
 
+	err = r.checkStorage(log, tempo, storageConfig)
+	if err != nil {
+		return err
+	}
+
+	remoteWriteParams, err := r.getRemoteWriteParams(ctx, tempo)
+	if err != nil {
+		return &status.ConfigurationError{
+			Reason:  v1alpha1.ReasonInvalidMetricsGeneratorConfig,
+			Message: err.Error(),
+		}
+	}
+
 	if err = v1alpha1.ValidateTenantConfigs(tempo); err != nil {
 		return &status.ConfigurationError{
 			Message: fmt.Sprintf("Invalid tenants configuration: %s", err),
@@ -124,11 +133,16 @@ func (r *TempoStackReconciler) createOrUpdate(ctx context.Context, log logr.Logg
 
 	}
 
+	err = r.expandIngesterVolumes(ctx, log, tempo)
+	if err != nil {
+		return err
+	}
+
 	// Collect all objects owned by the operator, to be able to prune objects
 	// which exist in the cluster but are not managed by the operator anymore.
 	// For example, when the Jaeger Query Ingress is enabled and later disabled,
 	// the Ingress object should be removed from the cluster.
-	pruneObjects, err := r.findObjectsOwnedByTempoOperator(ctx, tempo)
+	pruneObjects, err := findObjectsOwnedByTempoOperator(ctx, r.Client, r.CtrlConfig.Gates, &tempo, manifestutils.CommonLabels(tempo.Name))
 	if err != nil {
 		return err
 	}
@@ -157,130 +171,29 @@ func (r *TempoStackReconciler) createOrUpdate(ctx context.Context, log logr.Logg
 		TLSProfile:          tlsProfile,
 		GatewayTenantSecret: tenantSecrets,
 		GatewayTenantsData:  gatewayTenantsData,
+		RemoteWrite:         remoteWriteParams,
 	})
 	// TODO (pavolloffay) check error type and change return appropriately
 	if err != nil {
 		return fmt.Errorf("error building manifests: %w", err)
 	}
 
-	errs := []error{}
-	for _, obj := range managedObjects {
-		l := log.WithValues(
-			"object_name", obj.GetName(),
-			"object_kind", obj.GetObjectKind(),
-		)
-
-		if isNamespaceScoped(obj) {
-			obj.SetNamespace(req.Namespace)
-			if err := ctrl.SetControllerReference(&tempo, obj, r.Scheme); err != nil {
-				l.Error(err, "failed to set controller owner reference to resource")
-				errs = append(errs, err)
-				continue
-			}
-		}
-
-		desired := obj.DeepCopyObject().(client.Object)
-		mutateFn := manifests.MutateFuncFor(obj, desired)
-
-		op, err := ctrl.CreateOrUpdate(ctx, r.Client, obj, mutateFn)
-		if err != nil {
-			l.Error(err, "failed to configure resource")
-			errs = append(errs, err)
-			continue
-		}
-
-		l.V(1).Info(fmt.Sprintf("resource has been %s", op))
-
-		// This object is still managed by the operator, remove it from the list of objects to prune
-		delete(pruneObjects, obj.GetUID())
+	// A pending or failed scale-down keeps the removed ingesters running,
+	// therefore the managed objects are reconciled before the scale-down status is returned.
+	scaleDownErr := r.scaleDownIngesters(ctx, log, tempo, managedObjects)
+	var pendingErr *status.PendingError
+	var failedErr *status.FailedError
+	if scaleDownErr != nil && !errors.As(scaleDownErr, &pendingErr) && !errors.As(scaleDownErr, &failedErr) {
+		return scaleDownErr
 	}
 
-	if len(errs) > 0 {
-		return fmt.Errorf("failed to create objects for TempoStack %s: %w", req.NamespacedName, errors.Join(errs...))
-	}
-
-	// Prune owned objects in the cluster which are not managed anymore.
-	pruneErrs := []error{}
-	for _, obj := range pruneObjects {
-		l := log.WithValues(
-			"object_name", obj.GetName(),
-			"object_kind", obj.GetObjectKind(),
-		)
-		l.Info("pruning unmanaged resource")
-
-		err = r.Delete(ctx, obj)
-		if err != nil {
-			l.Error(err, "failed to delete resource")
-			pruneErrs = append(pruneErrs, err)
-		}
-	}
-	if len(pruneErrs) > 0 {
-		return fmt.Errorf("failed to prune objects of TempoStack %s: %w", req.NamespacedName, errors.Join(pruneErrs...))
-	}
-
-	return nil
-}
-
-func (r *TempoStackReconciler) findObjectsOwnedByTempoOperator(ctx context.Context, tempo v1alpha1.TempoStack) (map[types.UID]client.Object, error) {
-	ownedObjects := map[types.UID]client.Object{}
-	listOps := &client.ListOptions{
-		Namespace:     tempo.GetNamespace(),
-		LabelSelector: labels.SelectorFromSet(manifestutils.CommonLabels(tempo.Name)),
-	}
-
-	// Add all resources where the operator can conditionally create an object.
-	// For example, Ingress and Route can be enabled or disabled in the CR.
-
-	ingressList := &networkingv1.IngressList{}
-	err := r.List(ctx, ingressList, listOps)
+	err = reconcileManagedObjects(ctx, log, r.Client, &tempo, r.Scheme, managedObjects, pruneObjects)
 	if err != nil {
-		return nil, fmt.Errorf("error listing ingress: %w", err)
-	}
-	for i := range ingressList.Items {
-		ownedObjects[ingressList.Items[i].GetUID()] = &ingressList.Items[i]
-	}
-
-	if r.CtrlConfig.Gates.PrometheusOperator {
-		servicemonitorList := &monitoringv1.ServiceMonitorList{}
-		err := r.List(ctx, servicemonitorList, listOps)
-		if err != nil {
-			return nil, fmt.Errorf("error listing service monitors: %w", err)
-		}
-		for i := range servicemonitorList.Items {
-			ownedObjects[servicemonitorList.Items[i].GetUID()] = servicemonitorList.Items[i]
-		}
-
-		prometheusRulesList := &monitoringv1.PrometheusRuleList{}
-		err = r.List(ctx, prometheusRulesList, listOps)
-		if err != nil {
-			return nil, fmt.Errorf("error listing prometheus rules: %w", err)
-		}
-		for i := range prometheusRulesList.Items {
-			ownedObjects[prometheusRulesList.Items[i].GetUID()] = prometheusRulesList.Items[i]
-		}
-	}
-
-	if r.CtrlConfig.Gates.OpenShift.OpenShiftRoute {
-		routesList := &routev1.RouteList{}
-		err := r.List(ctx, routesList, listOps)
-		if err != nil {
-			return nil, fmt.Errorf("error listing routes: %w", err)
-		}
-		for i := range routesList.Items {
-			ownedObjects[routesList.Items[i].GetUID()] = &routesList.Items[i]
-		}
+		return err
 	}
 
-	if r.CtrlConfig.Gates.GrafanaOperator {
-		datasourceList := &grafanav1.GrafanaDatasourceList{}
-		err := r.List(ctx, datasourceList, listOps)
-		if err != nil {
-			return nil, fmt.Errorf("error listing datasources: %w", err)
-		}
-		for i := range datasourceList.Items {
-			ownedObjects[datasourceList.Items[i].GetUID()] = &datasourceList.Items[i]
-		}
+	if scaleDownErr != nil {
+		return scaleDownErr
 	}
-
-	return ownedObjects, nil
+	return r.checkIngesterVolumes(ctx, tempo)
 }
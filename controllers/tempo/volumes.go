@@ -0,0 +1,237 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/status"
+)
+
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;patch;delete
+// +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch
+
+// expandIngesterVolumes expands the persistent volume claims of the ingesters if spec.storageSize was increased.
+//
+// The volume claim templates of a StatefulSet are immutable. Therefore the existing claims are patched
+// with the new size, and the StatefulSet is deleted with the orphan propagation policy, i.e. the pods keep running.
+// The StatefulSet is recreated with the new volume claim template once it is removed from the cluster,
+// and adopts the running pods.
+func (r *TempoStackReconciler) expandIngesterVolumes(ctx context.Context, log logr.Logger, tempo v1alpha1.TempoStack) error {
+	statefulSets, err := r.getIngesterStatefulSets(ctx, tempo)
+	if err != nil {
+		return err
+	}
+
+	expanded := false
+	for i := range statefulSets {
+		sts := &statefulSets[i]
+		if !sts.DeletionTimestamp.IsZero() {
+			return &status.PendingError{
+				Reason:  v1alpha1.ReasonVolumeExpansionInProgress,
+				Message: "Waiting for the ingester StatefulSet to be recreated",
+			}
+		}
+
+		ok, err := r.expandStatefulSetVolumes(ctx, log, tempo, sts)
+		if err != nil {
+			return err
+		}
+		expanded = expanded || ok
+	}
+
+	if !expanded {
+		return nil
+	}
+	return &status.PendingError{
+		Reason:  v1alpha1.ReasonVolumeExpansionInProgress,
+		Message: fmt.Sprintf("Expanding the ingester volumes to %s", tempo.Spec.StorageSize.String()),
+	}
+}
+
+// expandStatefulSetVolumes expands the persistent volume claims of an ingester StatefulSet
+// and returns true if the StatefulSet was deleted to update its volume claim template.
+func (r *TempoStackReconciler) expandStatefulSetVolumes(ctx context.Context, log logr.Logger, tempo v1alpha1.TempoStack, sts *appsv1.StatefulSet) (bool, error) {
+	if len(sts.Spec.VolumeClaimTemplates) == 0 {
+		return false, nil
+	}
+	template := sts.Spec.VolumeClaimTemplates[0]
+	current := template.Spec.Resources.Requests[corev1.ResourceStorage]
+	desired := tempo.Spec.StorageSize
+
+	switch desired.Cmp(current) {
+	case 0:
+		return false, nil
+	case -1:
+		return false, &status.ConfigurationError{
+			Reason:  v1alpha1.ReasonVolumeExpansionFailed,
+			Message: fmt.Sprintf("The storage size cannot be decreased from %s to %s", current.String(), desired.String()),
+		}
+	}
+
+	claims, err := r.getVolumeClaims(ctx, sts, template.Name)
+	if err != nil {
+		return false, err
+	}
+
+	// Verify that all claims can be expanded before modifying any of them.
+	storageClasses := map[string]bool{}
+	for _, claim := range claims {
+		className := ptr.Deref(claim.Spec.StorageClassName, "")
+		allowed, ok := storageClasses[className]
+		if !ok {
+			allowed, err = r.allowsVolumeExpansion(ctx, className)
+			if err != nil {
+				return false, err
+			}
+			storageClasses[className] = allowed
+		}
+		if !allowed {
+			return false, &status.ConfigurationError{
+				Reason:  v1alpha1.ReasonVolumeExpansionFailed,
+				Message: fmt.Sprintf("The StorageClass %q of the PersistentVolumeClaim %s does not allow volume expansion", className, claim.Name),
+			}
+		}
+	}
+
+	for i := range claims {
+		claim := &claims[i]
+		requested := claim.Spec.Resources.Requests[corev1.ResourceStorage]
+		if requested.Cmp(desired) >= 0 {
+			continue
+		}
+
+		patch := client.MergeFrom(claim.DeepCopy())
+		if claim.Spec.Resources.Requests == nil {
+			claim.Spec.Resources.Requests = corev1.ResourceList{}
+		}
+		claim.Spec.Resources.Requests[corev1.ResourceStorage] = desired
+		if err := r.Patch(ctx, claim, patch); err != nil {
+			return false, fmt.Errorf("could not expand persistent volume claim %s: %w", claim.Name, err)
+		}
+		log.Info("expanding persistent volume claim", "name", claim.Name, "from", requested.String(), "to", desired.String())
+	}
+
+	err = r.Delete(ctx, sts, client.PropagationPolicy(metav1.DeletePropagationOrphan))
+	if err != nil && !apierrors.IsNotFound(err) {
+		return false, fmt.Errorf("could not delete ingester statefulset: %w", err)
+	}
+	log.Info("deleted ingester statefulset to update the volume claim template, the pods are orphaned", "name", sts.Name)
+	return true, nil
+}
+
+// checkIngesterVolumes returns a PendingError if the expansion of some ingester volumes is not complete yet.
+func (r *TempoStackReconciler) checkIngesterVolumes(ctx context.Context, tempo v1alpha1.TempoStack) error {
+	statefulSets, err := r.getIngesterStatefulSets(ctx, tempo)
+	if err != nil {
+		return err
+	}
+
+	total := 0
+	resizing := 0
+	for i := range statefulSets {
+		sts := &statefulSets[i]
+		if len(sts.Spec.VolumeClaimTemplates) == 0 {
+			continue
+		}
+
+		claims, err := r.getVolumeClaims(ctx, sts, sts.Spec.VolumeClaimTemplates[0].Name)
+		if err != nil {
+			return err
+		}
+		total += len(claims)
+		for _, claim := range claims {
+			if isVolumeClaimResizing(claim) {
+				resizing++
+			}
+		}
+	}
+	if resizing == 0 {
+		return nil
+	}
+
+	return &status.PendingError{
+		Reason: v1alpha1.ReasonVolumeExpansionInProgress,
+		Message: fmt.Sprintf("Expanding %d of %d ingester volumes to %s",
+			resizing, total, tempo.Spec.StorageSize.String()),
+	}
+}
+
+// getIngesterStatefulSets returns the ingester StatefulSets, i.e. one StatefulSet per zone
+// if zone-aware replication is enabled.
+func (r *TempoStackReconciler) getIngesterStatefulSets(ctx context.Context, tempo v1alpha1.TempoStack) ([]appsv1.StatefulSet, error) {
+	list := &appsv1.StatefulSetList{}
+	opts := []client.ListOption{
+		client.InNamespace(tempo.Namespace),
+		client.MatchingLabels(manifestutils.ComponentLabels(manifestutils.IngesterComponentName, tempo.Name)),
+	}
+	if err := r.List(ctx, list, opts...); err != nil {
+		return nil, fmt.Errorf("could not list ingester statefulsets: %w", err)
+	}
+	return list.Items, nil
+}
+
+// getVolumeClaims returns the persistent volume claims created from a volume claim template of a StatefulSet.
+// The claims are named <template name>-<statefulset name>-<ordinal> and labeled with the selector of the StatefulSet.
+func (r *TempoStackReconciler) getVolumeClaims(ctx context.Context, sts *appsv1.StatefulSet, templateName string) ([]corev1.PersistentVolumeClaim, error) {
+	list := &corev1.PersistentVolumeClaimList{}
+	opts := []client.ListOption{
+		client.InNamespace(sts.Namespace),
+	}
+	if sts.Spec.Selector != nil {
+		opts = append(opts, client.MatchingLabels(sts.Spec.Selector.MatchLabels))
+	}
+	if err := r.List(ctx, list, opts...); err != nil {
+		return nil, fmt.Errorf("could not list persistent volume claims: %w", err)
+	}
+
+	prefix := fmt.Sprintf("%s-%s-", templateName, sts.Name)
+	claims := []corev1.PersistentVolumeClaim{}
+	for _, claim := range list.Items {
+		if strings.HasPrefix(claim.Name, prefix) {
+			claims = append(claims, claim)
+		}
+	}
+	return claims, nil
+}
+
+// allowsVolumeExpansion checks if the StorageClass allows the expansion of volumes.
+func (r *TempoStackReconciler) allowsVolumeExpansion(ctx context.Context, className string) (bool, error) {
+	if className == "" {
+		return false, nil
+	}
+
+	storageClass := &storagev1.StorageClass{}
+	err := r.Get(ctx, types.NamespacedName{Name: className}, storageClass)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("could not fetch storage class: %w", err)
+	}
+
+	return storageClass.AllowVolumeExpansion != nil && *storageClass.AllowVolumeExpansion, nil
+}
+
+// isVolumeClaimResizing checks if the capacity of a bound claim is less than its requested size.
+func isVolumeClaimResizing(claim corev1.PersistentVolumeClaim) bool {
+	if claim.Status.Phase != corev1.ClaimBound {
+		return false
+	}
+
+	requested := claim.Spec.Resources.Requests[corev1.ResourceStorage]
+	capacity := claim.Status.Capacity[corev1.ResourceStorage]
+	return capacity.Cmp(requested) < 0
+}
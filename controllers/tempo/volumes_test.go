@@ -0,0 +1,200 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/version"
+)
+
+func createVolumeClaim(t *testing.T, nsn types.NamespacedName, storageClassName string, size string) *corev1.PersistentVolumeClaim {
+	claim := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "data-tempo-" + nsn.Name + "-ingester-0",
+			Namespace: nsn.Namespace,
+			Labels:    manifestutils.ComponentLabels(manifestutils.IngesterComponentName, nsn.Name),
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			StorageClassName: ptr.To(storageClassName),
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse(size),
+				},
+			},
+		},
+	}
+	err := k8sClient.Create(context.Background(), claim)
+	require.NoError(t, err)
+	return claim
+}
+
+func createStorageClass(t *testing.T, name string, allowVolumeExpansion bool) {
+	storageClass := &storagev1.StorageClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Provisioner:          "kubernetes.io/no-provisioner",
+		AllowVolumeExpansion: ptr.To(allowVolumeExpansion),
+	}
+	err := k8sClient.Create(context.Background(), storageClass)
+	require.NoError(t, err)
+}
+
+func setStorageSize(t *testing.T, nsn types.NamespacedName, size string) {
+	tempo := v1alpha1.TempoStack{}
+	err := k8sClient.Get(context.Background(), nsn, &tempo)
+	require.NoError(t, err)
+	tempo.Spec.StorageSize = resource.MustParse(size)
+	err = k8sClient.Update(context.Background(), &tempo)
+	require.NoError(t, err)
+}
+
+func TestIngesterVolumeExpansion(t *testing.T) {
+	nsn := types.NamespacedName{Name: "volume-expansion-test", Namespace: "default"}
+	storageSecret := createSecret(t, nsn)
+	createTempoCR(t, nsn, storageSecret)
+	setStorageSize(t, nsn, "10Gi")
+	createStorageClass(t, "expandable", true)
+
+	reconciler := TempoStackReconciler{
+		Client:   k8sClient,
+		Scheme:   testScheme,
+		Recorder: record.NewFakeRecorder(1),
+		CtrlConfig: configv1alpha1.ProjectConfig{
+			Gates: configv1alpha1.FeatureGates{
+				TLSProfile: string(configv1alpha1.TLSProfileIntermediateType),
+			},
+		},
+		Version: version.Get(),
+	}
+	req := ctrl.Request{
+		NamespacedName: nsn,
+	}
+	_, err := reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+	createVolumeClaim(t, nsn, "expandable", "10Gi")
+
+	// Increase the storage size
+	setStorageSize(t, nsn, "20Gi")
+	result, err := reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, pendingRequeueInterval, result.RequeueAfter)
+
+	// Verify that the claim got expanded
+	claim := &corev1.PersistentVolumeClaim{}
+	err = k8sClient.Get(context.Background(), types.NamespacedName{Namespace: nsn.Namespace, Name: "data-tempo-" + nsn.Name + "-ingester-0"}, claim)
+	require.NoError(t, err)
+	assert.Equal(t, resource.MustParse("20Gi"), claim.Spec.Resources.Requests[corev1.ResourceStorage])
+
+	// Verify that the StatefulSet is being deleted without deleting its pods
+	sts := &appsv1.StatefulSet{}
+	err = k8sClient.Get(context.Background(), types.NamespacedName{Namespace: nsn.Namespace, Name: "tempo-" + nsn.Name + "-ingester"}, sts)
+	require.NoError(t, err)
+	assert.NotNil(t, sts.DeletionTimestamp)
+	assert.Contains(t, sts.Finalizers, metav1.FinalizerOrphanDependents)
+
+	// Verify status conditions: Pending=true
+	updatedTempo := v1alpha1.TempoStack{}
+	err = k8sClient.Get(context.Background(), nsn, &updatedTempo)
+	require.NoError(t, err)
+	pending := metav1.Condition{}
+	for _, condition := range updatedTempo.Status.Conditions {
+		if condition.Status == metav1.ConditionTrue {
+			pending = condition
+		}
+	}
+	assert.Equal(t, string(v1alpha1.ConditionPending), pending.Type)
+	assert.Equal(t, string(v1alpha1.ReasonVolumeExpansionInProgress), pending.Reason)
+	assert.Equal(t, "Expanding the ingester volumes to 20Gi", pending.Message)
+}
+
+func TestIngesterVolumeExpansionNotSupported(t *testing.T) {
+	nsn := types.NamespacedName{Name: "volume-expansion-unsupported-test", Namespace: "default"}
+	storageSecret := createSecret(t, nsn)
+	createTempoCR(t, nsn, storageSecret)
+	setStorageSize(t, nsn, "10Gi")
+	createStorageClass(t, "not-expandable", false)
+
+	reconciler := TempoStackReconciler{
+		Client:   k8sClient,
+		Scheme:   testScheme,
+		Recorder: record.NewFakeRecorder(1),
+		CtrlConfig: configv1alpha1.ProjectConfig{
+			Gates: configv1alpha1.FeatureGates{
+				TLSProfile: string(configv1alpha1.TLSProfileIntermediateType),
+			},
+		},
+		Version: version.Get(),
+	}
+	req := ctrl.Request{
+		NamespacedName: nsn,
+	}
+	_, err := reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+	createVolumeClaim(t, nsn, "not-expandable", "10Gi")
+
+	// Increase the storage size
+	setStorageSize(t, nsn, "20Gi")
+	_, err = reconciler.Reconcile(context.Background(), req)
+	require.ErrorContains(t, err, "terminal error")
+
+	// Verify that the claim was not modified
+	claim := &corev1.PersistentVolumeClaim{}
+	err = k8sClient.Get(context.Background(), types.NamespacedName{Namespace: nsn.Namespace, Name: "data-tempo-" + nsn.Name + "-ingester-0"}, claim)
+	require.NoError(t, err)
+	assert.Equal(t, resource.MustParse("10Gi"), claim.Spec.Resources.Requests[corev1.ResourceStorage])
+
+	// Verify status conditions: ConfigurationError=true
+	updatedTempo := v1alpha1.TempoStack{}
+	err = k8sClient.Get(context.Background(), nsn, &updatedTempo)
+	require.NoError(t, err)
+	configurationError := metav1.Condition{}
+	for _, condition := range updatedTempo.Status.Conditions {
+		if condition.Status == metav1.ConditionTrue {
+			configurationError = condition
+		}
+	}
+	assert.Equal(t, string(v1alpha1.ConditionConfigurationError), configurationError.Type)
+	assert.Equal(t, string(v1alpha1.ReasonVolumeExpansionFailed), configurationError.Reason)
+	assert.Equal(t, "The StorageClass \"not-expandable\" of the PersistentVolumeClaim data-tempo-volume-expansion-unsupported-test-ingester-0 does not allow volume expansion", configurationError.Message)
+}
+
+func TestIsVolumeClaimResizing(t *testing.T) {
+	claim := corev1.PersistentVolumeClaim{
+		Spec: corev1.PersistentVolumeClaimSpec{
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("20Gi"),
+				},
+			},
+		},
+	}
+	assert.False(t, isVolumeClaimResizing(claim))
+
+	claim.Status = corev1.PersistentVolumeClaimStatus{
+		Phase: corev1.ClaimBound,
+		Capacity: corev1.ResourceList{
+			corev1.ResourceStorage: resource.MustParse("10Gi"),
+		},
+	}
+	assert.True(t, isVolumeClaimResizing(claim))
+
+	claim.Status.Capacity[corev1.ResourceStorage] = resource.MustParse("20Gi")
+	assert.False(t, isVolumeClaimResizing(claim))
+}
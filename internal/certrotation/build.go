@@ -8,6 +8,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
 )
 
 var defaultUserInfo = &user.DefaultInfo{Name: "system:tempostacks", Groups: []string{"system:logging"}}
@@ -55,6 +57,8 @@ func ApplyDefaultSettings(opts *Options, cfg configv1alpha1.BuiltInCertManagemen
 	if opts.Certificates == nil {
 		opts.Certificates = make(map[string]SelfSignedCertKey)
 	}
+	gatewayService := naming.Name(manifestutils.GatewayComponentName, opts.StackName)
+	gossipService := naming.Name(manifestutils.GossipComponentName, opts.StackName)
 	for service, name := range ComponentCertSecretNames(opts.StackName) {
 		r := certificateRotation{
 			Clock:    clock,
@@ -64,6 +68,13 @@ func ApplyDefaultSettings(opts *Options, cfg configv1alpha1.BuiltInCertManagemen
 				fmt.Sprintf("%s.%s.svc", service, opts.StackNamespace),
 			},
 		}
+		// The members of the gossip ring present their certificate to each other for memberlist TLS.
+		if service != gatewayService {
+			r.Hostnames = append(r.Hostnames,
+				fmt.Sprintf("%s.%s.svc.cluster.local", gossipService, opts.StackNamespace),
+				fmt.Sprintf("%s.%s.svc", gossipService, opts.StackNamespace),
+			)
+		}
 
 		cert, ok := opts.Certificates[name]
 		if !ok {
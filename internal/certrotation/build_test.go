@@ -11,6 +11,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
 )
 
 func TestBuildAll(t *testing.T) {
@@ -34,7 +36,7 @@ func TestBuildAll(t *testing.T) {
 
 	objs, err := BuildAll(opts)
 	require.NoError(t, err)
-	require.Len(t, objs, 8)
+	require.Len(t, objs, 9)
 
 	for _, obj := range objs {
 		objectName := obj.GetName()
@@ -83,6 +85,13 @@ func TestApplyDefaultSettings_EmptySecrets(t *testing.T) {
 			fmt.Sprintf("%s.%s.svc.cluster.local", service, opts.StackNamespace),
 			fmt.Sprintf("%s.%s.svc", service, opts.StackNamespace),
 		}
+		if service != naming.Name(manifestutils.GatewayComponentName, opts.StackName) {
+			gossipService := naming.Name(manifestutils.GossipComponentName, opts.StackName)
+			hostnames = append(hostnames,
+				fmt.Sprintf("%s.%s.svc.cluster.local", gossipService, opts.StackNamespace),
+				fmt.Sprintf("%s.%s.svc", gossipService, opts.StackNamespace),
+			)
+		}
 
 		require.ElementsMatch(t, hostnames, cert.Rotation.Hostnames)
 		require.Equal(t, defaultUserInfo, cert.Rotation.UserInfo)
@@ -143,6 +152,13 @@ func TestApplyDefaultSettings_ExistingSecrets(t *testing.T) {
 			fmt.Sprintf("%s.%s.svc.cluster.local", service, opts.StackNamespace),
 			fmt.Sprintf("%s.%s.svc", service, opts.StackNamespace),
 		}
+		if service != naming.Name(manifestutils.GatewayComponentName, opts.StackName) {
+			gossipService := naming.Name(manifestutils.GossipComponentName, opts.StackName)
+			hostnames = append(hostnames,
+				fmt.Sprintf("%s.%s.svc.cluster.local", gossipService, opts.StackNamespace),
+				fmt.Sprintf("%s.%s.svc", gossipService, opts.StackNamespace),
+			)
+		}
 
 		require.ElementsMatch(t, hostnames, cert.Rotation.Hostnames)
 		require.Equal(t, defaultUserInfo, cert.Rotation.UserInfo)
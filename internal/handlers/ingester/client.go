@@ -0,0 +1,169 @@
+package ingester
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/certrotation"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+)
+
+const (
+	requestTimeout = 30 * time.Second
+
+	// RingStateActive is the state of an ingester which receives writes.
+	RingStateActive = "ACTIVE"
+)
+
+// Client calls the HTTP API of the ingesters and the distributors of a TempoStack.
+type Client struct {
+	httpClient      *http.Client
+	scheme          string
+	port            int
+	distributorHost string
+}
+
+// RingInstance is an instance of the ingester ring.
+type RingInstance struct {
+	ID    string `json:"id"`
+	State string `json:"state"`
+}
+
+type ringResponse struct {
+	Shards []RingInstance `json:"shards"`
+}
+
+// NewClient creates a client for the HTTP API of the ingesters.
+// If HTTP encryption is enabled, the client authenticates with the certificate of the ingester component.
+func NewClient(ctx context.Context, k8sclient client.Client, tempo v1alpha1.TempoStack, gates configv1alpha1.FeatureGates) (*Client, error) {
+	c := &Client{
+		httpClient: &http.Client{
+			Timeout: requestTimeout,
+		},
+		scheme:          "http",
+		port:            manifestutils.PortHTTPServer,
+		distributorHost: net.JoinHostPort(naming.ServiceFqdn(tempo.Namespace, tempo.Name, manifestutils.DistributorComponentName), strconv.Itoa(manifestutils.PortHTTPServer)),
+	}
+
+	if gates.HTTPEncryption {
+		tlsConfig, err := clientTLSConfig(ctx, k8sclient, tempo)
+		if err != nil {
+			return nil, err
+		}
+		c.scheme = "https"
+		c.httpClient.Transport = &http.Transport{TLSClientConfig: tlsConfig}
+	}
+
+	return c, nil
+}
+
+func clientTLSConfig(ctx context.Context, k8sclient client.Client, tempo v1alpha1.TempoStack) (*tls.Config, error) {
+	certSecret := &corev1.Secret{}
+	err := k8sclient.Get(ctx, types.NamespacedName{Namespace: tempo.Namespace, Name: naming.TLSSecretName(manifestutils.IngesterComponentName, tempo.Name)}, certSecret)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch ingester certificate: %w", err)
+	}
+	cert, err := tls.X509KeyPair(certSecret.Data[corev1.TLSCertKey], certSecret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return nil, fmt.Errorf("invalid ingester certificate: %w", err)
+	}
+
+	caBundle := &corev1.ConfigMap{}
+	err = k8sclient.Get(ctx, types.NamespacedName{Namespace: tempo.Namespace, Name: naming.SigningCABundleName(tempo.Name)}, caBundle)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch CA bundle: %w", err)
+	}
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM([]byte(caBundle.Data[certrotation.CAFile])) {
+		return nil, fmt.Errorf("invalid CA bundle")
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      caPool,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// FlushAndShutdown flushes the traces of an ingester to the object storage, and
+// removes the ingester from the ring.
+func (c *Client) FlushAndShutdown(ctx context.Context, tempo v1alpha1.TempoStack, pod corev1.Pod) error {
+	if pod.Status.PodIP == "" {
+		return fmt.Errorf("pod %s has no IP address", pod.Name)
+	}
+
+	host := net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(c.port))
+	serverName := naming.ServiceFqdn(tempo.Namespace, tempo.Name, manifestutils.IngesterComponentName)
+	for _, path := range []string{"/flush", "/shutdown"} {
+		_, err := c.do(ctx, http.MethodPost, host, serverName, path)
+		if err != nil {
+			return fmt.Errorf("%s of ingester %s failed: %w", path, pod.Name, err)
+		}
+	}
+	return nil
+}
+
+// RingInstances returns the instances of the ingester ring by their ID, i.e. the pod name.
+func (c *Client) RingInstances(ctx context.Context) (map[string]RingInstance, error) {
+	body, err := c.do(ctx, http.MethodGet, c.distributorHost, "", "/ingester/ring")
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch the ingester ring: %w", err)
+	}
+
+	ring := ringResponse{}
+	if err := json.Unmarshal(body, &ring); err != nil {
+		return nil, fmt.Errorf("could not parse the ingester ring: %w", err)
+	}
+
+	instances := map[string]RingInstance{}
+	for _, instance := range ring.Shards {
+		instances[instance.ID] = instance
+	}
+	return instances, nil
+}
+
+func (c *Client) do(ctx context.Context, method string, host string, serverName string, path string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s://%s%s", c.scheme, host, path), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	httpClient := c.httpClient
+	if transport, ok := httpClient.Transport.(*http.Transport); ok && transport.TLSClientConfig != nil && serverName != "" {
+		// The pods are called by their IP address, therefore the certificate is verified against the service name.
+		transport = transport.Clone()
+		transport.TLSClientConfig.ServerName = serverName
+		httpClient = &http.Client{Timeout: httpClient.Timeout, Transport: transport}
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+	}
+	return body, nil
+}
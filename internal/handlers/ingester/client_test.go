@@ -0,0 +1,89 @@
+package ingester
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+func newTestClient(t *testing.T, server *httptest.Server) (*Client, string) {
+	host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+	portNumber, err := strconv.Atoi(port)
+	require.NoError(t, err)
+
+	return &Client{
+		httpClient:      server.Client(),
+		scheme:          "http",
+		port:            portNumber,
+		distributorHost: server.Listener.Addr().String(),
+	}, host
+}
+
+func TestFlushAndShutdown(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		paths = append(paths, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, host := newTestClient(t, server)
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "tempo-simplest-ingester-1"},
+		Status:     corev1.PodStatus{PodIP: host},
+	}
+	err := client.FlushAndShutdown(context.Background(), v1alpha1.TempoStack{}, pod)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/flush", "/shutdown"}, paths)
+}
+
+func TestFlushAndShutdownError(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		http.Error(w, "flush failed", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client, host := newTestClient(t, server)
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "tempo-simplest-ingester-1"},
+		Status:     corev1.PodStatus{PodIP: host},
+	}
+	err := client.FlushAndShutdown(context.Background(), v1alpha1.TempoStack{}, pod)
+	require.ErrorContains(t, err, "/flush of ingester tempo-simplest-ingester-1 failed: unexpected status code 500")
+	assert.Equal(t, []string{"/flush"}, paths)
+
+	pod.Status.PodIP = ""
+	err = client.FlushAndShutdown(context.Background(), v1alpha1.TempoStack{}, pod)
+	require.EqualError(t, err, "pod tempo-simplest-ingester-1 has no IP address")
+}
+
+func TestRingInstances(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/ingester/ring", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Accept"))
+		_, _ = w.Write([]byte(`{"shards":[{"id":"tempo-simplest-ingester-0","state":"ACTIVE"},{"id":"tempo-simplest-ingester-1","state":"LEAVING"}],"now":"2023-01-01T00:00:00Z"}`))
+	}))
+	defer server.Close()
+
+	client, _ := newTestClient(t, server)
+	instances, err := client.RingInstances(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]RingInstance{
+		"tempo-simplest-ingester-0": {ID: "tempo-simplest-ingester-0", State: RingStateActive},
+		"tempo-simplest-ingester-1": {ID: "tempo-simplest-ingester-1", State: "LEAVING"},
+	}, instances)
+}
@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+// azureDefaultEndpointSuffix is the endpoint suffix of the public Azure cloud.
+const azureDefaultEndpointSuffix = "blob.core.windows.net"
+
+func checkAzure(ctx context.Context, azure *manifestutils.AzureStorage, storageSecret corev1.Secret, transport *http.Transport) error {
+	accountName := string(storageSecret.Data["account_name"])
+	credential, err := azblob.NewSharedKeyCredential(accountName, string(storageSecret.Data["account_key"]))
+	if err != nil {
+		return fmt.Errorf("invalid Azure storage account key: %w", err)
+	}
+
+	client, err := azblob.NewClientWithSharedKeyCredential(azureServiceURL(accountName, azure.EndpointSuffix), credential, &azblob.ClientOptions{
+		ClientOptions: azcore.ClientOptions{
+			Transport: &http.Client{Transport: transport},
+			// Report errors immediately, the check is repeated by the operator.
+			Retry: policy.RetryOptions{MaxRetries: -1},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("could not create Azure storage client: %w", err)
+	}
+
+	pager := client.NewListBlobsFlatPager(azure.Container, &azblob.ListBlobsFlatOptions{
+		Prefix:     ptr.To(listPrefix(azure.Prefix)),
+		MaxResults: ptr.To(int32(1)),
+	})
+	_, err = pager.NextPage(ctx)
+	if err != nil {
+		return fmt.Errorf("could not list container %s: %w", azure.Container, azureError(err))
+	}
+
+	blobName := markerPath(azure.Prefix)
+	_, err = client.UploadBuffer(ctx, azure.Container, blobName, markerContent, nil)
+	if err != nil {
+		return fmt.Errorf("could not write blob %s to container %s: %w", blobName, azure.Container, azureError(err))
+	}
+
+	_, err = client.DeleteBlob(ctx, azure.Container, blobName, nil)
+	if err != nil {
+		return fmt.Errorf("could not delete blob %s from container %s: %w", blobName, azure.Container, azureError(err))
+	}
+	return nil
+}
+
+// azureServiceURL returns the URL of the blob service of the storage account, in the same way as Tempo:
+// endpoint suffixes which do not start with "blob." are emulators (e.g. Azurite), which expect the account in the path.
+func azureServiceURL(accountName string, endpointSuffix string) string {
+	if endpointSuffix == "" {
+		endpointSuffix = azureDefaultEndpointSuffix
+	}
+	if !strings.HasPrefix(endpointSuffix, "blob.") {
+		return fmt.Sprintf("http://%s/%s", endpointSuffix, accountName)
+	}
+	return fmt.Sprintf("https://%s.%s", accountName, endpointSuffix)
+}
+
+// azureError shortens the errors of the Azure SDK, which include the full HTTP response.
+func azureError(err error) error {
+	var responseErr *azcore.ResponseError
+	if errors.As(err, &responseErr) {
+		return fmt.Errorf("%s (status code %d)", responseErr.ErrorCode, responseErr.StatusCode)
+	}
+	return err
+}
@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+const azureEmptyListResponse = `<?xml version="1.0" encoding="utf-8"?>
+<EnumerationResults ContainerName="container"><Prefix>tenant-a/</Prefix><MaxResults>1</MaxResults><Blobs /><NextMarker /></EnumerationResults>`
+
+var azureStorage = v1alpha1.ObjectStorageSpec{
+	Secret: v1alpha1.ObjectStorageSecretSpec{
+		Name: "storage-secret",
+		Type: v1alpha1.ObjectStorageSecretAzure,
+	},
+}
+
+var azureSecret = corev1.Secret{
+	Data: map[string][]byte{
+		"account_name": []byte("account"),
+		"account_key":  []byte(base64.StdEncoding.EncodeToString([]byte("key"))),
+	},
+}
+
+func azureParams(server *httptest.Server) manifestutils.StorageParams {
+	return manifestutils.StorageParams{
+		AzureStorage: &manifestutils.AzureStorage{
+			Container:      "container",
+			Prefix:         "tenant-a",
+			EndpointSuffix: strings.TrimPrefix(server.URL, "http://"),
+		},
+	}
+}
+
+func TestCheckAzure(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		assert.Contains(t, r.Header.Get("Authorization"), "SharedKey account:")
+		switch r.Method {
+		case http.MethodGet:
+			assert.Equal(t, "tenant-a/", r.URL.Query().Get("prefix"))
+			_, _ = w.Write([]byte(azureEmptyListResponse))
+		case http.MethodPut:
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	defer server.Close()
+
+	err := Check(context.Background(), azureStorage, azureParams(server), azureSecret, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"GET /account/container",
+		"PUT /account/container/tenant-a/tempo-operator-storage-check",
+		"DELETE /account/container/tenant-a/tempo-operator-storage-check",
+	}, requests)
+}
+
+func TestCheckAzureAuthenticationFailed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("x-ms-error-code", "AuthenticationFailed")
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	err := Check(context.Background(), azureStorage, azureParams(server), azureSecret, nil)
+	require.EqualError(t, err, "could not list container container: AuthenticationFailed (status code 403)")
+}
+
+func TestAzureServiceURL(t *testing.T) {
+	assert.Equal(t, "https://account.blob.core.windows.net", azureServiceURL("account", ""))
+	assert.Equal(t, "https://account.blob.core.usgovcloudapi.net", azureServiceURL("account", "blob.core.usgovcloudapi.net"))
+	assert.Equal(t, "http://azurite:10000/account", azureServiceURL("account", "azurite:10000"))
+}
@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+const (
+	checkTimeout = 30 * time.Second
+
+	// markerObject is written to the bucket and deleted again by the storage check.
+	// Tempo only considers the "directories" below the prefix as tenants, therefore the marker
+	// object does not interfere with Tempo, even if it cannot be deleted.
+	markerObject = "tempo-operator-storage-check"
+)
+
+var markerContent = []byte("This object is written by the Tempo Operator to check the access to the object storage.\n")
+
+// ErrNotSupported is returned if the object storage cannot be checked by the operator.
+// In the token credential mode, the short-lived credentials are only available to the Tempo pods.
+var ErrNotSupported = errors.New("the storage check is not supported in the token credential mode")
+
+// Check verifies that the object storage is reachable with the credentials of the storage secret and the CA
+// of the storage CA ConfigMap: it lists the objects below the prefix, and writes and deletes a marker object.
+func Check(ctx context.Context, storage v1alpha1.ObjectStorageSpec, params manifestutils.StorageParams, storageSecret corev1.Secret, caConfigMap *corev1.ConfigMap) error {
+	if storage.CredentialMode == v1alpha1.CredentialModeToken {
+		return ErrNotSupported
+	}
+
+	transport, err := newTransport(caConfigMap)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	switch storage.Secret.Type {
+	case v1alpha1.ObjectStorageSecretS3:
+		return checkS3(ctx, params.S3, storageSecret, transport)
+	case v1alpha1.ObjectStorageSecretAzure:
+		return checkAzure(ctx, params.AzureStorage, storageSecret, transport)
+	case v1alpha1.ObjectStorageSecretGCS:
+		return checkGCS(ctx, params.GCS, storageSecret, transport)
+	default:
+		return fmt.Errorf("unsupported storage type %q", storage.Secret.Type)
+	}
+}
+
+// newTransport returns a HTTP transport which trusts the system certificates and the CA of the storage CA ConfigMap.
+func newTransport(caConfigMap *corev1.ConfigMap) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if caConfigMap == nil {
+		return transport, nil
+	}
+
+	rootCAs, err := x509.SystemCertPool()
+	if err != nil {
+		rootCAs = x509.NewCertPool()
+	}
+	if !rootCAs.AppendCertsFromPEM([]byte(caConfigMap.Data["ca.crt"])) {
+		return nil, fmt.Errorf("invalid CA certificate in ConfigMap %s", caConfigMap.Name)
+	}
+	transport.TLSClientConfig = &tls.Config{
+		RootCAs:    rootCAs,
+		MinVersion: tls.VersionTLS12,
+	}
+	return transport, nil
+}
+
+// listPrefix returns the prefix of the objects listed by the storage check.
+func listPrefix(prefix string) string {
+	if prefix == "" {
+		return ""
+	}
+	return prefix + "/"
+}
+
+// markerPath returns the path of the marker object below the prefix.
+func markerPath(prefix string) string {
+	return listPrefix(prefix) + markerObject
+}
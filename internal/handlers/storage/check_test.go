@@ -0,0 +1,40 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func TestCheckTokenMode(t *testing.T) {
+	err := Check(context.Background(), v1alpha1.ObjectStorageSpec{
+		Secret: v1alpha1.ObjectStorageSecretSpec{
+			Name: "storage-secret",
+			Type: v1alpha1.ObjectStorageSecretS3,
+		},
+		CredentialMode: v1alpha1.CredentialModeToken,
+	}, manifestutils.StorageParams{S3: &manifestutils.S3{Bucket: "bucket"}}, corev1.Secret{}, nil)
+	require.ErrorIs(t, err, ErrNotSupported)
+}
+
+func TestNewTransportInvalidCA(t *testing.T) {
+	_, err := newTransport(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "storage-ca"},
+		Data:       map[string]string{"ca.crt": "invalid"},
+	})
+	require.EqualError(t, err, "invalid CA certificate in ConfigMap storage-ca")
+}
+
+func TestMarkerPath(t *testing.T) {
+	assert.Equal(t, "tempo-operator-storage-check", markerPath(""))
+	assert.Equal(t, "tenant-a/tempo-operator-storage-check", markerPath("tenant-a"))
+	assert.Equal(t, "", listPrefix(""))
+	assert.Equal(t, "tenant-a/", listPrefix("tenant-a"))
+}
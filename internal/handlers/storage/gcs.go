@@ -0,0 +1,101 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+const (
+	// gcsDefaultEndpoint is the endpoint of the Google Cloud Storage JSON API.
+	gcsDefaultEndpoint = "https://storage.googleapis.com/storage/v1/"
+	gcsReadWriteScope  = "https://www.googleapis.com/auth/devstorage.read_write"
+)
+
+type gcsErrorResponse struct {
+	Error struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func checkGCS(ctx context.Context, gcs *manifestutils.GCS, storageSecret corev1.Secret, transport *http.Transport) error {
+	// The token of the service account is requested with the same transport.
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: transport})
+	credentials, err := google.CredentialsFromJSON(ctx, storageSecret.Data["key.json"], gcsReadWriteScope)
+	if err != nil {
+		return fmt.Errorf("invalid GCS credentials: %w", err)
+	}
+	client := oauth2.NewClient(ctx, credentials.TokenSource)
+
+	endpoint := gcs.Endpoint
+	if endpoint == "" {
+		endpoint = gcsDefaultEndpoint
+	}
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid GCS endpoint: %w", err)
+	}
+	objectsURL := fmt.Sprintf("%s/b/%s/o", strings.TrimSuffix(endpoint, "/"), url.PathEscape(gcs.Bucket))
+	// Uploads use a separate path of the same host, like in the Google Cloud SDK.
+	uploadURL := endpointURL.ResolveReference(&url.URL{Path: fmt.Sprintf("/upload/storage/v1/b/%s/o", gcs.Bucket)}).String()
+	objectName := markerPath(gcs.Prefix)
+
+	query := url.Values{"prefix": {listPrefix(gcs.Prefix)}, "maxResults": {"1"}}
+	err = gcsRequest(ctx, client, http.MethodGet, objectsURL+"?"+query.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("could not list bucket %s: %w", gcs.Bucket, err)
+	}
+
+	query = url.Values{"uploadType": {"media"}, "name": {objectName}}
+	err = gcsRequest(ctx, client, http.MethodPost, uploadURL+"?"+query.Encode(), markerContent)
+	if err != nil {
+		return fmt.Errorf("could not write object %s to bucket %s: %w", objectName, gcs.Bucket, err)
+	}
+
+	err = gcsRequest(ctx, client, http.MethodDelete, objectsURL+"/"+url.PathEscape(objectName), nil)
+	if err != nil {
+		return fmt.Errorf("could not delete object %s from bucket %s: %w", objectName, gcs.Bucket, err)
+	}
+	return nil
+}
+
+func gcsRequest(ctx context.Context, client *http.Client, method string, url string, body []byte) error {
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "text/plain")
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		errorResponse := gcsErrorResponse{}
+		respBody, _ := io.ReadAll(resp.Body)
+		if err := json.Unmarshal(respBody, &errorResponse); err != nil || errorResponse.Error.Message == "" {
+			return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+		}
+		return fmt.Errorf("%s (status code %d)", errorResponse.Error.Message, resp.StatusCode)
+	}
+	return nil
+}
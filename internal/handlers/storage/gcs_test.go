@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+var gcsStorage = v1alpha1.ObjectStorageSpec{
+	Secret: v1alpha1.ObjectStorageSecretSpec{
+		Name: "storage-secret",
+		Type: v1alpha1.ObjectStorageSecretGCS,
+	},
+}
+
+func gcsSecret(t *testing.T, tokenURI string) corev1.Secret {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyJSON, err := json.Marshal(map[string]string{
+		"type":         "service_account",
+		"client_email": "tempo@project.iam.gserviceaccount.com",
+		"private_key":  string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})),
+		"token_uri":    tokenURI,
+	})
+	require.NoError(t, err)
+	return corev1.Secret{Data: map[string][]byte{"key.json": keyJSON}}
+}
+
+func gcsParams(server *httptest.Server) manifestutils.StorageParams {
+	return manifestutils.StorageParams{
+		GCS: &manifestutils.GCS{
+			Bucket:   "bucket",
+			Prefix:   "tenant-a",
+			Endpoint: server.URL + "/storage/v1/",
+		},
+	}
+}
+
+func TestCheckGCS(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			_, _ = w.Write([]byte(`{"access_token":"token","token_type":"Bearer","expires_in":3600}`))
+			return
+		}
+
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath())
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		switch r.Method {
+		case http.MethodGet:
+			assert.Equal(t, "tenant-a/", r.URL.Query().Get("prefix"))
+			_, _ = w.Write([]byte(`{"kind":"storage#objects"}`))
+		case http.MethodPost:
+			assert.Equal(t, "tenant-a/tempo-operator-storage-check", r.URL.Query().Get("name"))
+			_, _ = w.Write([]byte(`{"kind":"storage#object"}`))
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	err := Check(context.Background(), gcsStorage, gcsParams(server), gcsSecret(t, server.URL+"/token"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"GET /storage/v1/b/bucket/o",
+		"POST /upload/storage/v1/b/bucket/o",
+		"DELETE /storage/v1/b/bucket/o/tenant-a%2Ftempo-operator-storage-check",
+	}, requests)
+}
+
+func TestCheckGCSForbidden(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			_, _ = w.Write([]byte(`{"access_token":"token","token_type":"Bearer","expires_in":3600}`))
+			return
+		}
+
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error":{"code":403,"message":"tempo@project.iam.gserviceaccount.com does not have storage.objects.list access"}}`))
+	}))
+	defer server.Close()
+
+	err := Check(context.Background(), gcsStorage, gcsParams(server), gcsSecret(t, server.URL+"/token"), nil)
+	require.EqualError(t, err, "could not list bucket bucket: tempo@project.iam.gserviceaccount.com does not have storage.objects.list access (status code 403)")
+}
+
+func TestCheckGCSInvalidCredentials(t *testing.T) {
+	err := Check(context.Background(), gcsStorage, manifestutils.StorageParams{GCS: &manifestutils.GCS{Bucket: "bucket"}}, corev1.Secret{
+		Data: map[string][]byte{"key.json": []byte("invalid")},
+	}, nil)
+	require.ErrorContains(t, err, "invalid GCS credentials")
+}
@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func checkS3(ctx context.Context, s3 *manifestutils.S3, storageSecret corev1.Secret, transport *http.Transport) error {
+	bucketLookup := minio.BucketLookupAuto
+	if s3.ForcePathStyle {
+		bucketLookup = minio.BucketLookupPath
+	}
+
+	client, err := minio.New(s3.Endpoint, &minio.Options{
+		Creds:        credentials.NewStaticV4(string(storageSecret.Data["access_key_id"]), string(storageSecret.Data["access_key_secret"]), ""),
+		Secure:       !s3.Insecure,
+		Region:       s3.Region,
+		BucketLookup: bucketLookup,
+		Transport:    transport,
+	})
+	if err != nil {
+		return fmt.Errorf("could not create S3 client: %w", err)
+	}
+
+	listCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	for object := range client.ListObjects(listCtx, s3.Bucket, minio.ListObjectsOptions{Prefix: listPrefix(s3.Prefix), MaxKeys: 1}) {
+		if object.Err != nil {
+			return fmt.Errorf("could not list bucket %s: %w", s3.Bucket, object.Err)
+		}
+		break
+	}
+
+	key := markerPath(s3.Prefix)
+	_, err = client.PutObject(ctx, s3.Bucket, key, bytes.NewReader(markerContent), int64(len(markerContent)), minio.PutObjectOptions{})
+	if err != nil {
+		return fmt.Errorf("could not write object %s to bucket %s: %w", key, s3.Bucket, err)
+	}
+
+	err = client.RemoveObject(ctx, s3.Bucket, key, minio.RemoveObjectOptions{})
+	if err != nil {
+		return fmt.Errorf("could not delete object %s from bucket %s: %w", key, s3.Bucket, err)
+	}
+	return nil
+}
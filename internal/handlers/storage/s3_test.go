@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+const s3EmptyListResponse = `<?xml version="1.0" encoding="UTF-8"?>
+<ListBucketResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Name>bucket</Name><Prefix>tenant-a/</Prefix><KeyCount>0</KeyCount><MaxKeys>1</MaxKeys><IsTruncated>false</IsTruncated></ListBucketResult>`
+
+const s3AccessDeniedResponse = `<?xml version="1.0" encoding="UTF-8"?>
+<Error><Code>AccessDenied</Code><Message>Access Denied</Message></Error>`
+
+var s3Storage = v1alpha1.ObjectStorageSpec{
+	Secret: v1alpha1.ObjectStorageSecretSpec{
+		Name: "storage-secret",
+		Type: v1alpha1.ObjectStorageSecretS3,
+	},
+}
+
+var s3Secret = corev1.Secret{
+	Data: map[string][]byte{
+		"access_key_id":     []byte("id"),
+		"access_key_secret": []byte("secret"),
+	},
+}
+
+func s3Params(server *httptest.Server) manifestutils.StorageParams {
+	return manifestutils.StorageParams{
+		S3: &manifestutils.S3{
+			Endpoint:       strings.TrimPrefix(server.URL, "http://"),
+			Bucket:         "bucket",
+			Prefix:         "tenant-a",
+			Insecure:       true,
+			Region:         "us-east-1",
+			ForcePathStyle: true,
+		},
+	}
+}
+
+func TestCheckS3(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method {
+		case http.MethodGet:
+			assert.Equal(t, "tenant-a/", r.URL.Query().Get("prefix"))
+			_, _ = w.Write([]byte(s3EmptyListResponse))
+		case http.MethodPut:
+			w.Header().Set("ETag", `"etag"`)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	err := Check(context.Background(), s3Storage, s3Params(server), s3Secret, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"GET /bucket/",
+		"PUT /bucket/tenant-a/tempo-operator-storage-check",
+		"DELETE /bucket/tenant-a/tempo-operator-storage-check",
+	}, requests)
+}
+
+func TestCheckS3AccessDenied(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(s3AccessDeniedResponse))
+	}))
+	defer server.Close()
+
+	err := Check(context.Background(), s3Storage, s3Params(server), s3Secret, nil)
+	require.EqualError(t, err, "could not list bucket bucket: Access Denied")
+}
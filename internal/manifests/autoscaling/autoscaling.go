@@ -0,0 +1,124 @@
+package autoscaling
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+)
+
+const (
+	// ingesterScaleDownStabilizationSeconds is the time window the autoscaler considers
+	// before removing ingesters. It is longer than the max block duration of the ingester,
+	// to avoid flapping the ring and flushing incomplete blocks.
+	ingesterScaleDownStabilizationSeconds = 900
+	// ingesterScaleDownPeriodSeconds allows removing at most one ingester per period,
+	// which gives the leaving ingester time to flush its data and leave the ring.
+	ingesterScaleDownPeriodSeconds = 600
+)
+
+// BuildAutoscalers creates the HorizontalPodAutoscalers of all components with enabled autoscaling.
+func BuildAutoscalers(params manifestutils.Params) []client.Object {
+	tempo := params.Tempo
+	deploymentKind := "Deployment"
+	statefulSetKind := "StatefulSet"
+
+	components := []struct {
+		name string
+		kind string
+		spec v1alpha1.TempoComponentSpec
+	}{
+		{manifestutils.DistributorComponentName, deploymentKind, tempo.Spec.Template.Distributor.TempoComponentSpec},
+		{manifestutils.IngesterComponentName, statefulSetKind, tempo.Spec.Template.Ingester.TempoComponentSpec},
+		{manifestutils.QuerierComponentName, deploymentKind, tempo.Spec.Template.Querier},
+		{manifestutils.QueryFrontendComponentName, deploymentKind, tempo.Spec.Template.QueryFrontend.TempoComponentSpec},
+		{manifestutils.CompactorComponentName, deploymentKind, tempo.Spec.Template.Compactor},
+	}
+
+	var objects []client.Object
+	for _, component := range components {
+		if !component.spec.Autoscaling.Enabled {
+			continue
+		}
+
+		hpa := horizontalPodAutoscaler(tempo, component.name, component.kind, component.spec.Autoscaling)
+		if component.name == manifestutils.IngesterComponentName {
+			hpa.Spec.Behavior = ingesterBehavior()
+		}
+		objects = append(objects, hpa)
+	}
+	return objects
+}
+
+func horizontalPodAutoscaler(tempo v1alpha1.TempoStack, component string, kind string, spec v1alpha1.AutoscalingSpec) *autoscalingv2.HorizontalPodAutoscaler {
+	labels := manifestutils.ComponentLabels(component, tempo.Name)
+
+	var metrics []autoscalingv2.MetricSpec
+	if spec.TargetCPUUtilization != nil {
+		metrics = append(metrics, resourceMetric(corev1.ResourceCPU, *spec.TargetCPUUtilization))
+	}
+	if spec.TargetMemoryUtilization != nil {
+		metrics = append(metrics, resourceMetric(corev1.ResourceMemory, *spec.TargetMemoryUtilization))
+	}
+	metrics = append(metrics, spec.Metrics...)
+
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: autoscalingv2.SchemeGroupVersion.String(),
+			Kind:       "HorizontalPodAutoscaler",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.Name(component, tempo.Name),
+			Namespace: tempo.Namespace,
+			Labels:    labels,
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: appsv1.SchemeGroupVersion.String(),
+				Kind:       kind,
+				Name:       naming.Name(component, tempo.Name),
+			},
+			MinReplicas: spec.MinReplicas,
+			MaxReplicas: spec.MaxReplicas,
+			Metrics:     metrics,
+		},
+	}
+}
+
+func resourceMetric(resource corev1.ResourceName, averageUtilization int32) autoscalingv2.MetricSpec {
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: resource,
+			Target: autoscalingv2.MetricTarget{
+				Type:               autoscalingv2.UtilizationMetricType,
+				AverageUtilization: ptr.To(averageUtilization),
+			},
+		},
+	}
+}
+
+// ingesterBehavior returns a conservative scale down behavior for the ingesters.
+// Ingesters hold the most recent traces in memory, therefore they are removed one by one,
+// giving every leaving ingester enough time to flush its data to the object storage
+// and to leave the ring before the next one is removed.
+func ingesterBehavior() *autoscalingv2.HorizontalPodAutoscalerBehavior {
+	return &autoscalingv2.HorizontalPodAutoscalerBehavior{
+		ScaleDown: &autoscalingv2.HPAScalingRules{
+			StabilizationWindowSeconds: ptr.To(int32(ingesterScaleDownStabilizationSeconds)),
+			Policies: []autoscalingv2.HPAScalingPolicy{
+				{
+					Type:          autoscalingv2.PodsScalingPolicy,
+					Value:         1,
+					PeriodSeconds: ingesterScaleDownPeriodSeconds,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,137 @@
+package autoscaling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func TestBuildAutoscalersDisabled(t *testing.T) {
+	objects := BuildAutoscalers(manifestutils.Params{Tempo: v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+	}})
+	assert.Empty(t, objects)
+}
+
+func TestBuildAutoscalers(t *testing.T) {
+	customMetric := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{
+				Name: "tempo_request_duration_seconds",
+			},
+			Target: autoscalingv2.MetricTarget{
+				Type:         autoscalingv2.AverageValueMetricType,
+				AverageValue: ptr.To(resource.MustParse("1")),
+			},
+		},
+	}
+
+	objects := BuildAutoscalers(manifestutils.Params{Tempo: v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Template: v1alpha1.TempoTemplateSpec{
+				Querier: v1alpha1.TempoComponentSpec{
+					Autoscaling: v1alpha1.AutoscalingSpec{
+						Enabled:                 true,
+						MinReplicas:             ptr.To(int32(2)),
+						MaxReplicas:             5,
+						TargetCPUUtilization:    ptr.To(int32(80)),
+						TargetMemoryUtilization: ptr.To(int32(70)),
+						Metrics:                 []autoscalingv2.MetricSpec{customMetric},
+					},
+				},
+				Ingester: v1alpha1.TempoIngesterSpec{
+					TempoComponentSpec: v1alpha1.TempoComponentSpec{
+						Autoscaling: v1alpha1.AutoscalingSpec{
+							Enabled:              true,
+							MinReplicas:          ptr.To(int32(3)),
+							MaxReplicas:          6,
+							TargetCPUUtilization: ptr.To(int32(80)),
+						},
+					},
+				},
+			},
+		},
+	}})
+	require.Len(t, objects, 2)
+
+	assert.Equal(t, &autoscalingv2.HorizontalPodAutoscaler{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "autoscaling/v2",
+			Kind:       "HorizontalPodAutoscaler",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tempo-test-querier",
+			Namespace: "project1",
+			Labels:    manifestutils.ComponentLabels("querier", "test"),
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       "tempo-test-querier",
+			},
+			MinReplicas: ptr.To(int32(2)),
+			MaxReplicas: 5,
+			Metrics: []autoscalingv2.MetricSpec{
+				{
+					Type: autoscalingv2.ResourceMetricSourceType,
+					Resource: &autoscalingv2.ResourceMetricSource{
+						Name: corev1.ResourceCPU,
+						Target: autoscalingv2.MetricTarget{
+							Type:               autoscalingv2.UtilizationMetricType,
+							AverageUtilization: ptr.To(int32(80)),
+						},
+					},
+				},
+				{
+					Type: autoscalingv2.ResourceMetricSourceType,
+					Resource: &autoscalingv2.ResourceMetricSource{
+						Name: corev1.ResourceMemory,
+						Target: autoscalingv2.MetricTarget{
+							Type:               autoscalingv2.UtilizationMetricType,
+							AverageUtilization: ptr.To(int32(70)),
+						},
+					},
+				},
+				customMetric,
+			},
+		},
+	}, objects[1])
+
+	ingester := objects[0].(*autoscalingv2.HorizontalPodAutoscaler)
+	assert.Equal(t, "tempo-test-ingester", ingester.Name)
+	assert.Equal(t, autoscalingv2.CrossVersionObjectReference{
+		APIVersion: "apps/v1",
+		Kind:       "StatefulSet",
+		Name:       "tempo-test-ingester",
+	}, ingester.Spec.ScaleTargetRef)
+	assert.Equal(t, &autoscalingv2.HorizontalPodAutoscalerBehavior{
+		ScaleDown: &autoscalingv2.HPAScalingRules{
+			StabilizationWindowSeconds: ptr.To(int32(900)),
+			Policies: []autoscalingv2.HPAScalingPolicy{
+				{
+					Type:          autoscalingv2.PodsScalingPolicy,
+					Value:         1,
+					PeriodSeconds: 600,
+				},
+			},
+		},
+	}, ingester.Spec.Behavior)
+}
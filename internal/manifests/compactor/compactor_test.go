@@ -116,6 +116,7 @@ func TestBuildCompactor(t *testing.T) {
 							Args: []string{
 								"-target=compactor",
 								"-config.file=/conf/tempo.yaml",
+								"-config.expand-env=true",
 								"-log.level=info",
 							},
 							VolumeMounts: []corev1.VolumeMount{
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"html/template"
 	"io"
+	"strconv"
 
 	"k8s.io/utils/ptr"
 
@@ -15,13 +16,27 @@ import (
 )
 
 var (
+	// tempoConfigFuncs are the functions of the tempo.yaml templates.
+	// The Tempo components expand environment variables in tempo.yaml (-config.expand-env=true),
+	// therefore user-supplied values are escaped before they are quoted.
+	tempoConfigFuncs = template.FuncMap{
+		"yamlQuote": func(s string) template.HTML {
+			return yamlQuote(envEscaper.Replace(s))
+		},
+	}
+	// overridesConfigFuncs are the functions of the per-tenant overrides template,
+	// which is not expanded by the Tempo components.
+	overridesConfigFuncs = template.FuncMap{
+		"yamlQuote": yamlQuote,
+	}
+
 	//go:embed tempo-config.yaml
 	tempoConfigYAMLTmplFile embed.FS
-	tempoConfigYAMLTmpl     = template.Must(template.ParseFS(tempoConfigYAMLTmplFile, "tempo-config.yaml"))
+	tempoConfigYAMLTmpl     = template.Must(template.New("tempo-config.yaml").Funcs(tempoConfigFuncs).ParseFS(tempoConfigYAMLTmplFile, "tempo-config.yaml"))
 
 	//go:embed tempo-overrides.yaml
 	tempoTenantsOverridesYAMLTmplFile embed.FS
-	tempoTenantsOverridesYAMLTmpl     = template.Must(template.ParseFS(tempoTenantsOverridesYAMLTmplFile, "tempo-overrides.yaml"))
+	tempoTenantsOverridesYAMLTmpl     = template.Must(template.New("tempo-overrides.yaml").Funcs(overridesConfigFuncs).ParseFS(tempoTenantsOverridesYAMLTmplFile, "tempo-overrides.yaml"))
 
 	//go:embed tempo-query.yaml
 	tempoQueryYAMLTmplFile embed.FS
@@ -42,11 +57,93 @@ func fromRateLimitSpecToRateLimitOptions(spec v1alpha1.RateLimitSpec) rateLimits
 func fromRateLimitSpecToRateLimitOptionsMap(ratemaps map[string]v1alpha1.RateLimitSpec) map[string]rateLimitsOptions {
 	result := make(map[string]rateLimitsOptions, len(ratemaps))
 	for tenant, spec := range ratemaps {
-		result[tenant] = fromRateLimitSpecToRateLimitOptions(spec)
+		opts := fromRateLimitSpecToRateLimitOptions(spec)
+		opts.MetricsGeneratorProcessors, opts.OverrideMetricsGeneratorProcessors = tenantMetricsGeneratorProcessors(spec.MetricsGenerator)
+		result[tenant] = opts
+	}
+	return result
+}
+
+func fromProcessorsToStrings(processors []v1alpha1.MetricsGeneratorProcessor) []string {
+	result := make([]string, len(processors))
+	for i, processor := range processors {
+		result[i] = string(processor)
 	}
 	return result
 }
 
+// tenantMetricsGeneratorProcessors returns the metrics-generator processors of a tenant
+// and whether they override the processors of the global configuration.
+func tenantMetricsGeneratorProcessors(spec v1alpha1.MetricsGeneratorLimitSpec) ([]string, bool) {
+	if spec.Disabled {
+		return []string{}, true
+	}
+	if len(spec.Processors) > 0 {
+		return fromProcessorsToStrings(spec.Processors), true
+	}
+	return nil, false
+}
+
+func buildCacheOptions(tempo v1alpha1.TempoStack) cacheOptions {
+	spec := tempo.Spec.Cache
+	switch spec.Backend {
+	case v1alpha1.CacheBackendMemcached:
+		addresses := spec.Endpoint
+		if spec.Managed.Enabled {
+			// The dns+ prefix resolves all A records of the headless memcached service.
+			addresses = fmt.Sprintf("dns+%s:%d",
+				naming.ServiceFqdn(tempo.Namespace, tempo.Name, manifestutils.MemcachedComponentName),
+				manifestutils.PortMemcached)
+		}
+		return cacheOptions{
+			Enabled:   true,
+			Memcached: &memcachedOptions{Addresses: addresses},
+		}
+	case v1alpha1.CacheBackendRedis:
+		return cacheOptions{
+			Enabled: true,
+			Redis:   &redisOptions{Endpoint: spec.Endpoint},
+		}
+	default:
+		return cacheOptions{}
+	}
+}
+
+func buildMetricsGeneratorOptions(params manifestutils.Params) metricsGeneratorOptions {
+	spec := params.Tempo.Spec.Template.MetricsGenerator
+	if !spec.Enabled {
+		return metricsGeneratorOptions{}
+	}
+
+	opts := metricsGeneratorOptions{
+		Enabled: true,
+		RemoteWrite: remoteWriteOptions{
+			URL: spec.RemoteWrite.URL,
+		},
+	}
+	if params.RemoteWrite.CA {
+		opts.RemoteWrite.CAFile = fmt.Sprintf("%s/%s", manifestutils.RemoteWriteSecretDir, manifestutils.RemoteWriteCAKey)
+	}
+	if params.RemoteWrite.BasicAuth {
+		// Tempo 2.3 does not support reading the username from a file,
+		// therefore the username is passed as environment variable.
+		opts.RemoteWrite.Username = fmt.Sprintf("${%s}", manifestutils.RemoteWriteUsernameEnvVar)
+		opts.RemoteWrite.PasswordFile = fmt.Sprintf("%s/%s", manifestutils.RemoteWriteSecretDir, manifestutils.RemoteWritePasswordKey)
+	}
+	if params.RemoteWrite.BearerToken {
+		opts.RemoteWrite.TokenFile = fmt.Sprintf("%s/%s", manifestutils.RemoteWriteSecretDir, manifestutils.RemoteWriteTokenKey)
+	}
+	return opts
+}
+
+// yamlQuote renders a string as double-quoted YAML scalar, which is not escaped by html/template.
+// Go escape sequences are a subset of the YAML double-quoted escape sequences.
+// All user-supplied strings are rendered with yamlQuote, because characters like '&', ':' or '#'
+// are escaped by html/template or change the meaning of a plain YAML scalar.
+func yamlQuote(s string) template.HTML {
+	return template.HTML(strconv.Quote(s)) // nolint:gosec
+}
+
 func buildQueryFrontEndConfig(params manifestutils.Params) ([]byte, error) {
 	if !params.Tempo.Spec.Template.Gateway.Enabled {
 		params.CtrlConfig.Gates.HTTPEncryption = false
@@ -68,13 +165,10 @@ func buildConfiguration(params manifestutils.Params) ([]byte, error) {
 	}
 
 	opts := options{
-		StorageType:     string(tempo.Spec.Storage.Secret.Type),
-		StorageParams:   params.StorageParams,
-		GlobalRetention: tempo.Spec.Retention.Global.Traces.Duration.String(),
-		MemberList: memberlistOptions{
-			JoinMembers: []string{naming.Name("gossip-ring", tempo.Name)},
-			EnableIPv6:  ptr.Deref(tempo.Spec.HashRing.MemberList.EnableIPv6, false),
-		},
+		StorageType:            string(tempo.Spec.Storage.Secret.Type),
+		StorageParams:          params.StorageParams,
+		GlobalRetention:        tempo.Spec.Retention.Global.Traces.Duration.String(),
+		MemberList:             fromMemberListSpecToOptions(tempo, params.CtrlConfig.Gates.GRPCEncryption),
 		QueryFrontendDiscovery: fmt.Sprintf("%s:%d", naming.Name("query-frontend-discovery", tempo.Name), manifestutils.PortGRPCServer),
 		GlobalRateLimits:       fromRateLimitSpecToRateLimitOptions(tempo.Spec.LimitSpec.Global),
 		Search:                 fromSearchSpecToOptions(tempo.Spec.SearchSpec),
@@ -85,37 +179,72 @@ func buildConfiguration(params manifestutils.Params) ([]byte, error) {
 			GRPCEncryption: params.CtrlConfig.Gates.GRPCEncryption,
 			HTTPEncryption: params.CtrlConfig.Gates.HTTPEncryption,
 		},
-		TLS:         tlsopts,
-		ReceiverTLS: buildReceiverTLSConfig(tempo),
+		TLS:              tlsopts,
+		ReceiverTLS:      buildReceiverTLSConfig(tempo.Spec.Template.Distributor.TLS),
+		MetricsGenerator: buildMetricsGeneratorOptions(params),
+		Cache:            buildCacheOptions(tempo),
+		LogFormat:        manifestutils.LogFormat(tempo.Spec.LogFormat),
+		Ingester: ingesterOptions{
+			FlushAllOnShutdown:   tempo.Spec.Template.Ingester.Autoscaling.Enabled,
+			UnregisterOnShutdown: tempo.Spec.Template.Ingester.Autoscaling.Enabled,
+			ZoneAwareReplication: tempo.Spec.Template.Ingester.ZoneAwareReplication.Enabled,
+		},
 	}
 
-	if isTenantOverridesConfigRequired(tempo.Spec.LimitSpec) {
+	if opts.MetricsGenerator.Enabled {
+		opts.GlobalRateLimits.MetricsGeneratorProcessors = fromProcessorsToStrings(tempo.Spec.Template.MetricsGenerator.Processors)
+		opts.GlobalRateLimits.OverrideMetricsGeneratorProcessors = true
+	}
+
+	if isTenantOverridesConfigRequired(tempo) {
 		opts.TenantRateLimitsPath = tenantOverridesMountPath
 	}
 
-	return renderTemplate(opts)
+	config, err := renderTemplate(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if tempo.Spec.ExtraConfig != nil {
+		return mergeExtraConfig(config, tempo.Spec.ExtraConfig.Tempo, true)
+	}
+	return config, nil
 }
 
-func isTenantOverridesConfigRequired(limitSpec v1alpha1.LimitSpec) bool {
-	return len(limitSpec.PerTenant) > 0
+func isTenantOverridesConfigRequired(tempo v1alpha1.TempoStack) bool {
+	return len(tempo.Spec.LimitSpec.PerTenant) > 0 || len(tempo.Spec.Retention.PerTenant) > 0
 }
 
 func buildTenantOverrides(tempo v1alpha1.TempoStack) ([]byte, error) {
+	overrides := fromRateLimitSpecToRateLimitOptionsMap(tempo.Spec.LimitSpec.PerTenant)
+	for tenant, retention := range tempo.Spec.Retention.PerTenant {
+		if retention.Traces.Duration == 0 {
+			continue
+		}
+
+		opts, ok := overrides[tenant]
+		if !ok {
+			opts = fromRateLimitSpecToRateLimitOptions(v1alpha1.RateLimitSpec{})
+		}
+		opts.BlockRetention = retention.Traces.Duration.String()
+		overrides[tenant] = opts
+	}
+
 	return renderTenantOverridesTemplate(tenantOptions{
-		RateLimits: fromRateLimitSpecToRateLimitOptionsMap(tempo.Spec.LimitSpec.PerTenant),
+		RateLimits: overrides,
 	})
 }
 
-func buildReceiverTLSConfig(tempo v1alpha1.TempoStack) receiverTLSOptions {
+func buildReceiverTLSConfig(spec v1alpha1.ReceiversTLSSpec) receiverTLSOptions {
 	return receiverTLSOptions{
-		Enabled:         tempo.Spec.Template.Distributor.TLS.Enabled,
-		ClientCAEnabled: tempo.Spec.Template.Distributor.TLS.CA != "",
+		Enabled:         spec.Enabled,
+		ClientCAEnabled: spec.CA != "",
 		Paths: tlsFilePaths{
 			CA:          fmt.Sprintf("%s/%s", manifestutils.CAReceiver, manifestutils.ReceiverCAKey),
 			Key:         fmt.Sprintf("%s/%s", manifestutils.TempoReceiverTLSDir(), manifestutils.ReceiverPrivateKey),
 			Certificate: fmt.Sprintf("%s/%s", manifestutils.TempoReceiverTLSDir(), manifestutils.ReceiverPublicKey),
 		},
-		MinTLSVersion: tempo.Spec.Template.Distributor.TLS.MinVersion,
+		MinTLSVersion: spec.MinVersion,
 	}
 }
 
@@ -132,8 +261,10 @@ func buildTLSConfig(params manifestutils.Params) (tlsOptions, error) {
 			Certificate: fmt.Sprintf("%s/tls.crt", manifestutils.TempoServerTLSDir()),
 		},
 		ServerNames: serverNames{
-			QueryFrontend: naming.ServiceFqdn(tempo.Namespace, tempo.Name, manifestutils.QueryFrontendComponentName),
-			Ingester:      naming.ServiceFqdn(tempo.Namespace, tempo.Name, manifestutils.IngesterComponentName),
+			QueryFrontend:    naming.ServiceFqdn(tempo.Namespace, tempo.Name, manifestutils.QueryFrontendComponentName),
+			Ingester:         naming.ServiceFqdn(tempo.Namespace, tempo.Name, manifestutils.IngesterComponentName),
+			MetricsGenerator: naming.ServiceFqdn(tempo.Namespace, tempo.Name, manifestutils.MetricsGeneratorComponentName),
+			Gossip:           naming.ServiceFqdn(tempo.Namespace, tempo.Name, manifestutils.GossipComponentName),
 		},
 		Profile: tlsProfileOptions{
 			MinTLSVersion:      params.TLSProfile.MinTLSVersion,
@@ -150,7 +281,7 @@ func buildTempoQueryConfig(params manifestutils.Params) ([]byte, error) {
 		return []byte{}, err
 	}
 
-	return renderTempoQueryTemplate(tempoQueryOptions{
+	config, err := renderTempoQueryTemplate(tempoQueryOptions{
 		TLS:      tlsopts,
 		HTTPPort: manifestutils.PortHTTPServer,
 		Gates: featureGates{
@@ -160,6 +291,14 @@ func buildTempoQueryConfig(params manifestutils.Params) ([]byte, error) {
 		TenantHeader: manifestutils.TenantHeader,
 		Gateway:      params.Tempo.Spec.Template.Gateway.Enabled,
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	if params.Tempo.Spec.ExtraConfig != nil {
+		return mergeExtraConfig(config, params.Tempo.Spec.ExtraConfig.TempoQuery, false)
+	}
+	return config, nil
 }
 
 func renderTemplate(opts options) ([]byte, error) {
@@ -213,6 +352,31 @@ func fromSearchSpecToOptions(spec v1alpha1.SearchSpec) searchOptions {
 	return options
 }
 
+// fromMemberListSpecToOptions isolates the ring of the TempoStack with a cluster label and encrypts the gossip traffic,
+// according to the step of the memberlist security switch-over.
+func fromMemberListSpecToOptions(tempo v1alpha1.TempoStack, grpcEncryption bool) memberlistOptions {
+	options := memberlistOptions{
+		JoinMembers: []string{naming.Name(manifestutils.GossipComponentName, tempo.Name)},
+		EnableIPv6:  ptr.Deref(tempo.Spec.HashRing.MemberList.EnableIPv6, false),
+	}
+	clusterLabel := fmt.Sprintf("%s.%s", tempo.Namespace, tempo.Name)
+
+	switch tempo.Spec.HashRing.MemberList.Security {
+	case v1alpha1.MemberListSecurityLegacy:
+	case v1alpha1.MemberListSecurityLabelVerificationDisabled:
+		options.ClusterLabelVerificationDisabled = true
+	case v1alpha1.MemberListSecurityClusterLabelUnverified:
+		options.ClusterLabel = clusterLabel
+		options.ClusterLabelVerificationDisabled = true
+	case v1alpha1.MemberListSecurityClusterLabel:
+		options.ClusterLabel = clusterLabel
+	default:
+		options.ClusterLabel = clusterLabel
+		options.TLS = grpcEncryption
+	}
+	return options
+}
+
 func renderTempoQueryTemplate(opts tempoQueryOptions) ([]byte, error) {
 	// Build tempo query config yaml
 	w := bytes.NewBuffer(nil)
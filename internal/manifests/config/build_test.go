@@ -1,13 +1,17 @@
 package config
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
+	"github.com/drone/envsubst"
 	openshiftconfigv1 "github.com/openshift/api/config/v1"
 	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
 
 	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
 	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
@@ -60,6 +64,8 @@ ingester:
   max_block_duration: 10m
 memberlist:
   abort_if_cluster_join_fails: false
+  cluster_label: .test
+  cluster_label_verification_disabled: false
   join_members:
     - tempo-test-gossip-ring
 multitenancy_enabled: false
@@ -181,6 +187,8 @@ ingester:
   max_block_duration: 10m
 memberlist:
   abort_if_cluster_join_fails: false
+  cluster_label: .test
+  cluster_label_verification_disabled: false
   join_members:
     - tempo-test-gossip-ring
 multitenancy_enabled: false
@@ -268,6 +276,8 @@ ingester:
   max_block_duration: 10m
 memberlist:
   abort_if_cluster_join_fails: false
+  cluster_label: .test
+  cluster_label_verification_disabled: false
   join_members:
     - tempo-test-gossip-ring
 multitenancy_enabled: false
@@ -356,6 +366,8 @@ ingester:
   max_block_duration: 10m
 memberlist:
   abort_if_cluster_join_fails: false
+  cluster_label: .test
+  cluster_label_verification_disabled: false
   join_members:
     - tempo-test-gossip-ring
 multitenancy_enabled: false
@@ -445,6 +457,8 @@ ingester:
   max_block_duration: 10m
 memberlist:
   abort_if_cluster_join_fails: false
+  cluster_label: .test
+  cluster_label_verification_disabled: false
   join_members:
     - tempo-test-gossip-ring
 multitenancy_enabled: false
@@ -534,6 +548,8 @@ ingester:
   max_block_duration: 10m
 memberlist:
   abort_if_cluster_join_fails: false
+  cluster_label: .test
+  cluster_label_verification_disabled: false
   join_members:
     - tempo-test-gossip-ring
 multitenancy_enabled: false
@@ -623,6 +639,8 @@ ingester:
   max_block_duration: 10m
 memberlist:
   abort_if_cluster_join_fails: false
+  cluster_label: .test
+  cluster_label_verification_disabled: false
   join_members:
     - tempo-test-gossip-ring
 multitenancy_enabled: false
@@ -712,6 +730,8 @@ ingester:
   max_block_duration: 10m
 memberlist:
   abort_if_cluster_join_fails: false
+  cluster_label: .test
+  cluster_label_verification_disabled: false
   join_members:
     - tempo-test-gossip-ring
 multitenancy_enabled: false
@@ -808,6 +828,8 @@ ingester:
   max_block_duration: 10m
 memberlist:
   abort_if_cluster_join_fails: false
+  cluster_label: .test
+  cluster_label_verification_disabled: false
   join_members:
     - tempo-test-gossip-ring
 multitenancy_enabled: false
@@ -912,6 +934,8 @@ ingester:
   max_block_duration: 10m
 memberlist:
   abort_if_cluster_join_fails: false
+  cluster_label: .test
+  cluster_label_verification_disabled: false
   join_members:
     - tempo-test-gossip-ring
 multitenancy_enabled: false
@@ -1021,23 +1045,135 @@ overrides:
 	require.YAMLEq(t, expectedCfg, string(cfg))
 }
 
-func TestBuildConfiguration_SearchConfig(t *testing.T) {
-	defaultResultLimit := 20
-	testCases := []struct {
-		name   string
-		expect string
-		spec   v1alpha1.SearchSpec
-	}{
-		{
-			name: "defaults",
-			spec: v1alpha1.SearchSpec{
-				DefaultResultLimit: &defaultResultLimit,
+func TestBuildTenantsOverridesMetricsGenerator(t *testing.T) {
+	expectedCfg := `
+---
+overrides:
+  "disabled":
+    metrics_generator_processors: []
+  "mytenant":
+    metrics_generator_processors:
+    - local-blocks
+  "other":
+    ingestion_burst_size_bytes: 100
+`
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			LimitSpec: v1alpha1.LimitSpec{
+				PerTenant: map[string]v1alpha1.RateLimitSpec{
+					"mytenant": {
+						MetricsGenerator: v1alpha1.MetricsGeneratorLimitSpec{
+							Processors: []v1alpha1.MetricsGeneratorProcessor{v1alpha1.MetricsGeneratorProcessorLocalBlocks},
+						},
+					},
+					"disabled": {
+						MetricsGenerator: v1alpha1.MetricsGeneratorLimitSpec{
+							Disabled: true,
+						},
+					},
+					"other": {
+						Ingestion: v1alpha1.IngestionLimitSpec{
+							IngestionBurstSizeBytes: intToPointer(100),
+						},
+					},
+				},
 			},
-			expect: `
+		},
+	}
+	cfg, err := buildTenantOverrides(tempo)
+	require.NoError(t, err)
+	require.YAMLEq(t, expectedCfg, string(cfg))
+}
+
+func TestBuildTenantsOverridesRetention(t *testing.T) {
+	expectedCfg := `
+---
+overrides:
+  "mytenant":
+    ingestion_burst_size_bytes: 100
+    block_retention: 72h0m0s
+  "other":
+    block_retention: 1h0m0s
+`
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			LimitSpec: v1alpha1.LimitSpec{
+				PerTenant: map[string]v1alpha1.RateLimitSpec{
+					"mytenant": {
+						Ingestion: v1alpha1.IngestionLimitSpec{
+							IngestionBurstSizeBytes: intToPointer(100),
+						},
+					},
+				},
+			},
+			Retention: v1alpha1.RetentionSpec{
+				PerTenant: map[string]v1alpha1.RetentionConfig{
+					"mytenant": {
+						Traces: metav1.Duration{Duration: 72 * time.Hour},
+					},
+					"other": {
+						Traces: metav1.Duration{Duration: time.Hour},
+					},
+				},
+			},
+		},
+	}
+	cfg, err := buildTenantOverrides(tempo)
+	require.NoError(t, err)
+	require.YAMLEq(t, expectedCfg, string(cfg))
+}
+
+func TestBuildConfigurationPerTenantRetention(t *testing.T) {
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "nstest",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretS3,
+					},
+				},
+				Retention: v1alpha1.RetentionSpec{
+					Global: v1alpha1.RetentionConfig{
+						Traces: metav1.Duration{Duration: 48 * time.Hour},
+					},
+					PerTenant: map[string]v1alpha1.RetentionConfig{
+						"mytenant": {
+							Traces: metav1.Duration{Duration: 72 * time.Hour},
+						},
+					},
+				},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			S3: &manifestutils.S3{},
+		},
+	})
+	require.NoError(t, err)
+
+	parsed := map[string]any{}
+	require.NoError(t, yaml.Unmarshal(cfg, &parsed))
+	require.Equal(t, map[string]any{
+		"per_tenant_override_config": "/conf/overrides.yaml",
+	}, parsed["overrides"])
+	require.Equal(t, "48h0m0s", parsed["compactor"].(map[string]any)["compaction"].(map[string]any)["block_retention"])
+}
+
+func TestBuildConfigurationMetricsGenerator(t *testing.T) {
+	expCfg := `
 ---
 compactor:
   compaction:
-    block_retention: 0s
+    block_retention: 48h0m0s
   ring:
     kvstore:
       store: memberlist
@@ -1071,11 +1207,36 @@ ingester:
       replication_factor: 1
     tokens_file_path: /var/tempo/tokens.json
   max_block_duration: 10m
+metrics_generator:
+  ring:
+    kvstore:
+      store: memberlist
+  registry:
+    external_labels:
+      source: tempo
+  storage:
+    path: /var/tempo/generator/wal
+    remote_write:
+    - url: http://prometheus:9090/api/v1/write
+      send_exemplars: true
+      tls_config:
+        ca_file: /var/run/tempo/remote-write/ca.crt
+      basic_auth:
+        username: ${REMOTE_WRITE_USERNAME}
+        password_file: /var/run/tempo/remote-write/password
+  traces_storage:
+    path: /var/tempo/generator/traces
 memberlist:
   abort_if_cluster_join_fails: false
+  cluster_label: .test
+  cluster_label_verification_disabled: false
   join_members:
     - tempo-test-gossip-ring
 multitenancy_enabled: false
+overrides:
+  metrics_generator_processors:
+  - span-metrics
+  - service-graphs
 querier:
   max_concurrent_queries: 20
   search:
@@ -1092,62 +1253,226 @@ server:
   log_format: logfmt
 storage:
   trace:
-    backend: gcs
+    backend: s3
     blocklist_poll: 5m
     cache: none
     local:
       path: /var/tempo/traces
-    gcs:
-      bucket_name: test-bucket
+    s3:
+      bucket: tempo
+      endpoint: "minio:9000"
+      insecure: true
     wal:
       path: /var/tempo/wal
 usage_report:
   reporting_enabled: false
 query_frontend:
   search:
-    default_result_limit: 20
     concurrent_jobs: 2000
     max_duration: 0s
-      `,
+`
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretS3,
+					},
+				},
+				ReplicationFactor: 1,
+				Retention: v1alpha1.RetentionSpec{
+					Global: v1alpha1.RetentionConfig{
+						Traces: metav1.Duration{Duration: 48 * time.Hour},
+					},
+				},
+				Template: v1alpha1.TempoTemplateSpec{
+					MetricsGenerator: v1alpha1.TempoMetricsGeneratorSpec{
+						Enabled: true,
+						Processors: []v1alpha1.MetricsGeneratorProcessor{
+							v1alpha1.MetricsGeneratorProcessorSpanMetrics,
+							v1alpha1.MetricsGeneratorProcessorServiceGraphs,
+						},
+						RemoteWrite: v1alpha1.MetricsGeneratorRemoteWriteSpec{
+							URL:    "http://prometheus:9090/api/v1/write",
+							Secret: "remote-write",
+						},
+					},
+				},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			S3: &manifestutils.S3{
+				Endpoint: "minio:9000",
+				Bucket:   "tempo",
+				Insecure: true,
+			},
+		},
+		RemoteWrite: manifestutils.RemoteWriteParams{
+			CA:        true,
+			BasicAuth: true,
+		},
+		TLSProfile: tlsprofile.TLSProfileOptions{
+			MinTLSVersion: string(openshiftconfigv1.VersionTLS13),
+		},
+	})
+	require.NoError(t, err)
+	require.YAMLEq(t, expCfg, string(cfg))
+	requireValidTempoConfig(t, cfg)
+}
+
+func TestBuildConfigurationMetricsGeneratorRemoteWriteURL(t *testing.T) {
+	url := "https://prometheus.example.com/api/v1/write?tenant=a+b&extra_label=source%3Dtempo"
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "nstest",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretS3,
+					},
+				},
+				Template: v1alpha1.TempoTemplateSpec{
+					MetricsGenerator: v1alpha1.TempoMetricsGeneratorSpec{
+						Enabled: true,
+						RemoteWrite: v1alpha1.MetricsGeneratorRemoteWriteSpec{
+							URL: url,
+						},
+					},
+				},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			S3: &manifestutils.S3{},
+		},
+	})
+	require.NoError(t, err)
+
+	parsed := map[string]any{}
+	require.NoError(t, yaml.Unmarshal(cfg, &parsed))
+	storage := parsed["metrics_generator"].(map[string]any)["storage"].(map[string]any)
+	remoteWrite := storage["remote_write"].([]any)
+	require.Len(t, remoteWrite, 1)
+	require.Equal(t, url, remoteWrite[0].(map[string]any)["url"])
+}
+
+func TestBuildConfigurationQuotesUserValues(t *testing.T) {
+	// Characters which are escaped by html/template or change the meaning of a plain YAML scalar.
+	value := "a&b: c #d 'e' \"f\" <g>"
+
+	tests := []struct {
+		name          string
+		cache         v1alpha1.CacheSpec
+		storageParams manifestutils.StorageParams
+		path          []string
+		expected      map[string]any
+	}{
+		{
+			name: "s3",
+			storageParams: manifestutils.StorageParams{
+				S3: &manifestutils.S3{Endpoint: value, Bucket: value, Prefix: value, Region: value},
+			},
+			path:     []string{"storage", "trace", "s3"},
+			expected: map[string]any{"endpoint": value, "bucket": value, "prefix": value, "region": value},
+		},
+		{
+			name: "gcs",
+			storageParams: manifestutils.StorageParams{
+				GCS: &manifestutils.GCS{Bucket: value, Prefix: value, Endpoint: value},
+			},
+			path:     []string{"storage", "trace", "gcs"},
+			expected: map[string]any{"bucket_name": value, "prefix": value, "endpoint": value},
+		},
+		{
+			name: "azure",
+			storageParams: manifestutils.StorageParams{
+				AzureStorage: &manifestutils.AzureStorage{Container: value, Prefix: value, EndpointSuffix: value},
+			},
+			path:     []string{"storage", "trace", "azure"},
+			expected: map[string]any{"container_name": value, "prefix": value, "endpoint_suffix": value},
+		},
+		{
+			name:          "memcached",
+			cache:         v1alpha1.CacheSpec{Backend: v1alpha1.CacheBackendMemcached, Endpoint: value},
+			storageParams: manifestutils.StorageParams{S3: &manifestutils.S3{}},
+			path:          []string{"storage", "trace", "memcached"},
+			expected:      map[string]any{"addresses": value},
+		},
+		{
+			name:          "redis",
+			cache:         v1alpha1.CacheSpec{Backend: v1alpha1.CacheBackendRedis, Endpoint: value},
+			storageParams: manifestutils.StorageParams{S3: &manifestutils.S3{}},
+			path:          []string{"storage", "trace", "redis"},
+			expected:      map[string]any{"endpoint": value},
 		},
 	}
 
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
 			cfg, err := buildConfiguration(manifestutils.Params{
 				Tempo: v1alpha1.TempoStack{
 					ObjectMeta: metav1.ObjectMeta{
-						Name: "test",
+						Name:      "test",
+						Namespace: "nstest",
 					},
 					Spec: v1alpha1.TempoStackSpec{
-						Storage: v1alpha1.ObjectStorageSpec{
-							Secret: v1alpha1.ObjectStorageSecretSpec{
-								Type: v1alpha1.ObjectStorageSecretGCS,
-							},
-						},
-						ReplicationFactor: 1,
-						SearchSpec:        tc.spec,
-					},
-				},
-				StorageParams: manifestutils.StorageParams{
-					GCS: &manifestutils.GCS{
-						Bucket: "test-bucket",
+						Cache: test.cache,
 					},
 				},
-				TLSProfile: tlsprofile.TLSProfileOptions{
-					MinTLSVersion: string(openshiftconfigv1.VersionTLS13),
-				},
+				StorageParams: test.storageParams,
 			})
 			require.NoError(t, err)
-			require.YAMLEq(t, tc.expect, string(cfg))
+
+			parsed := map[string]any{}
+			require.NoError(t, yaml.Unmarshal(cfg, &parsed))
+			section := parsed
+			for _, key := range test.path {
+				section = section[key].(map[string]any)
+			}
+			for key, expected := range test.expected {
+				require.Equal(t, expected, section[key], key)
+			}
 		})
 	}
 }
 
-func TestBuildConfiguration_ReplicationFactor(t *testing.T) {
+func TestBuildTenantOverridesQuotesTenantID(t *testing.T) {
+	tenantID := "a&b: c #d"
+	cfg, err := buildTenantOverrides(v1alpha1.TempoStack{
+		Spec: v1alpha1.TempoStackSpec{
+			LimitSpec: v1alpha1.LimitSpec{
+				PerTenant: map[string]v1alpha1.RateLimitSpec{
+					tenantID: {Ingestion: v1alpha1.IngestionLimitSpec{MaxTracesPerUser: ptr.To(1000)}},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
 
-	replcationFactor := 10
-	expect := `
+	parsed := map[string]any{}
+	require.NoError(t, yaml.Unmarshal(cfg, &parsed))
+	overrides := parsed["overrides"].(map[string]any)
+	require.Contains(t, overrides, tenantID)
+}
+
+func TestBuildConfiguration_SearchConfig(t *testing.T) {
+	defaultResultLimit := 20
+	testCases := []struct {
+		name   string
+		expect string
+		spec   v1alpha1.SearchSpec
+	}{
+		{
+			name: "defaults",
+			spec: v1alpha1.SearchSpec{
+				DefaultResultLimit: &defaultResultLimit,
+			},
+			expect: `
 ---
 compactor:
   compaction:
@@ -1182,11 +1507,13 @@ ingester:
     ring:
       kvstore:
         store: memberlist
-      replication_factor: 10
+      replication_factor: 1
     tokens_file_path: /var/tempo/tokens.json
   max_block_duration: 10m
 memberlist:
   abort_if_cluster_join_fails: false
+  cluster_label: .test
+  cluster_label_verification_disabled: false
   join_members:
     - tempo-test-gossip-ring
 multitenancy_enabled: false
@@ -1206,14 +1533,130 @@ server:
   log_format: logfmt
 storage:
   trace:
-    backend: azure
+    backend: gcs
     blocklist_poll: 5m
     cache: none
     local:
       path: /var/tempo/traces
-    azure:
-      container_name: "container-test"
-    wal:
+    gcs:
+      bucket_name: test-bucket
+    wal:
+      path: /var/tempo/wal
+usage_report:
+  reporting_enabled: false
+query_frontend:
+  search:
+    default_result_limit: 20
+    concurrent_jobs: 2000
+    max_duration: 0s
+      `,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg, err := buildConfiguration(manifestutils.Params{
+				Tempo: v1alpha1.TempoStack{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test",
+					},
+					Spec: v1alpha1.TempoStackSpec{
+						Storage: v1alpha1.ObjectStorageSpec{
+							Secret: v1alpha1.ObjectStorageSecretSpec{
+								Type: v1alpha1.ObjectStorageSecretGCS,
+							},
+						},
+						ReplicationFactor: 1,
+						SearchSpec:        tc.spec,
+					},
+				},
+				StorageParams: manifestutils.StorageParams{
+					GCS: &manifestutils.GCS{
+						Bucket: "test-bucket",
+					},
+				},
+				TLSProfile: tlsprofile.TLSProfileOptions{
+					MinTLSVersion: string(openshiftconfigv1.VersionTLS13),
+				},
+			})
+			require.NoError(t, err)
+			require.YAMLEq(t, tc.expect, string(cfg))
+		})
+	}
+}
+
+func TestBuildConfiguration_ReplicationFactor(t *testing.T) {
+
+	replcationFactor := 10
+	expect := `
+---
+compactor:
+  compaction:
+    block_retention: 0s
+  ring:
+    kvstore:
+      store: memberlist
+distributor:
+  receivers:
+    jaeger:
+      protocols:
+        thrift_http:
+          endpoint: 0.0.0.0:14268
+        thrift_binary:
+          endpoint: 0.0.0.0:6832
+        thrift_compact:
+          endpoint: 0.0.0.0:6831
+        grpc:
+          endpoint: 0.0.0.0:14250
+    zipkin:
+    otlp:
+      protocols:
+        grpc:
+          endpoint: "0.0.0.0:4317"
+        http:
+          endpoint: "0.0.0.0:4318"
+  ring:
+    kvstore:
+      store: memberlist
+ingester:
+  lifecycler:
+    ring:
+      kvstore:
+        store: memberlist
+      replication_factor: 10
+    tokens_file_path: /var/tempo/tokens.json
+  max_block_duration: 10m
+memberlist:
+  abort_if_cluster_join_fails: false
+  cluster_label: .test
+  cluster_label_verification_disabled: false
+  join_members:
+    - tempo-test-gossip-ring
+multitenancy_enabled: false
+querier:
+  max_concurrent_queries: 20
+  search:
+    external_hedge_requests_at: 8s
+    external_hedge_requests_up_to: 2
+  frontend_worker:
+    frontend_address: "tempo-test-query-frontend-discovery:9095"
+server:
+  grpc_server_max_recv_msg_size: 4194304
+  grpc_server_max_send_msg_size: 4194304
+  http_listen_port: 3200
+  http_server_read_timeout: 3m
+  http_server_write_timeout: 3m
+  log_format: logfmt
+storage:
+  trace:
+    backend: azure
+    blocklist_poll: 5m
+    cache: none
+    local:
+      path: /var/tempo/traces
+    azure:
+      container_name: "container-test"
+    wal:
       path: /var/tempo/wal
 usage_report:
   reporting_enabled: false
@@ -1291,6 +1734,8 @@ ingester:
   max_block_duration: 10m
 memberlist:
   abort_if_cluster_join_fails: false
+  cluster_label: .test
+  cluster_label_verification_disabled: false
   join_members:
     - tempo-test-gossip-ring
 multitenancy_enabled: true
@@ -1419,8 +1864,18 @@ ingester:
   max_block_duration: 10m
 memberlist:
   abort_if_cluster_join_fails: false
+  cluster_label: nstest.test
+  cluster_label_verification_disabled: false
   join_members:
     - tempo-test-gossip-ring
+  tls_enabled: true
+  tls_cert_path: /var/run/tls/server/tls.crt
+  tls_key_path: /var/run/tls/server/tls.key
+  tls_ca_path: /var/run/ca/service-ca.crt
+  tls_server_name: tempo-test-gossip-ring.nstest.svc.cluster.local
+  tls_insecure_skip_verify: false
+  tls_cipher_suites: TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384
+  tls_min_version: VersionTLS12
 multitenancy_enabled: false
 querier:
   max_concurrent_queries: 20
@@ -1540,8 +1995,17 @@ ingester:
   max_block_duration: 10m
 memberlist:
   abort_if_cluster_join_fails: false
+  cluster_label: nstest.test
+  cluster_label_verification_disabled: false
   join_members:
     - tempo-test-gossip-ring
+  tls_enabled: true
+  tls_cert_path: /var/run/tls/server/tls.crt
+  tls_key_path: /var/run/tls/server/tls.key
+  tls_ca_path: /var/run/ca/service-ca.crt
+  tls_server_name: tempo-test-gossip-ring.nstest.svc.cluster.local
+  tls_insecure_skip_verify: false
+  tls_min_version: VersionTLS13
 multitenancy_enabled: false
 querier:
   max_concurrent_queries: 20
@@ -1692,8 +2156,18 @@ ingester:
   max_block_duration: 10m
 memberlist:
   abort_if_cluster_join_fails: false
+  cluster_label: nstest.test
+  cluster_label_verification_disabled: false
   join_members:
     - tempo-test-gossip-ring
+  tls_enabled: true
+  tls_cert_path: /var/run/tls/server/tls.crt
+  tls_key_path: /var/run/tls/server/tls.key
+  tls_ca_path: /var/run/ca/service-ca.crt
+  tls_server_name: tempo-test-gossip-ring.nstest.svc.cluster.local
+  tls_insecure_skip_verify: false
+  tls_cipher_suites: TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384
+  tls_min_version: VersionTLS12
 multitenancy_enabled: false
 querier:
   max_concurrent_queries: 20
@@ -1871,6 +2345,8 @@ ingester:
   max_block_duration: 10m
 memberlist:
   abort_if_cluster_join_fails: false
+  cluster_label: .test
+  cluster_label_verification_disabled: false
   join_members:
     - tempo-test-gossip-ring
 multitenancy_enabled: false
@@ -1979,6 +2455,8 @@ ingester:
   max_block_duration: 10m
 memberlist:
   abort_if_cluster_join_fails: false
+  cluster_label: .test
+  cluster_label_verification_disabled: false
   join_members:
     - tempo-test-gossip-ring
 multitenancy_enabled: false
@@ -2093,6 +2571,8 @@ ingester:
   max_block_duration: 10m
 memberlist:
   abort_if_cluster_join_fails: false
+  cluster_label: .test
+  cluster_label_verification_disabled: false
   join_members:
     - tempo-test-gossip-ring
 multitenancy_enabled: false
@@ -2224,6 +2704,8 @@ ingester:
   max_block_duration: 10m
 memberlist:
   abort_if_cluster_join_fails: false
+  cluster_label: .test
+  cluster_label_verification_disabled: false
   join_members:
     - tempo-test-gossip-ring
 multitenancy_enabled: false
@@ -2307,6 +2789,8 @@ ingester:
   max_block_duration: 10m
 memberlist:
   abort_if_cluster_join_fails: false
+  cluster_label: .test
+  cluster_label_verification_disabled: false
   join_members:
     - tempo-test-gossip-ring
 multitenancy_enabled: false
@@ -2387,3 +2871,817 @@ query_frontend:
 	}
 
 }
+
+func TestBuildConfigurationCache(t *testing.T) {
+	tests := []struct {
+		name          string
+		cache         v1alpha1.CacheSpec
+		expectedCache string
+	}{
+		{
+			name:  "cache disabled",
+			cache: v1alpha1.CacheSpec{},
+			expectedCache: `
+cache: none
+`,
+		},
+		{
+			name: "external memcached",
+			cache: v1alpha1.CacheSpec{
+				Backend:  v1alpha1.CacheBackendMemcached,
+				Endpoint: "dns+memcached.example.svc:11211",
+			},
+			expectedCache: `
+cache: memcached
+memcached:
+  addresses: dns+memcached.example.svc:11211
+  timeout: 500ms
+search:
+  cache_control:
+    footer: true
+`,
+		},
+		{
+			name: "managed memcached",
+			cache: v1alpha1.CacheSpec{
+				Backend: v1alpha1.CacheBackendMemcached,
+				Managed: v1alpha1.ManagedCacheSpec{
+					Enabled: true,
+				},
+			},
+			expectedCache: `
+cache: memcached
+memcached:
+  addresses: dns+tempo-test-memcached.nstest.svc.cluster.local:11211
+  timeout: 500ms
+search:
+  cache_control:
+    footer: true
+`,
+		},
+		{
+			name: "external redis",
+			cache: v1alpha1.CacheSpec{
+				Backend:  v1alpha1.CacheBackendRedis,
+				Endpoint: "redis:6379",
+			},
+			expectedCache: `
+cache: redis
+redis:
+  endpoint: redis:6379
+  timeout: 500ms
+search:
+  cache_control:
+    footer: true
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg, err := buildConfiguration(manifestutils.Params{
+				Tempo: v1alpha1.TempoStack{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test",
+						Namespace: "nstest",
+					},
+					Spec: v1alpha1.TempoStackSpec{
+						Storage: v1alpha1.ObjectStorageSpec{
+							Secret: v1alpha1.ObjectStorageSecretSpec{
+								Type: v1alpha1.ObjectStorageSecretS3,
+							},
+						},
+						Cache: test.cache,
+					},
+				},
+				StorageParams: manifestutils.StorageParams{
+					S3: &manifestutils.S3{},
+				},
+			})
+			require.NoError(t, err)
+			requireValidTempoConfig(t, cfg)
+
+			parsed := map[string]any{}
+			require.NoError(t, yaml.Unmarshal(cfg, &parsed))
+			trace := parsed["storage"].(map[string]any)["trace"].(map[string]any)
+			actualCache := map[string]any{}
+			for _, key := range []string{"cache", "memcached", "redis", "search"} {
+				if value, ok := trace[key]; ok {
+					actualCache[key] = value
+				}
+			}
+			actual, err := yaml.Marshal(actualCache)
+			require.NoError(t, err)
+			require.YAMLEq(t, test.expectedCache, string(actual))
+		})
+	}
+}
+
+func TestBuildConfigurationIngesterAutoscaling(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		cfg, err := buildConfiguration(manifestutils.Params{
+			Tempo: v1alpha1.TempoStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "nstest",
+				},
+				Spec: v1alpha1.TempoStackSpec{
+					Storage: v1alpha1.ObjectStorageSpec{
+						Secret: v1alpha1.ObjectStorageSecretSpec{
+							Type: v1alpha1.ObjectStorageSecretS3,
+						},
+					},
+					Template: v1alpha1.TempoTemplateSpec{
+						Ingester: v1alpha1.TempoIngesterSpec{
+							TempoComponentSpec: v1alpha1.TempoComponentSpec{
+								Autoscaling: v1alpha1.AutoscalingSpec{
+									Enabled: enabled,
+								},
+							},
+						},
+					},
+				},
+			},
+			StorageParams: manifestutils.StorageParams{
+				S3: &manifestutils.S3{},
+			},
+		})
+		require.NoError(t, err)
+
+		parsed := map[string]any{}
+		require.NoError(t, yaml.Unmarshal(cfg, &parsed))
+		ingester := parsed["ingester"].(map[string]any)
+		lifecycler := ingester["lifecycler"].(map[string]any)
+		if enabled {
+			require.Equal(t, true, ingester["flush_all_on_shutdown"])
+			require.Equal(t, true, lifecycler["unregister_on_shutdown"])
+		} else {
+			require.NotContains(t, ingester, "flush_all_on_shutdown")
+			require.NotContains(t, lifecycler, "unregister_on_shutdown")
+		}
+	}
+}
+
+func TestBuildConfigurationS3Region(t *testing.T) {
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "nstest",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretS3,
+					},
+					CredentialMode: v1alpha1.CredentialModeToken,
+				},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			S3: &manifestutils.S3{
+				Endpoint: "s3.eu-central-1.amazonaws.com",
+				Bucket:   "tempo",
+				Region:   "eu-central-1",
+				RoleARN:  "arn:aws:iam::123456789012:role/tempo",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	parsed := map[string]any{}
+	require.NoError(t, yaml.Unmarshal(cfg, &parsed))
+	s3 := parsed["storage"].(map[string]any)["trace"].(map[string]any)["s3"].(map[string]any)
+	require.Equal(t, map[string]any{
+		"endpoint": "s3.eu-central-1.amazonaws.com",
+		"bucket":   "tempo",
+		"insecure": false,
+		"region":   "eu-central-1",
+	}, s3)
+}
+
+func TestBuildConfigurationMemberListSecurity(t *testing.T) {
+	tests := []struct {
+		security v1alpha1.MemberListSecurity
+		expected map[string]any
+	}{
+		{
+			security: v1alpha1.MemberListSecurityLegacy,
+			expected: map[string]any{},
+		},
+		{
+			security: v1alpha1.MemberListSecurityLabelVerificationDisabled,
+			expected: map[string]any{
+				"cluster_label_verification_disabled": true,
+			},
+		},
+		{
+			security: v1alpha1.MemberListSecurityClusterLabelUnverified,
+			expected: map[string]any{
+				"cluster_label":                       "nstest.test",
+				"cluster_label_verification_disabled": true,
+			},
+		},
+		{
+			security: v1alpha1.MemberListSecurityClusterLabel,
+			expected: map[string]any{
+				"cluster_label":                       "nstest.test",
+				"cluster_label_verification_disabled": false,
+			},
+		},
+		{
+			security: v1alpha1.MemberListSecuritySecure,
+			expected: map[string]any{
+				"cluster_label":                       "nstest.test",
+				"cluster_label_verification_disabled": false,
+				"tls_enabled":                         true,
+				"tls_cert_path":                       "/var/run/tls/server/tls.crt",
+				"tls_key_path":                        "/var/run/tls/server/tls.key",
+				"tls_ca_path":                         "/var/run/ca/service-ca.crt",
+				"tls_server_name":                     "tempo-test-gossip-ring.nstest.svc.cluster.local",
+				"tls_insecure_skip_verify":            false,
+				"tls_min_version":                     "VersionTLS12",
+			},
+		},
+		{
+			// defaults to Secure
+			security: "",
+			expected: map[string]any{
+				"cluster_label":                       "nstest.test",
+				"cluster_label_verification_disabled": false,
+				"tls_enabled":                         true,
+				"tls_cert_path":                       "/var/run/tls/server/tls.crt",
+				"tls_key_path":                        "/var/run/tls/server/tls.key",
+				"tls_ca_path":                         "/var/run/ca/service-ca.crt",
+				"tls_server_name":                     "tempo-test-gossip-ring.nstest.svc.cluster.local",
+				"tls_insecure_skip_verify":            false,
+				"tls_min_version":                     "VersionTLS12",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.security), func(t *testing.T) {
+			cfg, err := buildConfiguration(manifestutils.Params{
+				Tempo: v1alpha1.TempoStack{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test",
+						Namespace: "nstest",
+					},
+					Spec: v1alpha1.TempoStackSpec{
+						Storage: v1alpha1.ObjectStorageSpec{
+							Secret: v1alpha1.ObjectStorageSecretSpec{
+								Type: v1alpha1.ObjectStorageSecretS3,
+							},
+						},
+						HashRing: v1alpha1.HashRingSpec{
+							MemberList: v1alpha1.MemberListSpec{
+								Security: test.security,
+							},
+						},
+					},
+				},
+				StorageParams: manifestutils.StorageParams{
+					S3: &manifestutils.S3{
+						Endpoint: "minio:9000",
+						Bucket:   "tempo",
+					},
+				},
+				TLSProfile: tlsprofile.TLSProfileOptions{MinTLSVersion: "VersionTLS12"},
+				CtrlConfig: configv1alpha1.ProjectConfig{
+					Gates: configv1alpha1.FeatureGates{
+						GRPCEncryption: true,
+					},
+				},
+			})
+			require.NoError(t, err)
+			requireValidTempoConfig(t, cfg)
+
+			parsed := map[string]any{}
+			require.NoError(t, yaml.Unmarshal(cfg, &parsed))
+			expected := map[string]any{
+				"abort_if_cluster_join_fails": false,
+				"join_members":                []any{"tempo-test-gossip-ring"},
+			}
+			for k, v := range test.expected {
+				expected[k] = v
+			}
+			require.Equal(t, expected, parsed["memberlist"])
+		})
+	}
+}
+
+func TestBuildConfigurationAzureFederatedToken(t *testing.T) {
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "nstest",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretAzure,
+					},
+					CredentialMode: v1alpha1.CredentialModeToken,
+				},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			AzureStorage: &manifestutils.AzureStorage{
+				Container:         "tempo",
+				UseFederatedToken: true,
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	parsed := map[string]any{}
+	require.NoError(t, yaml.Unmarshal(cfg, &parsed))
+	azure := parsed["storage"].(map[string]any)["trace"].(map[string]any)["azure"].(map[string]any)
+	require.Equal(t, map[string]any{
+		"container_name":      "tempo",
+		"use_federated_token": true,
+	}, azure)
+}
+
+func TestBuildConfigurationExtraConfig(t *testing.T) {
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "nstest",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretS3,
+					},
+				},
+				ExtraConfig: &v1alpha1.ExtraConfigSpec{
+					Tempo: apiextensionsv1.JSON{Raw: []byte(`{
+						"ingester": {"max_block_duration": "30m"},
+						"storage": {"trace": {"blocklist_poll": "10m"}},
+						"server": {"http_server_read_timeout": "5m"},
+						"memberlist": {"join_members": ["a", "b"]}
+					}`)},
+				},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			S3: &manifestutils.S3{
+				Endpoint: "minio:9000",
+				Bucket:   "tempo",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	parsed := map[string]any{}
+	require.NoError(t, yaml.Unmarshal(cfg, &parsed))
+
+	ingester := parsed["ingester"].(map[string]any)
+	require.Equal(t, "30m", ingester["max_block_duration"])
+	require.Equal(t, "/var/tempo/tokens.json", ingester["lifecycler"].(map[string]any)["tokens_file_path"])
+
+	trace := parsed["storage"].(map[string]any)["trace"].(map[string]any)
+	require.Equal(t, "10m", trace["blocklist_poll"])
+	require.Equal(t, "s3", trace["backend"])
+	require.Equal(t, "tempo", trace["s3"].(map[string]any)["bucket"])
+
+	server := parsed["server"].(map[string]any)
+	require.Equal(t, "5m", server["http_server_read_timeout"])
+	require.Equal(t, float64(3200), server["http_listen_port"])
+
+	// lists are replaced
+	require.Equal(t, []any{"a", "b"}, parsed["memberlist"].(map[string]any)["join_members"])
+}
+
+func TestBuildTempoQueryConfigExtraConfig(t *testing.T) {
+	cfg, err := buildTempoQueryConfig(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "nstest",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				ExtraConfig: &v1alpha1.ExtraConfigSpec{
+					TempoQuery: apiextensionsv1.JSON{Raw: []byte(`{"tenant_header_key": "X-Tenant"}`)},
+				},
+			},
+		},
+		TLSProfile: tlsprofile.TLSProfileOptions{
+			MinTLSVersion: string(openshiftconfigv1.VersionTLS12),
+		},
+	})
+	require.NoError(t, err)
+
+	parsed := map[string]any{}
+	require.NoError(t, yaml.Unmarshal(cfg, &parsed))
+	require.Equal(t, map[string]any{
+		"backend":           "127.0.0.1:3200",
+		"tenant_header_key": "X-Tenant",
+	}, parsed)
+}
+
+func TestMergeExtraConfig(t *testing.T) {
+	config := []byte("a: 1\nb:\n  c: 2\n")
+
+	merged, err := mergeExtraConfig(config, apiextensionsv1.JSON{}, false)
+	require.NoError(t, err)
+	require.Equal(t, config, merged)
+
+	merged, err = mergeExtraConfig(config, apiextensionsv1.JSON{Raw: []byte(`{"b": {"d": 3}}`)}, false)
+	require.NoError(t, err)
+	require.Equal(t, "a: 1\nb:\n  c: 2\n  d: 3\n", string(merged))
+
+	_, err = mergeExtraConfig(config, apiextensionsv1.JSON{Raw: []byte(`[]`)}, false)
+	require.Error(t, err)
+
+	merged, err = mergeExtraConfig(config, apiextensionsv1.JSON{Raw: []byte(`{"b": {"d": "pa$$word", "e": ["${HOME}"]}}`)}, true)
+	require.NoError(t, err)
+	require.Equal(t, "a: 1\nb:\n  c: 2\n  d: pa$$$$word\n  e:\n  - $${HOME}\n", string(merged))
+}
+
+func TestBuildConfigurationExtraConfigEnvExpansion(t *testing.T) {
+	t.Setenv("HOME", "/root")
+	t.Setenv("TEMPO_AVAILABILITY_ZONE", "zone-a")
+
+	extraConfig := map[string]any{
+		"password":   "pa$$word",
+		"home":       "${HOME}",
+		"user":       "$USER",
+		"regex":      `^\d+\/\\.*$`,
+		"path":       `C:\\temp\new`,
+		"multi_line": "line1\n\tline2 \\ $",
+		"list":       []any{"${HOME}", `\/`},
+		"$key":       "value",
+	}
+	raw, err := json.Marshal(map[string]any{
+		"ingester":     map[string]any{"lifecycler": map[string]any{"id": "${HOME}"}},
+		"extra_values": extraConfig,
+	})
+	require.NoError(t, err)
+
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "nstest",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretS3,
+					},
+				},
+				Template: v1alpha1.TempoTemplateSpec{
+					Ingester: v1alpha1.TempoIngesterSpec{
+						ZoneAwareReplication: v1alpha1.ZoneAwareReplicationSpec{Enabled: true},
+					},
+				},
+				ExtraConfig: &v1alpha1.ExtraConfigSpec{
+					Tempo: apiextensionsv1.JSON{Raw: raw},
+				},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			S3: &manifestutils.S3{
+				Endpoint: "minio:9000",
+				Bucket:   "tempo",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	// The Tempo components expand the environment variables of the configuration file with -config.expand-env=true.
+	expanded, err := envsubst.EvalEnv(string(cfg))
+	require.NoError(t, err)
+
+	parsed := map[string]any{}
+	require.NoError(t, yaml.Unmarshal([]byte(expanded), &parsed))
+
+	// values of the extra configuration are used literally
+	require.Equal(t, extraConfig, parsed["extra_values"])
+	lifecycler := parsed["ingester"].(map[string]any)["lifecycler"].(map[string]any)
+	require.Equal(t, "${HOME}", lifecycler["id"])
+
+	// environment variables of the generated configuration are expanded
+	require.Equal(t, "zone-a", lifecycler["availability_zone"])
+}
+
+func TestBuildConfigurationEscapesEnvReferencesOfUserValues(t *testing.T) {
+	prefix := "traces/${HOME}/$tenant\\"
+	url := "https://prometheus.example.com/api/v1/write?token=$TOKEN"
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "nstest",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Template: v1alpha1.TempoTemplateSpec{
+					MetricsGenerator: v1alpha1.TempoMetricsGeneratorSpec{
+						Enabled: true,
+						RemoteWrite: v1alpha1.MetricsGeneratorRemoteWriteSpec{
+							URL: url,
+						},
+					},
+				},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			S3: &manifestutils.S3{
+				Endpoint: "minio:9000",
+				Bucket:   "tempo",
+				Prefix:   prefix,
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	// The Tempo components expand the environment variables of the configuration file with -config.expand-env=true.
+	expanded, err := envsubst.EvalEnv(string(cfg))
+	require.NoError(t, err)
+
+	parsed := map[string]any{}
+	require.NoError(t, yaml.Unmarshal([]byte(expanded), &parsed))
+	s3 := parsed["storage"].(map[string]any)["trace"].(map[string]any)["s3"].(map[string]any)
+	require.Equal(t, prefix, s3["prefix"])
+	remoteWrite := parsed["metrics_generator"].(map[string]any)["storage"].(map[string]any)["remote_write"].([]any)
+	require.Equal(t, url, remoteWrite[0].(map[string]any)["url"])
+}
+
+func TestBuildConfigurationAzureEndpointSuffix(t *testing.T) {
+	tests := []struct {
+		name           string
+		endpointSuffix string
+		expected       any
+	}{
+		{name: "default", expected: nil},
+		{name: "sovereign cloud", endpointSuffix: "blob.core.chinacloudapi.cn", expected: "blob.core.chinacloudapi.cn"},
+		{name: "azurite", endpointSuffix: "azurite:10000", expected: "azurite:10000"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg, err := buildConfiguration(manifestutils.Params{
+				Tempo: v1alpha1.TempoStack{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test",
+						Namespace: "nstest",
+					},
+					Spec: v1alpha1.TempoStackSpec{
+						Storage: v1alpha1.ObjectStorageSpec{
+							Secret: v1alpha1.ObjectStorageSecretSpec{
+								Type: v1alpha1.ObjectStorageSecretAzure,
+							},
+						},
+					},
+				},
+				StorageParams: manifestutils.StorageParams{
+					AzureStorage: &manifestutils.AzureStorage{
+						Container:      "tempo",
+						EndpointSuffix: test.endpointSuffix,
+					},
+				},
+			})
+			require.NoError(t, err)
+			requireValidTempoConfig(t, cfg)
+
+			parsed := map[string]any{}
+			require.NoError(t, yaml.Unmarshal(cfg, &parsed))
+			azure := parsed["storage"].(map[string]any)["trace"].(map[string]any)["azure"].(map[string]any)
+			require.Equal(t, test.expected, azure["endpoint_suffix"])
+		})
+	}
+}
+
+func TestBuildConfigurationLogFormat(t *testing.T) {
+	tests := []struct {
+		name      string
+		logFormat v1alpha1.LogFormat
+		expected  string
+	}{
+		{name: "default", expected: "logfmt"},
+		{name: "json", logFormat: v1alpha1.LogFormatJSON, expected: "json"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg, err := buildConfiguration(manifestutils.Params{
+				Tempo: v1alpha1.TempoStack{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test",
+						Namespace: "nstest",
+					},
+					Spec: v1alpha1.TempoStackSpec{
+						Storage: v1alpha1.ObjectStorageSpec{
+							Secret: v1alpha1.ObjectStorageSecretSpec{
+								Type: v1alpha1.ObjectStorageSecretS3,
+							},
+						},
+						LogFormat: test.logFormat,
+					},
+				},
+				StorageParams: manifestutils.StorageParams{
+					S3: &manifestutils.S3{
+						Endpoint: "minio:9000",
+						Bucket:   "tempo",
+					},
+				},
+			})
+			require.NoError(t, err)
+
+			parsed := map[string]any{}
+			require.NoError(t, yaml.Unmarshal(cfg, &parsed))
+			require.Equal(t, test.expected, parsed["server"].(map[string]any)["log_format"])
+		})
+	}
+}
+
+func TestBuildConfigurationZoneAwareReplication(t *testing.T) {
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "nstest",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretS3,
+					},
+				},
+				ReplicationFactor: 3,
+				Template: v1alpha1.TempoTemplateSpec{
+					Ingester: v1alpha1.TempoIngesterSpec{
+						ZoneAwareReplication: v1alpha1.ZoneAwareReplicationSpec{
+							Enabled: true,
+							Zones:   []v1alpha1.ZoneSpec{{Name: "a"}, {Name: "b"}, {Name: "c"}},
+						},
+					},
+				},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			S3: &manifestutils.S3{
+				Endpoint: "minio:9000",
+				Bucket:   "tempo",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	parsed := map[string]any{}
+	require.NoError(t, yaml.Unmarshal(cfg, &parsed))
+	lifecycler := parsed["ingester"].(map[string]any)["lifecycler"].(map[string]any)
+	require.Equal(t, "${TEMPO_AVAILABILITY_ZONE}", lifecycler["availability_zone"])
+	require.Equal(t, true, lifecycler["ring"].(map[string]any)["zone_awareness_enabled"])
+}
+
+func TestBuildConfigurationStoragePrefix(t *testing.T) {
+	tests := []struct {
+		name          string
+		storageType   v1alpha1.ObjectStorageSecretType
+		storageParams manifestutils.StorageParams
+		expected      map[string]any
+	}{
+		{
+			name:        "s3",
+			storageType: v1alpha1.ObjectStorageSecretS3,
+			storageParams: manifestutils.StorageParams{
+				S3: &manifestutils.S3{
+					Endpoint: "minio:9000",
+					Bucket:   "tempo",
+					Prefix:   "team-a",
+					Insecure: true,
+				},
+			},
+			expected: map[string]any{
+				"s3": map[string]any{
+					"endpoint": "minio:9000",
+					"bucket":   "tempo",
+					"prefix":   "team-a",
+					"insecure": true,
+				},
+			},
+		},
+		{
+			name:        "s3 with region and path-style addressing",
+			storageType: v1alpha1.ObjectStorageSecretS3,
+			storageParams: manifestutils.StorageParams{
+				S3: &manifestutils.S3{
+					Endpoint:       "minio:9000",
+					Bucket:         "tempo",
+					Insecure:       true,
+					Region:         "eu-central-1",
+					ForcePathStyle: true,
+				},
+			},
+			expected: map[string]any{
+				"s3": map[string]any{
+					"endpoint":       "minio:9000",
+					"bucket":         "tempo",
+					"insecure":       true,
+					"region":         "eu-central-1",
+					"forcepathstyle": true,
+				},
+			},
+		},
+		{
+			name:        "gcs",
+			storageType: v1alpha1.ObjectStorageSecretGCS,
+			storageParams: manifestutils.StorageParams{
+				GCS: &manifestutils.GCS{
+					Bucket: "tempo",
+					Prefix: "team-a",
+				},
+			},
+			expected: map[string]any{
+				"gcs": map[string]any{
+					"bucket_name": "tempo",
+					"prefix":      "team-a",
+				},
+			},
+		},
+		{
+			name:        "gcs with custom endpoint",
+			storageType: v1alpha1.ObjectStorageSecretGCS,
+			storageParams: manifestutils.StorageParams{
+				GCS: &manifestutils.GCS{
+					Bucket:   "tempo",
+					Endpoint: "https://fake-gcs-server:4443/storage/v1/",
+				},
+			},
+			expected: map[string]any{
+				"gcs": map[string]any{
+					"bucket_name": "tempo",
+					"endpoint":    "https://fake-gcs-server:4443/storage/v1/",
+				},
+			},
+		},
+		{
+			name:        "azure with custom endpoint",
+			storageType: v1alpha1.ObjectStorageSecretAzure,
+			storageParams: manifestutils.StorageParams{
+				AzureStorage: &manifestutils.AzureStorage{
+					Container:      "tempo",
+					EndpointSuffix: "azurite:10000",
+				},
+			},
+			expected: map[string]any{
+				"azure": map[string]any{
+					"container_name":  "tempo",
+					"endpoint_suffix": "azurite:10000",
+				},
+			},
+		},
+		{
+			name:        "azure",
+			storageType: v1alpha1.ObjectStorageSecretAzure,
+			storageParams: manifestutils.StorageParams{
+				AzureStorage: &manifestutils.AzureStorage{
+					Container: "tempo",
+					Prefix:    "team-a",
+				},
+			},
+			expected: map[string]any{
+				"azure": map[string]any{
+					"container_name": "tempo",
+					"prefix":         "team-a",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg, err := buildConfiguration(manifestutils.Params{
+				Tempo: v1alpha1.TempoStack{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test",
+						Namespace: "nstest",
+					},
+					Spec: v1alpha1.TempoStackSpec{
+						Storage: v1alpha1.ObjectStorageSpec{
+							Secret: v1alpha1.ObjectStorageSecretSpec{
+								Type: test.storageType,
+							},
+						},
+					},
+				},
+				StorageParams: test.storageParams,
+			})
+			require.NoError(t, err)
+			requireValidTempoConfig(t, cfg)
+
+			parsed := map[string]any{}
+			require.NoError(t, yaml.Unmarshal(cfg, &parsed))
+			trace := parsed["storage"].(map[string]any)["trace"].(map[string]any)
+			for key, expected := range test.expected {
+				require.Equal(t, expected, trace[key])
+			}
+		})
+	}
+}
@@ -15,6 +15,7 @@ const tenantOverridesMountPath = "/conf/overrides.yaml"
 
 // BuildConfigMap builds the tempo configuration file and the tenant-specific overrides configuration.
 // It returns a ConfigMap containing both configuration files and the checksum of the main configuration file
+// and the tempo-query configuration file, including their extra configuration
 // (the tenant-specific configuration gets reloaded automatically, therefore no checksum is required).
 func BuildConfigMap(params manifestutils.Params) (*corev1.ConfigMap, string, error) {
 	tempo := params.Tempo
@@ -46,18 +47,21 @@ func BuildConfigMap(params manifestutils.Params) (*corev1.ConfigMap, string, err
 			"overrides.yaml":            string(overridesConfig),
 		},
 	}
+	// We only need to hash the main ConfigMap and the tempo-query configuration,
+	// the per-tenant overrides is reloaded by tempo without requiring a restart
+	h := sha256.New()
+	h.Write(config)
+
 	if tempo.Spec.Template.QueryFrontend.JaegerQuery.Enabled {
 		tempoQueryConfig, err := buildTempoQueryConfig(params)
 		if err != nil {
 			return nil, "", err
 		}
 		configMap.Data["tempo-query.yaml"] = string(tempoQueryConfig)
+		h.Write(tempoQueryConfig)
 	}
 
-	// We only need to hash the main ConfigMap, the per-tenant overrides
-	// is reloaded by tempo without requiring a restart
-	h := sha256.Sum256(config)
-	checksum := fmt.Sprintf("%x", h)
+	checksum := fmt.Sprintf("%x", h.Sum(nil))
 
 	return configMap, checksum, nil
 }
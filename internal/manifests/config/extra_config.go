@@ -0,0 +1,78 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// mergeExtraConfig deep-merges the extra configuration over the rendered configuration.
+// Maps are merged recursively, all other values of the extra configuration replace the rendered values.
+// The rendered configuration is returned unchanged if no extra configuration is set.
+// If escapeEnv is set, environment variable references in the extra configuration are escaped,
+// because the Tempo components expand them in the configuration file.
+func mergeExtraConfig(config []byte, extraConfig apiextensionsv1.JSON, escapeEnv bool) ([]byte, error) {
+	if len(extraConfig.Raw) == 0 {
+		return config, nil
+	}
+
+	extra := map[string]interface{}{}
+	if err := json.Unmarshal(extraConfig.Raw, &extra); err != nil {
+		return nil, fmt.Errorf("failed to parse extra configuration: %w", err)
+	}
+	if len(extra) == 0 {
+		return config, nil
+	}
+	if escapeEnv {
+		extra = escapeEnvReferences(extra).(map[string]interface{})
+	}
+
+	rendered := map[string]interface{}{}
+	if err := yaml.Unmarshal(config, &rendered); err != nil {
+		return nil, fmt.Errorf("failed to parse rendered configuration: %w", err)
+	}
+
+	return yaml.Marshal(deepMerge(rendered, extra))
+}
+
+func deepMerge(dst, src map[string]interface{}) map[string]interface{} {
+	for key, srcValue := range src {
+		srcMap, srcIsMap := srcValue.(map[string]interface{})
+		dstMap, dstIsMap := dst[key].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			dst[key] = deepMerge(dstMap, srcMap)
+		} else {
+			dst[key] = srcValue
+		}
+	}
+	return dst
+}
+
+// envEscaper escapes the characters interpreted by the environment variable expansion of the
+// Tempo components (github.com/drone/envsubst), which expands `$$` to `$` and `\\` to `\`.
+var envEscaper = strings.NewReplacer("$", "$$", `\`, `\\`)
+
+// escapeEnvReferences escapes the keys and string values of the extra configuration,
+// so that they are used literally after the environment variable expansion of the Tempo components.
+func escapeEnvReferences(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return envEscaper.Replace(v)
+	case map[string]interface{}:
+		escaped := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			escaped[envEscaper.Replace(key)] = escapeEnvReferences(item)
+		}
+		return escaped
+	case []interface{}:
+		for i, item := range v {
+			v[i] = escapeEnvReferences(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
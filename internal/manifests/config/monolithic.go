@@ -0,0 +1,103 @@
+package config
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"embed"
+	"fmt"
+	"html/template"
+	"io"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+)
+
+var (
+	//go:embed tempo-monolithic.yaml
+	tempoMonolithicYAMLTmplFile embed.FS
+	tempoMonolithicYAMLTmpl     = template.Must(template.New("tempo-monolithic.yaml").Funcs(tempoConfigFuncs).ParseFS(tempoMonolithicYAMLTmplFile, "tempo-monolithic.yaml"))
+)
+
+// BuildMonolithicConfigMap builds the tempo configuration file and the tempo-query configuration file of a TempoMonolithic instance.
+// It returns a ConfigMap containing both configuration files and the checksum of the tempo configuration file.
+func BuildMonolithicConfigMap(tempo v1alpha1.TempoMonolithic, storageParams manifestutils.StorageParams) (*corev1.ConfigMap, string, error) {
+	config, err := buildMonolithicConfiguration(tempo, storageParams)
+	if err != nil {
+		return nil, "", err
+	}
+
+	configMap := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.Name("config", tempo.Name),
+			Namespace: tempo.Namespace,
+			Labels:    manifestutils.MonolithicComponentLabels("config", tempo.Name),
+		},
+		Data: map[string]string{
+			"tempo.yaml": string(config),
+		},
+	}
+
+	if tempo.Spec.JaegerUI != nil && tempo.Spec.JaegerUI.Enabled {
+		tempoQueryConfig, err := renderTempoQueryTemplate(tempoQueryOptions{
+			HTTPPort:     manifestutils.PortHTTPServer,
+			TenantHeader: manifestutils.TenantHeader,
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		configMap.Data["tempo-query.yaml"] = string(tempoQueryConfig)
+	}
+
+	h := sha256.Sum256(config)
+	checksum := fmt.Sprintf("%x", h)
+
+	return configMap, checksum, nil
+}
+
+func buildMonolithicConfiguration(tempo v1alpha1.TempoMonolithic, storageParams manifestutils.StorageParams) ([]byte, error) {
+	opts := monolithicOptions{
+		StorageParams: storageParams,
+		HTTPPort:      manifestutils.PortHTTPServer,
+		LogFormat:     manifestutils.LogFormat(tempo.Spec.LogFormat),
+	}
+
+	switch tempo.Spec.Storage.Traces.Backend {
+	case v1alpha1.MonolithicTracesStorageBackendMemory, v1alpha1.MonolithicTracesStorageBackendPV:
+		opts.StorageType = "local"
+	default:
+		opts.StorageType = string(tempo.Spec.Storage.Traces.Backend)
+	}
+
+	if tempo.Spec.Ingestion != nil {
+		if tempo.Spec.Ingestion.OTLP != nil {
+			opts.OTLP.GRPC = tempo.Spec.Ingestion.OTLP.GRPC != nil && tempo.Spec.Ingestion.OTLP.GRPC.Enabled
+			opts.OTLP.HTTP = tempo.Spec.Ingestion.OTLP.HTTP != nil && tempo.Spec.Ingestion.OTLP.HTTP.Enabled
+		}
+		opts.ReceiverTLS = buildReceiverTLSConfig(tempo.Spec.Ingestion.TLS)
+	}
+
+	return renderMonolithicTemplate(opts)
+}
+
+func renderMonolithicTemplate(opts monolithicOptions) ([]byte, error) {
+	// Build monolithic tempo config yaml
+	w := bytes.NewBuffer(nil)
+	err := tempoMonolithicYAMLTmpl.Execute(w, opts)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := io.ReadAll(w)
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,209 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func TestBuildMonolithicConfigMap(t *testing.T) {
+	tempo := v1alpha1.TempoMonolithic{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "sample",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.TempoMonolithicSpec{
+			Storage: v1alpha1.MonolithicStorageSpec{
+				Traces: v1alpha1.MonolithicTracesStorageSpec{
+					Backend: v1alpha1.MonolithicTracesStorageBackendMemory,
+				},
+			},
+			Ingestion: &v1alpha1.MonolithicIngestionSpec{
+				OTLP: &v1alpha1.MonolithicIngestionOTLPSpec{
+					GRPC: &v1alpha1.MonolithicIngestionOTLPProtocolSpec{
+						Enabled: true,
+					},
+				},
+			},
+		},
+	}
+
+	cm, checksum, err := BuildMonolithicConfigMap(tempo, manifestutils.StorageParams{})
+	require.NoError(t, err)
+	require.Equal(t, "tempo-sample-config", cm.Name)
+	require.Equal(t, "default", cm.Namespace)
+	require.Equal(t, map[string]string{
+		"app.kubernetes.io/name":       "tempo-monolithic",
+		"app.kubernetes.io/instance":   "sample",
+		"app.kubernetes.io/managed-by": "tempo-operator",
+		"app.kubernetes.io/component":  "config",
+	}, map[string]string(cm.Labels))
+	require.NotEmpty(t, checksum)
+	require.NotContains(t, cm.Data, "tempo-query.yaml")
+	require.YAMLEq(t, `
+server:
+  http_listen_port: 3200
+  grpc_server_max_recv_msg_size: 4194304
+  grpc_server_max_send_msg_size: 4194304
+  http_server_read_timeout: 3m
+  http_server_write_timeout: 3m
+  log_format: logfmt
+storage:
+  trace:
+    backend: local
+    local:
+      path: /var/tempo/blocks
+    wal:
+      path: /var/tempo/wal
+distributor:
+  receivers:
+    otlp:
+      protocols:
+        grpc:
+          endpoint: 0.0.0.0:4317
+usage_report:
+  reporting_enabled: false
+`, cm.Data["tempo.yaml"])
+}
+
+func TestBuildMonolithicConfiguration(t *testing.T) {
+	tests := []struct {
+		name          string
+		spec          v1alpha1.TempoMonolithicSpec
+		storageParams manifestutils.StorageParams
+		expected      string
+	}{
+		{
+			name: "no ingestion",
+			spec: v1alpha1.TempoMonolithicSpec{
+				Storage: v1alpha1.MonolithicStorageSpec{
+					Traces: v1alpha1.MonolithicTracesStorageSpec{
+						Backend: v1alpha1.MonolithicTracesStorageBackendPV,
+					},
+				},
+			},
+			expected: `
+server:
+  http_listen_port: 3200
+  grpc_server_max_recv_msg_size: 4194304
+  grpc_server_max_send_msg_size: 4194304
+  http_server_read_timeout: 3m
+  http_server_write_timeout: 3m
+  log_format: logfmt
+storage:
+  trace:
+    backend: local
+    local:
+      path: /var/tempo/blocks
+    wal:
+      path: /var/tempo/wal
+usage_report:
+  reporting_enabled: false
+`,
+		},
+		{
+			name: "S3 storage, OTLP with TLS and JSON log format",
+			spec: v1alpha1.TempoMonolithicSpec{
+				LogFormat: v1alpha1.LogFormatJSON,
+				Storage: v1alpha1.MonolithicStorageSpec{
+					Traces: v1alpha1.MonolithicTracesStorageSpec{
+						Backend: v1alpha1.MonolithicTracesStorageBackendS3,
+					},
+				},
+				Ingestion: &v1alpha1.MonolithicIngestionSpec{
+					OTLP: &v1alpha1.MonolithicIngestionOTLPSpec{
+						GRPC: &v1alpha1.MonolithicIngestionOTLPProtocolSpec{
+							Enabled: true,
+						},
+						HTTP: &v1alpha1.MonolithicIngestionOTLPProtocolSpec{
+							Enabled: true,
+						},
+					},
+					TLS: v1alpha1.ReceiversTLSSpec{
+						Enabled:    true,
+						Cert:       "cert",
+						MinVersion: "1.2",
+					},
+				},
+			},
+			storageParams: manifestutils.StorageParams{
+				S3: &manifestutils.S3{
+					Endpoint: "minio:9000",
+					Bucket:   "tempo",
+					Prefix:   "dev&prod: #$1",
+					Insecure: true,
+				},
+			},
+			expected: `
+server:
+  http_listen_port: 3200
+  grpc_server_max_recv_msg_size: 4194304
+  grpc_server_max_send_msg_size: 4194304
+  http_server_read_timeout: 3m
+  http_server_write_timeout: 3m
+  log_format: json
+storage:
+  trace:
+    backend: s3
+    s3:
+      endpoint: minio:9000
+      bucket: tempo
+      prefix: "dev&prod: #$$1"
+      insecure: true
+    local:
+      path: /var/tempo/blocks
+    wal:
+      path: /var/tempo/wal
+distributor:
+  receivers:
+    otlp:
+      protocols:
+        grpc:
+          endpoint: 0.0.0.0:4317
+          tls:
+            cert_file: /var/run/tls/receiver/tls.crt
+            key_file: /var/run/tls/receiver/tls.key
+            min_version: 1.2
+        http:
+          endpoint: 0.0.0.0:4318
+          tls:
+            cert_file: /var/run/tls/receiver/tls.crt
+            key_file: /var/run/tls/receiver/tls.key
+            min_version: 1.2
+usage_report:
+  reporting_enabled: false
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tempo := v1alpha1.TempoMonolithic{Spec: test.spec}
+			cfg, err := buildMonolithicConfiguration(tempo, test.storageParams)
+			require.NoError(t, err)
+			require.YAMLEq(t, test.expected, string(cfg))
+		})
+	}
+}
+
+func TestBuildMonolithicConfigMapJaegerUI(t *testing.T) {
+	tempo := v1alpha1.TempoMonolithic{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "sample",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.TempoMonolithicSpec{
+			JaegerUI: &v1alpha1.MonolithicJaegerUISpec{
+				Enabled: true,
+			},
+		},
+	}
+
+	cm, _, err := BuildMonolithicConfigMap(tempo, manifestutils.StorageParams{})
+	require.NoError(t, err)
+	require.Contains(t, cm.Data, "tempo-query.yaml")
+}
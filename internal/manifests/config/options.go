@@ -1,6 +1,8 @@
 package config
 
-import "github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+import (
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
 
 // options holds the configuration template options.
 type options struct {
@@ -14,10 +16,14 @@ type options struct {
 	MemberList             memberlistOptions
 	Search                 searchOptions
 	ReplicationFactor      int
+	Ingester               ingesterOptions
 	Multitenancy           bool
 	Gateway                bool
 	Gates                  featureGates
 	ReceiverTLS            receiverTLSOptions
+	MetricsGenerator       metricsGeneratorOptions
+	Cache                  cacheOptions
+	LogFormat              string
 }
 
 type tempoQueryOptions struct {
@@ -44,6 +50,57 @@ type rateLimitsOptions struct {
 	MaxTracesPerUser        *int
 	MaxBytesPerTagValues    *int
 	MaxSearchDuration       string
+	// MetricsGeneratorProcessors is only rendered if OverrideMetricsGeneratorProcessors is set,
+	// because an empty list disables all processors.
+	MetricsGeneratorProcessors         []string
+	OverrideMetricsGeneratorProcessors bool
+	// BlockRetention is only rendered in the per-tenant overrides,
+	// the global retention is configured in the compactor section.
+	BlockRetention string
+}
+
+type ingesterOptions struct {
+	// FlushAllOnShutdown flushes all traces of an ingester to the object storage when it is stopped,
+	// which is required when the number of ingesters is reduced by an autoscaler.
+	// Tempo cannot distinguish a scale-down from a restart, therefore every restart of an autoscaled ingester
+	// (e.g. a rollout or a node drain) flushes its incomplete blocks as well, which creates more and smaller
+	// blocks for the compactor.
+	FlushAllOnShutdown bool
+	// UnregisterOnShutdown removes an ingester from the ring when it is stopped,
+	// which is required when the number of ingesters is reduced by an autoscaler.
+	// A restarted ingester therefore leaves and re-joins the ring, and the distributors write its traces
+	// to the other ingesters in the meantime.
+	UnregisterOnShutdown bool
+	// ZoneAwareReplication places the replicas of a trace in different availability zones.
+	// The availability zone of an ingester is read from the TEMPO_AVAILABILITY_ZONE environment variable.
+	ZoneAwareReplication bool
+}
+
+type cacheOptions struct {
+	Enabled   bool
+	Memcached *memcachedOptions
+	Redis     *redisOptions
+}
+
+type memcachedOptions struct {
+	Addresses string
+}
+
+type redisOptions struct {
+	Endpoint string
+}
+
+type metricsGeneratorOptions struct {
+	Enabled     bool
+	RemoteWrite remoteWriteOptions
+}
+
+type remoteWriteOptions struct {
+	URL          string
+	CAFile       string
+	Username     string
+	PasswordFile string
+	TokenFile    string
 }
 
 type searchOptions struct {
@@ -66,8 +123,11 @@ type tlsOptions struct {
 }
 
 type memberlistOptions struct {
-	JoinMembers []string
-	EnableIPv6  bool
+	JoinMembers                      []string
+	EnableIPv6                       bool
+	ClusterLabel                     string
+	ClusterLabelVerificationDisabled bool
+	TLS                              bool
 }
 
 type receiverTLSOptions struct {
@@ -96,8 +156,25 @@ type tlsFilePaths struct {
 }
 
 type serverNames struct {
-	Compactor     string
-	Ingester      string
-	QueryFrontend string
-	Querier       string
+	Compactor        string
+	Ingester         string
+	QueryFrontend    string
+	Querier          string
+	MetricsGenerator string
+	Gossip           string
+}
+
+// monolithicOptions holds the configuration template options of a TempoMonolithic instance.
+type monolithicOptions struct {
+	StorageType   string
+	StorageParams manifestutils.StorageParams
+	HTTPPort      int
+	OTLP          otlpOptions
+	ReceiverTLS   receiverTLSOptions
+	LogFormat     string
+}
+
+type otlpOptions struct {
+	GRPC bool
+	HTTP bool
 }
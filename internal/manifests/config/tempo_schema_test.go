@@ -0,0 +1,157 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+// The types below mirror the configuration keys of the Tempo version deployed by the operator (2.3.0).
+// Tempo rejects unknown configuration keys at startup, therefore rendering a key of a newer Tempo version
+// makes all components crash-loop. The sections which are rendered depending on the TempoStack spec are
+// mirrored in detail, the other sections are only checked on the top level.
+// The types must be updated together with the Tempo image in config/manager/manager.yaml.
+
+type tempoConfig struct {
+	Target                       string         `yaml:"target,omitempty"`
+	AuthEnabled                  bool           `yaml:"auth_enabled,omitempty"`
+	MultitenancyEnabled          bool           `yaml:"multitenancy_enabled,omitempty"`
+	StreamOverHTTPEnabled        bool           `yaml:"stream_over_http_enabled,omitempty"`
+	HTTPAPIPrefix                string         `yaml:"http_api_prefix"`
+	UseOTelTracer                bool           `yaml:"use_otel_tracer,omitempty"`
+	EnableGoRuntimeMetrics       bool           `yaml:"enable_go_runtime_metrics,omitempty"`
+	AutocompleteFilteringEnabled bool           `yaml:"autocomplete_filtering_enabled,omitempty"`
+	Server                       map[string]any `yaml:"server,omitempty"`
+	InternalServer               map[string]any `yaml:"internal_server,omitempty"`
+	Distributor                  map[string]any `yaml:"distributor,omitempty"`
+	IngesterClient               map[string]any `yaml:"ingester_client,omitempty"`
+	GeneratorClient              map[string]any `yaml:"metrics_generator_client,omitempty"`
+	Querier                      map[string]any `yaml:"querier,omitempty"`
+	Frontend                     map[string]any `yaml:"query_frontend,omitempty"`
+	Compactor                    map[string]any `yaml:"compactor,omitempty"`
+	Ingester                     map[string]any `yaml:"ingester,omitempty"`
+	Generator                    map[string]any `yaml:"metrics_generator,omitempty"`
+	Storage                      struct {
+		Trace tempoStorageConfig `yaml:"trace"`
+	} `yaml:"storage,omitempty"`
+	Overrides   map[string]any        `yaml:"overrides,omitempty"`
+	Memberlist  tempoMemberlistConfig `yaml:"memberlist,omitempty"`
+	UsageReport map[string]any        `yaml:"usage_report,omitempty"`
+}
+
+type tempoStorageConfig struct {
+	Pool                                   map[string]any `yaml:"pool,omitempty"`
+	WAL                                    map[string]any `yaml:"wal"`
+	Block                                  map[string]any `yaml:"block"`
+	Search                                 map[string]any `yaml:"search"`
+	BlocklistPoll                          string         `yaml:"blocklist_poll"`
+	BlocklistPollConcurrency               uint           `yaml:"blocklist_poll_concurrency"`
+	BlocklistPollFallback                  bool           `yaml:"blocklist_poll_fallback"`
+	BlocklistPollTenantIndexBuilders       int            `yaml:"blocklist_poll_tenant_index_builders"`
+	BlocklistPollStaleTenantIndex          string         `yaml:"blocklist_poll_stale_tenant_index"`
+	BlocklistPollJitterMs                  int            `yaml:"blocklist_poll_jitter_ms"`
+	BlocklistPollTolerateConsecutiveErrors int            `yaml:"blocklist_poll_tolerate_consecutive_errors"`
+	Backend                                string         `yaml:"backend"`
+	Local                                  map[string]any `yaml:"local"`
+	GCS                                    *struct {
+		BucketName         string            `yaml:"bucket_name"`
+		Prefix             string            `yaml:"prefix"`
+		ChunkBufferSize    int               `yaml:"chunk_buffer_size"`
+		Endpoint           string            `yaml:"endpoint"`
+		HedgeRequestsAt    string            `yaml:"hedge_requests_at"`
+		HedgeRequestsUpTo  int               `yaml:"hedge_requests_up_to"`
+		Insecure           bool              `yaml:"insecure"`
+		ObjectCacheControl string            `yaml:"object_cache_control"`
+		ObjectMetadata     map[string]string `yaml:"object_metadata"`
+	} `yaml:"gcs"`
+	S3 *struct {
+		tempoTLSClientConfig `yaml:",inline"`
+		Bucket               string            `yaml:"bucket"`
+		Prefix               string            `yaml:"prefix"`
+		Endpoint             string            `yaml:"endpoint"`
+		Region               string            `yaml:"region"`
+		AccessKey            string            `yaml:"access_key"`
+		SecretKey            string            `yaml:"secret_key"`
+		SessionToken         string            `yaml:"session_token"`
+		Insecure             bool              `yaml:"insecure"`
+		PartSize             uint64            `yaml:"part_size"`
+		HedgeRequestsAt      string            `yaml:"hedge_requests_at"`
+		HedgeRequestsUpTo    int               `yaml:"hedge_requests_up_to"`
+		SignatureV2          bool              `yaml:"signature_v2"`
+		ForcePathStyle       bool              `yaml:"forcepathstyle"`
+		BucketLookupType     int               `yaml:"bucket_lookup_type"`
+		Tags                 map[string]string `yaml:"tags"`
+		StorageClass         string            `yaml:"storage_class"`
+		Metadata             map[string]string `yaml:"metadata"`
+		NativeAWSAuthEnabled bool              `yaml:"native_aws_auth_enabled"`
+	} `yaml:"s3"`
+	Azure *struct {
+		StorageAccountName string `yaml:"storage_account_name"`
+		StorageAccountKey  string `yaml:"storage_account_key"`
+		UseManagedIdentity bool   `yaml:"use_managed_identity"`
+		UseFederatedToken  bool   `yaml:"use_federated_token"`
+		UserAssignedID     string `yaml:"user_assigned_id"`
+		ContainerName      string `yaml:"container_name"`
+		Prefix             string `yaml:"prefix"`
+		EndpointSuffix     string `yaml:"endpoint_suffix"`
+		MaxBuffers         int    `yaml:"max_buffers"`
+		BufferSize         int    `yaml:"buffer_size"`
+		HedgeRequestsAt    string `yaml:"hedge_requests_at"`
+		HedgeRequestsUpTo  int    `yaml:"hedge_requests_up_to"`
+		UseV2SDK           bool   `yaml:"use_v2_sdk"`
+	} `yaml:"azure"`
+	Cache                   string         `yaml:"cache"`
+	CacheMinCompactionLevel uint8          `yaml:"cache_min_compaction_level"`
+	CacheMaxBlockAge        string         `yaml:"cache_max_block_age"`
+	BackgroundCache         map[string]any `yaml:"background_cache"`
+	Memcached               map[string]any `yaml:"memcached"`
+	Redis                   map[string]any `yaml:"redis"`
+}
+
+type tempoTLSClientConfig struct {
+	CertPath           string `yaml:"tls_cert_path"`
+	KeyPath            string `yaml:"tls_key_path"`
+	CAPath             string `yaml:"tls_ca_path"`
+	ServerName         string `yaml:"tls_server_name"`
+	InsecureSkipVerify bool   `yaml:"tls_insecure_skip_verify"`
+	CipherSuites       string `yaml:"tls_cipher_suites"`
+	MinVersion         string `yaml:"tls_min_version"`
+}
+
+type tempoMemberlistConfig struct {
+	tempoTLSClientConfig             `yaml:",inline"`
+	NodeName                         string   `yaml:"node_name"`
+	RandomizeNodeName                bool     `yaml:"randomize_node_name"`
+	StreamTimeout                    string   `yaml:"stream_timeout"`
+	RetransmitFactor                 int      `yaml:"retransmit_factor"`
+	PullPushInterval                 string   `yaml:"pull_push_interval"`
+	GossipInterval                   string   `yaml:"gossip_interval"`
+	GossipNodes                      int      `yaml:"gossip_nodes"`
+	GossipToTheDeadTime              string   `yaml:"gossip_to_dead_nodes_time"`
+	DeadNodeReclaimTime              string   `yaml:"dead_node_reclaim_time"`
+	EnableCompression                bool     `yaml:"compression_enabled"`
+	AdvertiseAddr                    string   `yaml:"advertise_addr"`
+	AdvertisePort                    int      `yaml:"advertise_port"`
+	ClusterLabel                     string   `yaml:"cluster_label"`
+	ClusterLabelVerificationDisabled bool     `yaml:"cluster_label_verification_disabled"`
+	JoinMembers                      []string `yaml:"join_members"`
+	MinJoinBackoff                   string   `yaml:"min_join_backoff"`
+	MaxJoinBackoff                   string   `yaml:"max_join_backoff"`
+	MaxJoinRetries                   int      `yaml:"max_join_retries"`
+	AbortIfJoinFails                 bool     `yaml:"abort_if_cluster_join_fails"`
+	RejoinInterval                   string   `yaml:"rejoin_interval"`
+	LeftIngestersTimeout             string   `yaml:"left_ingesters_timeout"`
+	LeaveTimeout                     string   `yaml:"leave_timeout"`
+	MessageHistoryBufferBytes        int      `yaml:"message_history_buffer_bytes"`
+	BindAddrs                        []string `yaml:"bind_addr"`
+	BindPort                         int      `yaml:"bind_port"`
+	PacketDialTimeout                string   `yaml:"packet_dial_timeout"`
+	PacketWriteTimeout               string   `yaml:"packet_write_timeout"`
+	TLSEnabled                       bool     `yaml:"tls_enabled"`
+}
+
+// requireValidTempoConfig verifies that the rendered configuration only contains keys of the deployed Tempo version.
+func requireValidTempoConfig(t *testing.T, config []byte) {
+	require.NoError(t, yaml.UnmarshalStrict(config, &tempoConfig{}))
+}
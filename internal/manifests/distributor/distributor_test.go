@@ -498,6 +498,7 @@ func TestBuildDistributor(t *testing.T) {
 									Args: []string{
 										"-target=distributor",
 										"-config.file=/conf/tempo.yaml",
+										"-config.expand-env=true",
 										"-log.level=info",
 									},
 									Env:          []corev1.EnvVar{},
@@ -80,10 +80,20 @@ func newOptions(tempo v1alpha1.TempoStack, baseDomain string, oidcSecrets []*man
 			Mode:           tempo.Spec.Tenants.Mode,
 			Authentication: auths,
 			Authorization:  tempo.Spec.Tenants.Authorization,
+			OPA:            externalOPA(tempo),
 		},
 	}
 }
 
+// externalOPA returns the external OPA endpoint, which authorizes the requests of all tenants in static mode.
+func externalOPA(tempo v1alpha1.TempoStack) *v1alpha1.OPASpec {
+	tenants := tempo.Spec.Tenants
+	if tenants.Mode != v1alpha1.ModeStatic || tenants.Authorization == nil {
+		return nil
+	}
+	return tenants.Authorization.OPA
+}
+
 func getTenantData(tenantName string, tenantsData []*manifestutils.GatewayTenantsData) *manifestutils.GatewayTenantsData {
 	for _, d := range tenantsData {
 		if d.TenantName == tenantName {
@@ -115,6 +125,8 @@ type tenants struct {
 
 	Authentication []authentication
 	Authorization  *v1alpha1.AuthorizationSpec
+	// OPA is the external OPA endpoint in static mode, if configured.
+	OPA *v1alpha1.OPASpec
 }
 
 type authentication struct {
@@ -211,6 +211,41 @@ func TestTenantsTemplate(t *testing.T) {
     issuerURL: http://dex.svc:30556/dex
     redirectURL: http://tempo-foo-gateway.svc:8080/oidc/test-oidc/callback
     usernameClaim: email`,
+		},
+		{
+			name: "static with external OPA",
+			opts: options{
+				Namespace: "default",
+				Name:      "foo",
+				Tenants: &tenants{
+					Mode: v1alpha1.ModeStatic,
+					Authentication: []authentication{
+						{
+							TenantName: "dev",
+							TenantID:   "abcd1",
+							OIDC: &v1alpha1.OIDCSpec{
+								IssuerURL: "https://something.com",
+							},
+							OIDCSecret: oidcSecret{
+								ClientID: "clientid",
+							},
+						},
+					},
+					OPA: &v1alpha1.OPASpec{
+						URL:             "https://opa.example.com/v1/data/tempostack/allow",
+						WithAccessToken: true,
+					},
+				},
+			},
+			expected: `tenants:
+- name: dev
+  id: abcd1
+  opa:
+    url: https://opa.example.com/v1/data/tempostack/allow
+    withAccessToken: true
+  oidc:
+    clientID: clientid
+    issuerURL: https://something.com`,
 		},
 		{
 			name: "openshift",
@@ -88,6 +88,13 @@ func BuildGateway(params manifestutils.Params) ([]client.Object, error) {
 		}
 	}
 
+	if opa := externalOPA(params.Tempo); opa != nil && opa.CA != "" {
+		dep, err = patchExternalOPACA(*opa, dep)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if params.Tempo.Spec.Template.Gateway.Ingress.Type == v1alpha1.IngressTypeIngress {
 		objs = append(objs, ingress(params.Tempo))
 	} else if params.Tempo.Spec.Template.Gateway.Ingress.Type == v1alpha1.IngressTypeRoute {
@@ -103,6 +110,11 @@ func BuildGateway(params manifestutils.Params) ([]client.Object, error) {
 		return nil, err
 	}
 
+	dep.Spec.Template, err = manifestutils.PatchPodTemplate(params.Tempo.Spec.Template.Gateway.PodTemplate, dep.Spec.Template)
+	if err != nil {
+		return nil, err
+	}
+
 	objs = append(objs, dep)
 	return objs, nil
 }
@@ -179,7 +191,8 @@ func deployment(params manifestutils.Params, rbacCfgHash string, tenantsCfgHash
 								fmt.Sprintf("--grpc.listen=0.0.0.0:%d", portGRPC),
 								fmt.Sprintf("--rbac.config=%s", path.Join(tempoGatewayMountDir, "cm", tempoGatewayRbacFileName)),
 								fmt.Sprintf("--tenants.config=%s", path.Join(tempoGatewayMountDir, "secret", manifestutils.GatewayTenantFileName)),
-								"--log.level=info",
+								fmt.Sprintf("--log.level=%s", manifestutils.LogLevel(cfg.LogLevel)),
+								fmt.Sprintf("--log.format=%s", manifestutils.LogFormat(tempo.Spec.LogFormat)),
 							}, tlsArgs...),
 							Ports: []corev1.ContainerPort{
 								{
@@ -279,6 +292,58 @@ func deployment(params manifestutils.Params, rbacCfgHash string, tenantsCfgHash
 	return dep
 }
 
+// patchExternalOPACA mounts the CA certificate of the external OPA endpoint.
+// The gateway queries OPA with the default HTTP client, therefore the CA certificate is added
+// to the system certificates with the SSL_CERT_DIR environment variable.
+func patchExternalOPACA(opa v1alpha1.OPASpec, dep *appsv1.Deployment) (*appsv1.Deployment, error) {
+	caDir := path.Join(tempoGatewayMountDir, "opa-ca")
+	container := corev1.Container{
+		Env: []corev1.EnvVar{
+			{
+				Name:  "SSL_CERT_DIR",
+				Value: caDir,
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      "opa-ca",
+				ReadOnly:  true,
+				MountPath: caDir,
+			},
+		},
+	}
+	err := mergo.Merge(&dep.Spec.Template.Spec.Containers[0], container, mergo.WithAppendSlice)
+	if err != nil {
+		return nil, err
+	}
+
+	pod := corev1.PodSpec{
+		Volumes: []corev1.Volume{
+			{
+				Name: "opa-ca",
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: opa.CA,
+						},
+						Items: []corev1.KeyToPath{
+							{
+								Key:  "ca.crt",
+								Path: "ca.crt",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	err = mergo.Merge(&dep.Spec.Template.Spec, pod, mergo.WithAppendSlice)
+	if err != nil {
+		return nil, err
+	}
+	return dep, nil
+}
+
 func patchTracing(tempo v1alpha1.TempoStack, pod corev1.PodTemplateSpec) (corev1.PodTemplateSpec, error) {
 	if tempo.Spec.Observability.Tracing.SamplingFraction == "" {
 		return pod, nil
@@ -235,6 +235,79 @@ func TestBuildGateway_openshift(t *testing.T) {
 	}, caConfigMap.Annotations)
 }
 
+func TestBuildGateway_externalOPA(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "simplest",
+			Namespace: "observability",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Template: v1alpha1.TempoTemplateSpec{
+				Gateway: v1alpha1.TempoGatewaySpec{
+					Enabled: true,
+				},
+			},
+			Tenants: &v1alpha1.TenantsSpec{
+				Mode: v1alpha1.ModeStatic,
+				Authentication: []v1alpha1.AuthenticationSpec{
+					{
+						TenantName: "dev",
+						TenantID:   "abcd1",
+					},
+				},
+				Authorization: &v1alpha1.AuthorizationSpec{
+					OPA: &v1alpha1.OPASpec{
+						URL:             "https://opa.example.com/v1/data/tempostack/allow",
+						CA:              "opa-ca",
+						WithAccessToken: true,
+					},
+				},
+			},
+		},
+	}
+	objects, err := BuildGateway(manifestutils.Params{Tempo: tempo})
+	require.NoError(t, err)
+
+	obj := getObjectByTypeAndName(objects, "tempo-simplest-gateway", reflect.TypeOf(&corev1.Secret{}))
+	require.NotNil(t, obj)
+	secret, ok := obj.(*corev1.Secret)
+	require.True(t, ok)
+	assert.Contains(t, string(secret.Data[manifestutils.GatewayTenantFileName]), `  opa:
+    url: https://opa.example.com/v1/data/tempostack/allow
+    withAccessToken: true`)
+
+	obj = getObjectByTypeAndName(objects, "tempo-simplest-gateway", reflect.TypeOf(&appsv1.Deployment{}))
+	require.NotNil(t, obj)
+	dep, ok := obj.(*appsv1.Deployment)
+	require.True(t, ok)
+	require.Equal(t, 1, len(dep.Spec.Template.Spec.Containers))
+	assert.Contains(t, dep.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{
+		Name:  "SSL_CERT_DIR",
+		Value: "/etc/tempo-gateway/opa-ca",
+	})
+	assert.Contains(t, dep.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      "opa-ca",
+		ReadOnly:  true,
+		MountPath: "/etc/tempo-gateway/opa-ca",
+	})
+	assert.Contains(t, dep.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: "opa-ca",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: "opa-ca",
+				},
+				Items: []corev1.KeyToPath{
+					{
+						Key:  "ca.crt",
+						Path: "ca.crt",
+					},
+				},
+			},
+		},
+	})
+}
+
 func getObjectByTypeAndName(objects []client.Object, name string, t reflect.Type) client.Object { // nolint: unparam
 	for _, o := range objects {
 		objType := reflect.TypeOf(o)
@@ -481,6 +554,45 @@ func TestTLSParameters(t *testing.T) {
 	assert.Equal(t, corev1.URISchemeHTTP, dep.Spec.Template.Spec.Containers[0].ReadinessProbe.HTTPGet.Scheme)
 }
 
+func TestLogging(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "simplest",
+			Namespace: "observability",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Tenants: &v1alpha1.TenantsSpec{
+				Mode: v1alpha1.ModeStatic,
+				Authorization: &v1alpha1.AuthorizationSpec{
+					RoleBindings: []v1alpha1.RoleBindingsSpec{},
+					Roles:        []v1alpha1.RoleSpec{},
+				},
+			},
+			LogFormat: v1alpha1.LogFormatJSON,
+			Template: v1alpha1.TempoTemplateSpec{
+				Gateway: v1alpha1.TempoGatewaySpec{
+					TempoComponentSpec: v1alpha1.TempoComponentSpec{
+						LogLevel: v1alpha1.LogLevelDebug,
+					},
+					Enabled: true,
+				},
+			},
+		},
+	}
+
+	objects, err := BuildGateway(manifestutils.Params{Tempo: tempo})
+	require.NoError(t, err)
+	obj := getObjectByTypeAndName(objects, "tempo-simplest-gateway", reflect.TypeOf(&appsv1.Deployment{}))
+	require.NotNil(t, obj)
+
+	dep, ok := obj.(*appsv1.Deployment)
+	require.True(t, ok)
+
+	args := dep.Spec.Template.Spec.Containers[0].Args
+	assert.Contains(t, args, "--log.level=debug")
+	assert.Contains(t, args, "--log.format=json")
+}
+
 func TestIngress(t *testing.T) {
 	objects, err := BuildGateway(manifestutils.Params{Tempo: v1alpha1.TempoStack{
 		ObjectMeta: metav1.ObjectMeta{
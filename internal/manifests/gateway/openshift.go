@@ -224,9 +224,10 @@ func opaContainer(params manifestutils.Params) corev1.Container {
 	}
 
 	return corev1.Container{
-		Name:  "opa",
-		Image: image,
-		Args:  args,
+		Name:      "opa",
+		Image:     image,
+		Args:      args,
+		Resources: manifestutils.OPAResources(params.Tempo),
 		Ports: []corev1.ContainerPort{
 			{
 				Name:          "public",
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
@@ -16,6 +17,11 @@ import (
 )
 
 func TestPatchOPAContainer(t *testing.T) {
+	opaResources := corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("128Mi"),
+		},
+	}
 	tempo := v1alpha1.TempoStack{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "simplest",
@@ -35,6 +41,13 @@ func TestPatchOPAContainer(t *testing.T) {
 					},
 				},
 			},
+			Template: v1alpha1.TempoTemplateSpec{
+				Gateway: v1alpha1.TempoGatewaySpec{
+					OPA: v1alpha1.GatewayOPASpec{
+						Resources: &opaResources,
+					},
+				},
+			},
 		},
 	}
 	dep, err := patchOCPOPAContainer(manifestutils.Params{Tempo: tempo}, &appsv1.Deployment{})
@@ -49,6 +62,7 @@ func TestPatchOPAContainer(t *testing.T) {
 		"--openshift.mappings=dev=tempo.grafana.com",
 		"--openshift.mappings=prod=tempo.grafana.com",
 	}, dep.Spec.Template.Spec.Containers[0].Args)
+	assert.Equal(t, opaResources, dep.Spec.Template.Spec.Containers[0].Resources)
 }
 
 func TestPatchOCPServingCerts(t *testing.T) {
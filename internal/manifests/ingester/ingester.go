@@ -1,12 +1,15 @@
 package ingester
 
 import (
+	"fmt"
+
 	"github.com/operator-framework/operator-lib/proxy"
 	v1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8slabels "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
@@ -17,38 +20,97 @@ import (
 
 const (
 	dataVolumeName = "data"
+	// autoscalingTerminationGracePeriodSeconds is the time given to an autoscaled ingester to flush all traces
+	// to the object storage and to leave the ring, before it is killed. It is shorter than the scale-down period
+	// of the HorizontalPodAutoscaler, which removes one ingester at a time.
+	autoscalingTerminationGracePeriodSeconds = 300
 )
 
 // BuildIngester creates distributor objects.
 func BuildIngester(params manifestutils.Params) ([]client.Object, error) {
-	ss, err := statefulSet(params)
+	tempo := params.Tempo
+	zoneAwareReplication := tempo.Spec.Template.Ingester.ZoneAwareReplication
 
-	if err != nil {
-		return nil, err
+	var statefulSets []*v1.StatefulSet
+	if zoneAwareReplication.Enabled {
+		for i, zone := range zoneAwareReplication.Zones {
+			ss, err := zoneStatefulSet(params, zone, manifestutils.ZoneReplicas(manifestutils.Replicas(tempo.Spec.Template.Ingester.TempoComponentSpec), zoneAwareReplication.Zones, i))
+			if err != nil {
+				return nil, err
+			}
+			statefulSets = append(statefulSets, ss)
+		}
+	} else {
+		ss, err := statefulSet(params)
+		if err != nil {
+			return nil, err
+		}
+		statefulSets = append(statefulSets, ss)
 	}
 
+	objects := []client.Object{}
+	for _, ss := range statefulSets {
+		if err := configureStatefulSet(params, ss); err != nil {
+			return nil, err
+		}
+		objects = append(objects, ss)
+	}
+
+	return append(objects, service(tempo)), nil
+}
+
+func configureStatefulSet(params manifestutils.Params, ss *v1.StatefulSet) error {
 	gates := params.CtrlConfig.Gates
 	tempo := params.Tempo
 
 	if gates.HTTPEncryption || gates.GRPCEncryption {
 		caBundleName := naming.SigningCABundleName(tempo.Name)
 		if err := manifestutils.ConfigureServiceCA(&ss.Spec.Template.Spec, caBundleName); err != nil {
-			return nil, err
+			return err
 		}
 
 		err := manifestutils.ConfigureServicePKI(tempo.Name, manifestutils.IngesterComponentName, &ss.Spec.Template.Spec)
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
-	return []client.Object{ss, service(tempo)}, nil
+	var err error
+	ss.Spec.Template, err = manifestutils.PatchPodTemplate(tempo.Spec.Template.Ingester.PodTemplate, ss.Spec.Template)
+	return err
+}
+
+// zoneStatefulSet creates the ingester StatefulSet of an availability zone.
+// The pods of all zones share the component labels, i.e. they are part of the same ingester service.
+func zoneStatefulSet(params manifestutils.Params, zone v1alpha1.ZoneSpec, replicas *int32) (*v1.StatefulSet, error) {
+	ss, err := statefulSet(params)
+	if err != nil {
+		return nil, err
+	}
+
+	zoneLabels := map[string]string{manifestutils.ZoneLabel: zone.Name}
+	ss.Name = naming.Name(fmt.Sprintf("%s-%s", manifestutils.IngesterComponentName, zone.Name), params.Tempo.Name)
+	ss.Labels = k8slabels.Merge(ss.Labels, zoneLabels)
+	ss.Spec.Replicas = replicas
+	ss.Spec.Selector.MatchLabels = k8slabels.Merge(ss.Spec.Selector.MatchLabels, zoneLabels)
+	ss.Spec.Template.Labels = k8slabels.Merge(ss.Spec.Template.Labels, zoneLabels)
+	if len(zone.NodeSelector) > 0 {
+		ss.Spec.Template.Spec.NodeSelector = k8slabels.Merge(ss.Spec.Template.Spec.NodeSelector, zone.NodeSelector)
+	}
+
+	container := &ss.Spec.Template.Spec.Containers[0]
+	container.Env = append(container.Env, corev1.EnvVar{
+		Name:  manifestutils.AvailabilityZoneEnvVar,
+		Value: zone.Name,
+	})
+
+	return ss, nil
 }
 
 func statefulSet(params manifestutils.Params) (*v1.StatefulSet, error) {
 	tempo := params.Tempo
 	labels := manifestutils.ComponentLabels(manifestutils.IngesterComponentName, tempo.Name)
-	annotations := manifestutils.CommonAnnotations(params.ConfigChecksum)
+	annotations := manifestutils.StorageAnnotations(params.ConfigChecksum, params.StorageParams)
 	filesystem := corev1.PersistentVolumeFilesystem
 	cfg := tempo.Spec.Template.Ingester
 	image := tempo.Spec.Images.Tempo
@@ -63,7 +125,7 @@ func statefulSet(params manifestutils.Params) (*v1.StatefulSet, error) {
 			Labels:    labels,
 		},
 		Spec: v1.StatefulSetSpec{
-			Replicas: tempo.Spec.Template.Ingester.Replicas,
+			Replicas: manifestutils.Replicas(tempo.Spec.Template.Ingester.TempoComponentSpec),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: labels,
 			},
@@ -93,7 +155,8 @@ func statefulSet(params manifestutils.Params) (*v1.StatefulSet, error) {
 							Args: []string{
 								"-target=ingester",
 								"-config.file=/conf/tempo.yaml",
-								"-log.level=info",
+								"-config.expand-env=true",
+								fmt.Sprintf("-log.level=%s", manifestutils.LogLevel(cfg.LogLevel)),
 							},
 							VolumeMounts: []corev1.VolumeMount{
 								{
@@ -162,7 +225,13 @@ func statefulSet(params manifestutils.Params) (*v1.StatefulSet, error) {
 		},
 	}
 
-	err := manifestutils.ConfigureStorage(tempo, &ss.Spec.Template.Spec)
+	if cfg.Autoscaling.Enabled {
+		// Autoscaled ingesters flush their data and leave the ring when they are stopped
+		// (flush_all_on_shutdown and unregister_on_shutdown), which can take longer than the default grace period.
+		ss.Spec.Template.Spec.TerminationGracePeriodSeconds = ptr.To(int64(autoscalingTerminationGracePeriodSeconds))
+	}
+
+	err := manifestutils.ConfigureStorage(params, &ss.Spec.Template.Spec)
 	if err != nil {
 		return nil, err
 	}
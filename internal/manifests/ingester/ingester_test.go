@@ -11,6 +11,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8slabels "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 
 	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
 	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
@@ -39,11 +40,13 @@ func TestBuildIngester(t *testing.T) {
 			StorageSize:      resource.MustParse("10Gi"),
 			StorageClassName: &storageClassName,
 			Template: v1alpha1.TempoTemplateSpec{
-				Ingester: v1alpha1.TempoComponentSpec{
-					NodeSelector: map[string]string{"a": "b"},
-					Tolerations: []corev1.Toleration{
-						{
-							Key: "c",
+				Ingester: v1alpha1.TempoIngesterSpec{
+					TempoComponentSpec: v1alpha1.TempoComponentSpec{
+						NodeSelector: map[string]string{"a": "b"},
+						Tolerations: []corev1.Toleration{
+							{
+								Key: "c",
+							},
 						},
 					},
 				},
@@ -95,6 +98,7 @@ func TestBuildIngester(t *testing.T) {
 							Image: "docker.io/grafana/tempo:1.5.0",
 							Args: []string{"-target=ingester",
 								"-config.file=/conf/tempo.yaml",
+								"-config.expand-env=true",
 								"-log.level=info",
 								"--storage.trace.s3.secret_key=$(S3_SECRET_KEY)",
 								"--storage.trace.s3.access_key=$(S3_ACCESS_KEY)",
@@ -232,3 +236,138 @@ func TestBuildIngester(t *testing.T) {
 		},
 	}, objects[1])
 }
+
+func TestBuildIngesterPodTemplate(t *testing.T) {
+	objects, err := BuildIngester(manifestutils.Params{Tempo: v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Storage: v1alpha1.ObjectStorageSpec{
+				Secret: v1alpha1.ObjectStorageSecretSpec{
+					Name: "test-storage-secret",
+					Type: "s3",
+				},
+			},
+			StorageSize: resource.MustParse("10Gi"),
+			Template: v1alpha1.TempoTemplateSpec{
+				Ingester: v1alpha1.TempoIngesterSpec{
+					TempoComponentSpec: v1alpha1.TempoComponentSpec{
+						PodTemplate: &v1alpha1.PodTemplateSpec{
+							PriorityClassName: "high-priority",
+							Env: []corev1.EnvVar{
+								{Name: "GOMEMLIMIT", Value: "1GiB"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}})
+	require.NoError(t, err)
+
+	ss, ok := objects[0].(*v1.StatefulSet)
+	require.True(t, ok)
+	assert.Equal(t, "high-priority", ss.Spec.Template.Spec.PriorityClassName)
+	assert.Equal(t, manifestutils.DefaultAffinity(manifestutils.ComponentLabels(manifestutils.IngesterComponentName, "test")), ss.Spec.Template.Spec.Affinity)
+	assert.Contains(t, ss.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{Name: "GOMEMLIMIT", Value: "1GiB"})
+	assert.Subset(t, ss.Spec.Template.Labels, ss.Spec.Selector.MatchLabels)
+}
+
+func TestBuildIngesterZoneAwareReplication(t *testing.T) {
+	objects, err := BuildIngester(manifestutils.Params{Tempo: v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Storage: v1alpha1.ObjectStorageSpec{
+				Secret: v1alpha1.ObjectStorageSecretSpec{
+					Name: "test-storage-secret",
+					Type: "s3",
+				},
+			},
+			StorageSize: resource.MustParse("10Gi"),
+			Template: v1alpha1.TempoTemplateSpec{
+				Ingester: v1alpha1.TempoIngesterSpec{
+					TempoComponentSpec: v1alpha1.TempoComponentSpec{
+						Replicas:     ptr.To(int32(3)),
+						NodeSelector: map[string]string{"a": "b"},
+					},
+					ZoneAwareReplication: v1alpha1.ZoneAwareReplicationSpec{
+						Enabled: true,
+						Zones: []v1alpha1.ZoneSpec{
+							{Name: "zone-a", NodeSelector: map[string]string{"topology.kubernetes.io/zone": "us-east-1a"}},
+							{Name: "zone-b", NodeSelector: map[string]string{"topology.kubernetes.io/zone": "us-east-1b"}},
+						},
+					},
+				},
+			},
+		},
+	}})
+	require.NoError(t, err)
+	require.Len(t, objects, 3)
+
+	componentLabels := manifestutils.ComponentLabels(manifestutils.IngesterComponentName, "test")
+	for i, zone := range []struct {
+		name     string
+		nodeZone string
+		replicas int32
+	}{
+		{name: "zone-a", nodeZone: "us-east-1a", replicas: 2},
+		{name: "zone-b", nodeZone: "us-east-1b", replicas: 1},
+	} {
+		ss, ok := objects[i].(*v1.StatefulSet)
+		require.True(t, ok)
+		assert.Equal(t, "tempo-test-ingester-"+zone.name, ss.Name)
+		assert.Equal(t, ptr.To(zone.replicas), ss.Spec.Replicas)
+		assert.Equal(t, zone.name, ss.Spec.Selector.MatchLabels[manifestutils.ZoneLabel])
+		assert.Subset(t, ss.Spec.Template.Labels, ss.Spec.Selector.MatchLabels)
+		assert.Subset(t, ss.Spec.Template.Labels, componentLabels)
+		assert.Equal(t, map[string]string{"a": "b", "topology.kubernetes.io/zone": zone.nodeZone}, ss.Spec.Template.Spec.NodeSelector)
+		assert.Contains(t, ss.Spec.Template.Spec.Containers[0].Args, "-config.expand-env=true")
+		assert.Contains(t, ss.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{Name: manifestutils.AvailabilityZoneEnvVar, Value: zone.name})
+	}
+
+	svc, ok := objects[2].(*corev1.Service)
+	require.True(t, ok)
+	assert.Equal(t, componentLabels, k8slabels.Set(svc.Spec.Selector))
+}
+
+func TestBuildIngesterAutoscaling(t *testing.T) {
+	objects, err := BuildIngester(manifestutils.Params{Tempo: v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Storage: v1alpha1.ObjectStorageSpec{
+				Secret: v1alpha1.ObjectStorageSecretSpec{
+					Name: "test-storage-secret",
+					Type: "s3",
+				},
+			},
+			StorageSize: resource.MustParse("10Gi"),
+			Template: v1alpha1.TempoTemplateSpec{
+				Ingester: v1alpha1.TempoIngesterSpec{
+					TempoComponentSpec: v1alpha1.TempoComponentSpec{
+						Autoscaling: v1alpha1.AutoscalingSpec{
+							Enabled:     true,
+							MinReplicas: ptr.To(int32(2)),
+							MaxReplicas: 5,
+						},
+					},
+				},
+			},
+		},
+	}})
+	require.NoError(t, err)
+
+	ss, ok := objects[0].(*v1.StatefulSet)
+	require.True(t, ok)
+	// the replicas are managed by the HorizontalPodAutoscaler
+	assert.Nil(t, ss.Spec.Replicas)
+	// the removed ingesters get enough time to flush their data and leave the ring
+	assert.Equal(t, ptr.To(int64(autoscalingTerminationGracePeriodSeconds)), ss.Spec.Template.Spec.TerminationGracePeriodSeconds)
+}
@@ -4,6 +4,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/grafana/tempo-operator/internal/manifests/alerts"
+	"github.com/grafana/tempo-operator/internal/manifests/autoscaling"
 	"github.com/grafana/tempo-operator/internal/manifests/compactor"
 	"github.com/grafana/tempo-operator/internal/manifests/config"
 	"github.com/grafana/tempo-operator/internal/manifests/distributor"
@@ -12,7 +13,10 @@ import (
 	"github.com/grafana/tempo-operator/internal/manifests/ingester"
 	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
 	"github.com/grafana/tempo-operator/internal/manifests/memberlist"
+	"github.com/grafana/tempo-operator/internal/manifests/memcached"
+	"github.com/grafana/tempo-operator/internal/manifests/metricsgenerator"
 	"github.com/grafana/tempo-operator/internal/manifests/naming"
+	"github.com/grafana/tempo-operator/internal/manifests/poddisruptionbudget"
 	"github.com/grafana/tempo-operator/internal/manifests/querier"
 	"github.com/grafana/tempo-operator/internal/manifests/queryfrontend"
 	"github.com/grafana/tempo-operator/internal/manifests/serviceaccount"
@@ -54,7 +58,7 @@ func BuildAll(params manifestutils.Params) ([]client.Object, error) {
 	var manifests []client.Object
 	manifests = append(manifests, configMaps)
 	if params.Tempo.Spec.ServiceAccount == naming.DefaultServiceAccountName(params.Tempo.Name) {
-		manifests = append(manifests, serviceaccount.BuildDefaultServiceAccount(params.Tempo))
+		manifests = append(manifests, serviceaccount.BuildDefaultServiceAccount(params))
 	}
 	manifests = append(manifests, distributorObjs...)
 	manifests = append(manifests, ingesterObjs...)
@@ -62,6 +66,7 @@ func BuildAll(params manifestutils.Params) ([]client.Object, error) {
 	manifests = append(manifests, frontendObjs...)
 	manifests = append(manifests, querierObjs...)
 	manifests = append(manifests, compactorObjs...)
+	manifests = append(manifests, autoscaling.BuildAutoscalers(params)...)
 
 	if params.Tempo.Spec.Template.Gateway.Enabled {
 		gw, err := gateway.BuildGateway(params)
@@ -71,6 +76,20 @@ func BuildAll(params manifestutils.Params) ([]client.Object, error) {
 		manifests = append(manifests, gw...)
 	}
 
+	if params.Tempo.Spec.Template.MetricsGenerator.Enabled {
+		metricsGeneratorObjs, err := metricsgenerator.BuildMetricsGenerator(params)
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, metricsGeneratorObjs...)
+	}
+
+	if params.Tempo.Spec.Cache.Managed.Enabled {
+		manifests = append(manifests, memcached.BuildMemcached(params)...)
+	}
+
+	manifests = append(manifests, poddisruptionbudget.BuildPodDisruptionBudgets(params)...)
+
 	if params.Tempo.Spec.Observability.Metrics.CreateServiceMonitors {
 		manifests = append(manifests, servicemonitor.BuildServiceMonitors(params)...)
 	}
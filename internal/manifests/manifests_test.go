@@ -79,5 +79,5 @@ func TestBuildAll(t *testing.T) {
 		},
 	})
 	require.NoError(t, err)
-	assert.Len(t, objects, 17)
+	assert.Len(t, objects, 23)
 }
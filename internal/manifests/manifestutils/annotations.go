@@ -6,3 +6,14 @@ func CommonAnnotations(configChecksum string) map[string]string {
 		"tempo.grafana.com/config.hash": configChecksum,
 	}
 }
+
+// StorageAnnotations returns the common annotations for each pod accessing the object storage.
+// The credentials are passed as environment variables, therefore the pods are rolled out when
+// the hash of the storage secret or the storage CA ConfigMap changes.
+func StorageAnnotations(configChecksum string, storage StorageParams) map[string]string {
+	annotations := CommonAnnotations(configChecksum)
+	if storage.CredentialsHash != "" {
+		annotations["tempo.grafana.com/storage.hash"] = storage.CredentialsHash
+	}
+	return annotations
+}
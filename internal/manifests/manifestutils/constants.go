@@ -77,6 +77,15 @@ const (
 	// PortMemberlist declares the port number of the tempo memberlist port.
 	PortMemberlist = 7946
 
+	// MemcachedPortName declares the name of the memcached client port.
+	MemcachedPortName = "memcached"
+	// PortMemcached declares the port number of the memcached client port.
+	PortMemcached = 11211
+	// MemcachedMetricsPortName declares the name of the memcached exporter metrics port.
+	MemcachedMetricsPortName = "http-metrics"
+	// PortMemcachedMetrics declares the port number of the memcached exporter metrics port.
+	PortMemcachedMetrics = 9150
+
 	// CompactorComponentName declares the internal name of the compactor component.
 	CompactorComponentName = "compactor"
 	// QuerierComponentName declares the internal name of the querier component.
@@ -89,6 +98,12 @@ const (
 	IngesterComponentName = "ingester"
 	// GatewayComponentName declares the internal name of the gateway component.
 	GatewayComponentName = "gateway"
+	// MetricsGeneratorComponentName declares the internal name of the metrics-generator component.
+	MetricsGeneratorComponentName = "metrics-generator"
+	// GossipComponentName declares the internal name of the memberlist gossip ring.
+	GossipComponentName = "gossip-ring"
+	// MemcachedComponentName declares the internal name of the managed memcached component.
+	MemcachedComponentName = "memcached"
 	// TenantHeader is the header name that contains tenant name.
 	TenantHeader = "x-scope-orgid"
 
@@ -98,4 +113,17 @@ const (
 	ReceiverPublicKey = "tls.crt"
 	// ReceiverPrivateKey is the key name of the private key file in the configmap.
 	ReceiverPrivateKey = "tls.key"
+
+	// RemoteWriteSecretDir is the path where the metrics-generator remote write secret is mounted.
+	RemoteWriteSecretDir = "/var/run/tempo/remote-write"
+	// RemoteWriteCAKey is the key name of the CA certificate in the remote write secret.
+	RemoteWriteCAKey = "ca.crt"
+	// RemoteWriteUsernameKey is the key name of the basic auth username in the remote write secret.
+	RemoteWriteUsernameKey = "username"
+	// RemoteWriteUsernameEnvVar is the environment variable containing the basic auth username of the remote write endpoint.
+	RemoteWriteUsernameEnvVar = "REMOTE_WRITE_USERNAME"
+	// RemoteWritePasswordKey is the key name of the basic auth password in the remote write secret.
+	RemoteWritePasswordKey = "password"
+	// RemoteWriteTokenKey is the key name of the bearer token in the remote write secret.
+	RemoteWriteTokenKey = "token"
 )
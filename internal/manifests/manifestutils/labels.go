@@ -29,3 +29,20 @@ func CommonOperatorLabels() map[string]string {
 		"control-plane":                "controller-manager",
 	}
 }
+
+// MonolithicComponentLabels is a list of all common labels of a TempoMonolithic instance
+// including the app.kubernetes.io/component:<component> label.
+func MonolithicComponentLabels(component, instanceName string) labels.Set {
+	return labels.Merge(CommonMonolithicLabels(instanceName), map[string]string{
+		"app.kubernetes.io/component": component,
+	})
+}
+
+// CommonMonolithicLabels returns common labels for each object of a TempoMonolithic instance created by the operator.
+func CommonMonolithicLabels(instanceName string) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":       "tempo-monolithic",
+		"app.kubernetes.io/instance":   instanceName,
+		"app.kubernetes.io/managed-by": "tempo-operator",
+	}
+}
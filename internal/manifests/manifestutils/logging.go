@@ -0,0 +1,21 @@
+package manifestutils
+
+import (
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+// LogLevel returns the log level of a component, defaults to info.
+func LogLevel(level v1alpha1.LogLevel) string {
+	if level == "" {
+		return string(v1alpha1.LogLevelInfo)
+	}
+	return string(level)
+}
+
+// LogFormat returns the log format of the components, defaults to logfmt.
+func LogFormat(format v1alpha1.LogFormat) string {
+	if format == "" {
+		return string(v1alpha1.LogFormatLogfmt)
+	}
+	return string(format)
+}
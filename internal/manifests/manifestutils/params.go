@@ -15,6 +15,14 @@ type Params struct {
 	TLSProfile          tlsprofile.TLSProfileOptions
 	GatewayTenantSecret []*GatewayTenantOIDCSecret
 	GatewayTenantsData  []*GatewayTenantsData
+	RemoteWrite         RemoteWriteParams
+}
+
+// RemoteWriteParams holds the credentials available in the metrics-generator remote write secret.
+type RemoteWriteParams struct {
+	CA          bool
+	BasicAuth   bool
+	BearerToken bool
 }
 
 // StorageParams holds storage configuration.
@@ -22,19 +30,36 @@ type StorageParams struct {
 	AzureStorage *AzureStorage
 	GCS          *GCS
 	S3           *S3
+	// CredentialsHash is the hash of the storage secret and the storage CA ConfigMap.
+	CredentialsHash string
 }
 
 // AzureStorage for Azure Storage.
 type AzureStorage struct {
 	Container   string
+	Prefix      string
 	AccountName string
 	AccountKey  string
+	// UseFederatedToken enables Azure Workload Identity (token credential mode).
+	UseFederatedToken bool
+	ClientID          string
+	TenantID          string
+	// EndpointSuffix overrides the endpoint of the public Azure cloud, e.g. for sovereign clouds or emulators.
+	EndpointSuffix string
 }
 
 // GCS for Google Cloud Storage.
 type GCS struct {
 	Bucket  string
+	Prefix  string
 	KeyJson string
+	// Audience of the workload identity provider, set if a workload identity federation
+	// credential configuration is used (token credential mode).
+	Audience string
+	// IAMServiceAccount is the GCP service account impersonated with GKE Workload Identity.
+	IAMServiceAccount string
+	// Endpoint overrides the endpoint of the Google Cloud Storage API, e.g. for emulators.
+	Endpoint string
 }
 
 // S3 holds S3 configuration.
@@ -42,8 +67,14 @@ type S3 struct {
 	// Endpoint without http/https
 	Endpoint  string
 	Bucket    string
+	Prefix    string
 	Insecure  bool
 	TLSCAPath string
+	Region    string
+	// RoleARN is the AWS IAM role assumed by the Tempo components in the token credential mode.
+	RoleARN string
+	// ForcePathStyle forces path-style addressing of the bucket, e.g. for S3 compatible on-premises gateways.
+	ForcePathStyle bool
 }
 
 // GatewayTenantOIDCSecret holds clientID, clientSecret and issuerCAPath for tenant's authentication.
@@ -0,0 +1,89 @@
+package manifestutils
+
+import (
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+// PatchPodTemplate applies the pod template overrides of a component on top of the pod template created by the operator.
+// The overrides are applied as a strategic merge patch: env vars and volume mounts are added to the main (first) container,
+// sidecars are added as additional containers and volumes are added to the pod.
+// The affinity of the overrides replaces the default affinity, and the labels created by the operator are retained,
+// because they are used in the selectors of the component.
+func PatchPodTemplate(overrides *v1alpha1.PodTemplateSpec, pod corev1.PodTemplateSpec) (corev1.PodTemplateSpec, error) {
+	if overrides == nil || len(pod.Spec.Containers) == 0 {
+		return pod, nil
+	}
+
+	// The patch is built from a map, because serializing a corev1.PodTemplateSpec
+	// contains null values (e.g. of the containers), which would remove the fields from the pod template.
+	spec := map[string]interface{}{}
+	if len(overrides.TopologySpreadConstraints) > 0 {
+		spec["topologySpreadConstraints"] = overrides.TopologySpreadConstraints
+	}
+	if overrides.PriorityClassName != "" {
+		spec["priorityClassName"] = overrides.PriorityClassName
+	}
+	if len(overrides.Volumes) > 0 {
+		spec["volumes"] = overrides.Volumes
+	}
+
+	var containers []corev1.Container
+	if len(overrides.Env) > 0 || len(overrides.VolumeMounts) > 0 {
+		containers = append(containers, corev1.Container{
+			Name:         pod.Spec.Containers[0].Name,
+			Env:          overrides.Env,
+			VolumeMounts: overrides.VolumeMounts,
+		})
+	}
+	containers = append(containers, overrides.Sidecars...)
+	if len(containers) > 0 {
+		spec["containers"] = containers
+	}
+
+	metadata := map[string]interface{}{}
+	if len(overrides.Labels) > 0 {
+		metadata["labels"] = overrides.Labels
+	}
+	if len(overrides.Annotations) > 0 {
+		metadata["annotations"] = overrides.Annotations
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": metadata,
+		"spec":     spec,
+	})
+	if err != nil {
+		return corev1.PodTemplateSpec{}, err
+	}
+
+	original, err := json.Marshal(pod)
+	if err != nil {
+		return corev1.PodTemplateSpec{}, err
+	}
+
+	patched, err := strategicpatch.StrategicMergePatch(original, patch, corev1.PodTemplateSpec{})
+	if err != nil {
+		return corev1.PodTemplateSpec{}, err
+	}
+
+	result := corev1.PodTemplateSpec{}
+	if err := json.Unmarshal(patched, &result); err != nil {
+		return corev1.PodTemplateSpec{}, err
+	}
+
+	if overrides.Affinity != nil {
+		result.Spec.Affinity = overrides.Affinity.DeepCopy()
+	}
+	for key, value := range pod.Labels {
+		if result.Labels == nil {
+			result.Labels = map[string]string{}
+		}
+		result.Labels[key] = value
+	}
+	return result, nil
+}
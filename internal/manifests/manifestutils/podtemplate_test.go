@@ -0,0 +1,122 @@
+package manifestutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+func TestPatchPodTemplate(t *testing.T) {
+	labels := ComponentLabels(IngesterComponentName, "test")
+	pod := corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      labels,
+			Annotations: map[string]string{"tempo.grafana.com/config.hash": "abc"},
+		},
+		Spec: corev1.PodSpec{
+			Affinity: DefaultAffinity(labels),
+			Containers: []corev1.Container{
+				{
+					Name:  "tempo",
+					Image: "tempo:latest",
+					Env: []corev1.EnvVar{
+						{Name: "A", Value: "a"},
+						{Name: "B", Value: "b"},
+					},
+					VolumeMounts: []corev1.VolumeMount{
+						{Name: "config", MountPath: "/conf"},
+					},
+				},
+			},
+			Volumes: []corev1.Volume{
+				{Name: "config"},
+			},
+		},
+	}
+
+	t.Run("no overrides", func(t *testing.T) {
+		patched, err := PatchPodTemplate(nil, pod)
+		require.NoError(t, err)
+		assert.Equal(t, pod, patched)
+	})
+
+	t.Run("overrides", func(t *testing.T) {
+		affinity := &corev1.Affinity{
+			NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+						MatchExpressions: []corev1.NodeSelectorRequirement{{
+							Key:      "kubernetes.io/arch",
+							Operator: corev1.NodeSelectorOpIn,
+							Values:   []string{"amd64"},
+						}},
+					}},
+				},
+			},
+		}
+		overrides := &v1alpha1.PodTemplateSpec{
+			Labels: map[string]string{
+				"team":                   "tracing",
+				"app.kubernetes.io/name": "custom",
+			},
+			Annotations: map[string]string{"example.com/annotation": "value"},
+			Affinity:    affinity,
+			TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{
+				MaxSkew:           1,
+				TopologyKey:       "topology.kubernetes.io/zone",
+				WhenUnsatisfiable: corev1.ScheduleAnyway,
+			}},
+			PriorityClassName: "high-priority",
+			Env: []corev1.EnvVar{
+				{Name: "B", Value: "override"},
+				{Name: "C", Value: "c"},
+			},
+			Volumes: []corev1.Volume{
+				{Name: "extra", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+			},
+			VolumeMounts: []corev1.VolumeMount{
+				{Name: "extra", MountPath: "/extra"},
+			},
+			Sidecars: []corev1.Container{
+				{Name: "sidecar", Image: "sidecar:latest"},
+			},
+		}
+
+		patched, err := PatchPodTemplate(overrides, pod)
+		require.NoError(t, err)
+
+		assert.Equal(t, "tracing", patched.Labels["team"])
+		assert.Equal(t, labels["app.kubernetes.io/name"], patched.Labels["app.kubernetes.io/name"])
+		assert.Equal(t, map[string]string{
+			"tempo.grafana.com/config.hash": "abc",
+			"example.com/annotation":        "value",
+		}, patched.Annotations)
+		assert.Equal(t, affinity, patched.Spec.Affinity)
+		assert.Equal(t, overrides.TopologySpreadConstraints, patched.Spec.TopologySpreadConstraints)
+		assert.Equal(t, "high-priority", patched.Spec.PriorityClassName)
+		assert.ElementsMatch(t, []corev1.Volume{
+			{Name: "config"},
+			{Name: "extra", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+		}, patched.Spec.Volumes)
+
+		require.Len(t, patched.Spec.Containers, 2)
+		tempo := patched.Spec.Containers[0]
+		assert.Equal(t, "tempo", tempo.Name)
+		assert.Equal(t, "tempo:latest", tempo.Image)
+		assert.ElementsMatch(t, []corev1.EnvVar{
+			{Name: "A", Value: "a"},
+			{Name: "B", Value: "override"},
+			{Name: "C", Value: "c"},
+		}, tempo.Env)
+		assert.ElementsMatch(t, []corev1.VolumeMount{
+			{Name: "config", MountPath: "/conf"},
+			{Name: "extra", MountPath: "/extra"},
+		}, tempo.VolumeMounts)
+		assert.Equal(t, corev1.Container{Name: "sidecar", Image: "sidecar:latest"}, patched.Spec.Containers[1])
+	})
+}
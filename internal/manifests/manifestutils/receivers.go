@@ -0,0 +1,83 @@
+package manifestutils
+
+import (
+	"github.com/ViaQ/logerr/v2/kverrors"
+	"github.com/imdario/mergo"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+// ConfigureReceiversTLS mounts the CA ConfigMap and the certificate Secret of the receivers TLS configuration
+// into the first container of the pod.
+func ConfigureReceiversTLS(spec v1alpha1.ReceiversTLSSpec, podSpec *corev1.PodSpec) error {
+	caSecretName := spec.CA
+	certSecretName := spec.Cert
+	if caSecretName != "" {
+		/*Configure CA*/
+		secretCAVolumeSpec := corev1.PodSpec{
+			Volumes: []corev1.Volume{
+				{
+					Name: caSecretName,
+					VolumeSource: corev1.VolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: caSecretName,
+							},
+						},
+					},
+				},
+			},
+		}
+
+		secretCAContainerSpec := corev1.Container{
+			VolumeMounts: []corev1.VolumeMount{
+				{
+					Name:      caSecretName,
+					ReadOnly:  true,
+					MountPath: CAReceiver,
+				},
+			},
+		}
+		if err := mergo.Merge(podSpec, secretCAVolumeSpec, mergo.WithAppendSlice); err != nil {
+			return kverrors.Wrap(err, "failed to merge volumes")
+		}
+
+		if err := mergo.Merge(&podSpec.Containers[0], secretCAContainerSpec, mergo.WithAppendSlice); err != nil {
+			return kverrors.Wrap(err, "failed to merge container")
+		}
+	}
+
+	secretCertVolumeSpec := corev1.PodSpec{
+		Volumes: []corev1.Volume{
+			{
+				Name: certSecretName,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: certSecretName,
+					},
+				},
+			},
+		},
+	}
+	secretCertContainerSpec := corev1.Container{
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      certSecretName,
+				ReadOnly:  true,
+				MountPath: TempoReceiverTLSDir(),
+			},
+		},
+	}
+
+	/*Configure certificate*/
+
+	if err := mergo.Merge(podSpec, secretCertVolumeSpec, mergo.WithAppendSlice); err != nil {
+		return kverrors.Wrap(err, "failed to merge volumes")
+	}
+
+	if err := mergo.Merge(&podSpec.Containers[0], secretCertContainerSpec, mergo.WithAppendSlice); err != nil {
+		return kverrors.Wrap(err, "failed to merge container")
+	}
+	return nil
+}
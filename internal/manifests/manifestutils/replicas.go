@@ -0,0 +1,15 @@
+package manifestutils
+
+import (
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+// Replicas returns the number of replicas of a component.
+// If autoscaling is enabled, the replicas are managed by the HorizontalPodAutoscaler
+// and nil is returned, so that the operator does not override the scaling decisions.
+func Replicas(component v1alpha1.TempoComponentSpec) *int32 {
+	if component.Autoscaling.Enabled {
+		return nil
+	}
+	return component.Replicas
+}
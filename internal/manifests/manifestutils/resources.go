@@ -33,7 +33,11 @@ var (
 )
 
 // Resources calculates the resource requirements of a specific component.
+// Explicit resources of the component take precedence over its share of the total resources.
+// If the explicit resources of the component do not set a resource (e.g. only CPU is set),
+// this resource is filled from the share of the component of the total resources.
 func Resources(tempo v1alpha1.TempoStack, component string) corev1.ResourceRequirements {
+	explicit := explicitResources(tempo, component)
 
 	resourcesMap := resourcesMapNoGateway
 	if tempo.Spec.Template.Gateway.Enabled {
@@ -42,34 +46,155 @@ func Resources(tempo v1alpha1.TempoStack, component string) corev1.ResourceRequi
 
 	componentResources, ok := resourcesMap[component]
 	if tempo.Spec.Resources.Total == nil || !ok {
+		if explicit != nil {
+			return *explicit.DeepCopy()
+		}
 		return corev1.ResourceRequirements{}
 	}
-	resources := corev1.ResourceRequirements{}
-	totalCpu, ok := tempo.Spec.Resources.Total.Limits[corev1.ResourceCPU]
-	if ok {
-		totalCpuInt := totalCpu.MilliValue()
-		cpu := float32(totalCpuInt) * componentResources.cpu
 
-		resources.Limits = corev1.ResourceList{
-			corev1.ResourceCPU: *resource.NewMilliQuantity(int64(cpu), resource.BinarySI),
+	resources := corev1.ResourceRequirements{}
+	if explicit != nil {
+		resources = *explicit.DeepCopy()
+	}
+	for _, resourceName := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		if hasExplicitResource(explicit, resourceName) {
+			continue
 		}
-		resources.Requests = corev1.ResourceList{
-			corev1.ResourceCPU: *resource.NewMilliQuantity(int64(cpu*requestsPercentage), resource.BinarySI),
+		total, ok := tempo.Spec.Resources.Total.Limits[resourceName]
+		if !ok {
+			continue
 		}
-	}
 
-	totalMemory, ok := tempo.Spec.Resources.Total.Limits[corev1.ResourceMemory]
-	if ok {
+		limit, request := shareOfTotal(tempo, resourcesMap, componentResources, resourceName, total)
 		if resources.Limits == nil {
 			resources.Limits = corev1.ResourceList{}
 		}
 		if resources.Requests == nil {
 			resources.Requests = corev1.ResourceList{}
 		}
-		totalMemoryInt := totalMemory.Value()
-		mem := float32(totalMemoryInt) * componentResources.memory
-		resources.Limits[corev1.ResourceMemory] = *resource.NewQuantity(int64(mem), resource.BinarySI)
-		resources.Requests[corev1.ResourceMemory] = *resource.NewQuantity(int64(mem*0.3), resource.BinarySI)
+		resources.Limits[resourceName] = limit
+		resources.Requests[resourceName] = request
 	}
 	return resources
 }
+
+// shareOfTotal returns the limit and request of a resource of a component, which are derived from the total resources.
+// The explicit resources of all containers are subtracted from the total resources,
+// and the remaining resources are split across the components without explicit resources according to their ratio.
+func shareOfTotal(tempo v1alpha1.TempoStack, resourcesMap map[string]componentResource, componentResources componentResource,
+	resourceName corev1.ResourceName, total resource.Quantity) (resource.Quantity, resource.Quantity) {
+	ratioOf := func(r componentResource) float32 {
+		if resourceName == corev1.ResourceCPU {
+			return r.cpu
+		}
+		return r.memory
+	}
+
+	var totalRatio float32
+	hasExplicitResources := false
+	for name, r := range resourcesMap {
+		if hasExplicitResource(explicitResources(tempo, name), resourceName) {
+			hasExplicitResources = true
+			continue
+		}
+		totalRatio += ratioOf(r)
+	}
+
+	remaining := total.DeepCopy()
+	for _, explicit := range explicitContainerResources(tempo, resourcesMap) {
+		remaining.Sub(explicitQuantity(explicit, resourceName))
+	}
+	if remaining.Sign() < 0 {
+		remaining = resource.Quantity{}
+	}
+
+	ratio := ratioOf(componentResources)
+	if hasExplicitResources {
+		ratio /= totalRatio
+	}
+
+	if resourceName == corev1.ResourceCPU {
+		cpu := float32(remaining.MilliValue()) * ratio
+		return *resource.NewMilliQuantity(int64(cpu), resource.BinarySI),
+			*resource.NewMilliQuantity(int64(cpu*requestsPercentage), resource.BinarySI)
+	}
+	mem := float32(remaining.Value()) * ratio
+	return *resource.NewQuantity(int64(mem), resource.BinarySI),
+		*resource.NewQuantity(int64(mem*requestsPercentage), resource.BinarySI)
+}
+
+// JaegerQueryResources returns the resource requirements of the tempo-query container.
+// Defaults to the resources of the query-frontend.
+func JaegerQueryResources(tempo v1alpha1.TempoStack) corev1.ResourceRequirements {
+	if tempo.Spec.Template.QueryFrontend.JaegerQuery.Resources != nil {
+		return *tempo.Spec.Template.QueryFrontend.JaegerQuery.Resources.DeepCopy()
+	}
+	return Resources(tempo, QueryFrontendComponentName)
+}
+
+// OPAResources returns the resource requirements of the opa-openshift container of the gateway.
+func OPAResources(tempo v1alpha1.TempoStack) corev1.ResourceRequirements {
+	if tempo.Spec.Template.Gateway.OPA.Resources != nil {
+		return *tempo.Spec.Template.Gateway.OPA.Resources.DeepCopy()
+	}
+	return corev1.ResourceRequirements{}
+}
+
+// explicitResources returns the resources defined in the spec of a component, or nil if they are not set.
+func explicitResources(tempo v1alpha1.TempoStack, component string) *corev1.ResourceRequirements {
+	switch component {
+	case DistributorComponentName:
+		return tempo.Spec.Template.Distributor.Resources
+	case IngesterComponentName:
+		return tempo.Spec.Template.Ingester.Resources
+	case CompactorComponentName:
+		return tempo.Spec.Template.Compactor.Resources
+	case QuerierComponentName:
+		return tempo.Spec.Template.Querier.Resources
+	case QueryFrontendComponentName:
+		return tempo.Spec.Template.QueryFrontend.Resources
+	case GatewayComponentName:
+		return tempo.Spec.Template.Gateway.Resources
+	case MetricsGeneratorComponentName:
+		return tempo.Spec.Template.MetricsGenerator.Resources
+	case MemcachedComponentName:
+		return &tempo.Spec.Cache.Managed.Resources
+	}
+	return nil
+}
+
+// explicitContainerResources returns the explicit resources of all containers, which are deducted from the total resources.
+// Besides the components, this includes the tempo-query container of the query-frontend and the opa-openshift container of the gateway.
+func explicitContainerResources(tempo v1alpha1.TempoStack, resourcesMap map[string]componentResource) []corev1.ResourceRequirements {
+	var explicit []corev1.ResourceRequirements
+	for name := range resourcesMap {
+		if r := explicitResources(tempo, name); r != nil {
+			explicit = append(explicit, *r)
+		}
+	}
+	if r := tempo.Spec.Template.QueryFrontend.JaegerQuery.Resources; r != nil {
+		explicit = append(explicit, *r)
+	}
+	if r := tempo.Spec.Template.Gateway.OPA.Resources; r != nil {
+		explicit = append(explicit, *r)
+	}
+	return explicit
+}
+
+// hasExplicitResource returns true if the explicit resources set a limit or request of a resource.
+func hasExplicitResource(resources *corev1.ResourceRequirements, name corev1.ResourceName) bool {
+	if resources == nil {
+		return false
+	}
+	_, hasLimit := resources.Limits[name]
+	_, hasRequest := resources.Requests[name]
+	return hasLimit || hasRequest
+}
+
+// explicitQuantity returns the limit of a resource, or the request if no limit is set.
+func explicitQuantity(resources corev1.ResourceRequirements, name corev1.ResourceName) resource.Quantity {
+	if limit, ok := resources.Limits[name]; ok {
+		return limit
+	}
+	return resources.Requests[name]
+}
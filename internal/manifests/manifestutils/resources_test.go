@@ -155,3 +155,124 @@ func TestResources(t *testing.T) {
 		})
 	}
 }
+
+func TestResourcesExplicit(t *testing.T) {
+	ingesterResources := corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("500m"),
+			corev1.ResourceMemory: resource.MustParse("1Gi"),
+		},
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("500m"),
+			corev1.ResourceMemory: resource.MustParse("1Gi"),
+		},
+	}
+	jaegerQueryResources := corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("256Mi"),
+		},
+	}
+	opaResources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU: resource.MustParse("10m"),
+		},
+	}
+	tempo := v1alpha1.TempoStack{
+		Spec: v1alpha1.TempoStackSpec{
+			Resources: v1alpha1.Resources{
+				Total: &corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("2Gi"),
+						corev1.ResourceCPU:    resource.MustParse("1000m"),
+					},
+				},
+			},
+			Template: v1alpha1.TempoTemplateSpec{
+				Ingester: v1alpha1.TempoIngesterSpec{
+					TempoComponentSpec: v1alpha1.TempoComponentSpec{
+						Resources: &ingesterResources,
+					},
+				},
+				QueryFrontend: v1alpha1.TempoQueryFrontendSpec{
+					JaegerQuery: v1alpha1.JaegerQuerySpec{
+						Resources: &jaegerQueryResources,
+					},
+				},
+				Gateway: v1alpha1.TempoGatewaySpec{
+					OPA: v1alpha1.GatewayOPASpec{
+						Resources: &opaResources,
+					},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, ingesterResources, Resources(tempo, IngesterComponentName))
+	assert.Equal(t, jaegerQueryResources, JaegerQueryResources(tempo))
+	assert.Equal(t, opaResources, OPAResources(tempo))
+
+	// the resources of the ingester, tempo-query and opa-openshift containers are subtracted from the total resources,
+	// and the remaining 490m CPU and 768Mi memory are split across the other components
+	assert.Equal(t, corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    *resource.NewMilliQuantity(213, resource.BinarySI),
+			corev1.ResourceMemory: *resource.NewQuantity(193273520, resource.BinarySI),
+		},
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    *resource.NewMilliQuantity(64, resource.BinarySI),
+			corev1.ResourceMemory: *resource.NewQuantity(57982060, resource.BinarySI),
+		},
+	}, Resources(tempo, DistributorComponentName))
+
+	// components without explicit resources are not part of the split
+	assert.Equal(t, corev1.ResourceRequirements{}, Resources(tempo, MetricsGeneratorComponentName))
+}
+
+func TestResourcesExplicitCPUOnly(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		Spec: v1alpha1.TempoStackSpec{
+			Resources: v1alpha1.Resources{
+				Total: &corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("2Gi"),
+						corev1.ResourceCPU:    resource.MustParse("1000m"),
+					},
+				},
+			},
+			Template: v1alpha1.TempoTemplateSpec{
+				Ingester: v1alpha1.TempoIngesterSpec{
+					TempoComponentSpec: v1alpha1.TempoComponentSpec{
+						Resources: &corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("500m"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// the memory of the ingester is its share of the total memory
+	assert.Equal(t, corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("500m"),
+			corev1.ResourceMemory: *resource.NewQuantity(1073741824, resource.BinarySI),
+		},
+		Requests: corev1.ResourceList{
+			corev1.ResourceMemory: *resource.NewQuantity(322122560, resource.BinarySI),
+		},
+	}, Resources(tempo, IngesterComponentName))
+
+	// the remaining 500m CPU are split across the other components, the memory split is unchanged
+	assert.Equal(t, corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    *resource.NewMilliQuantity(217, resource.BinarySI),
+			corev1.ResourceMemory: *resource.NewQuantity(257698032, resource.BinarySI),
+		},
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    *resource.NewMilliQuantity(65, resource.BinarySI),
+			corev1.ResourceMemory: *resource.NewQuantity(77309416, resource.BinarySI),
+		},
+	}, Resources(tempo, DistributorComponentName))
+}
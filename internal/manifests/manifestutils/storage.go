@@ -6,12 +6,25 @@ import (
 	"github.com/ViaQ/logerr/v2/kverrors"
 	"github.com/imdario/mergo"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
 
 	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
 )
 
 const (
 	storageCAVolumeName = "storage-ca"
+
+	storageTokenVolumeName = "storage-token"
+	// storageTokenDir is the mount path of the projected service account token,
+	// which is exchanged for short-lived credentials of the object storage.
+	storageTokenDir = "/var/run/secrets/storage/serviceaccount" // nolint #nosec
+	// storageTokenExpirationSeconds is the lifetime of the projected service account token.
+	// The kubelet refreshes the token before it expires.
+	storageTokenExpirationSeconds = 3600
+	// awsSTSAudience is the audience of the service account token accepted by AWS STS.
+	awsSTSAudience = "sts.amazonaws.com"
+	// azureTokenExchangeAudience is the audience of the service account token accepted by Microsoft Entra ID.
+	azureTokenExchangeAudience = "api://AzureADTokenExchange"
 )
 
 // TempoStorageTLSDir returns the mount path of certificates for connecting to object storage.
@@ -24,7 +37,11 @@ func TempoStorageTLSCAPath() string {
 	return path.Join(TempoStorageTLSDir(), "ca.crt")
 }
 
-func configureAzureStorage(tempo *v1alpha1.TempoStack, pod *corev1.PodSpec) error {
+func configureAzureStorage(storage *v1alpha1.ObjectStorageSpec, pod *corev1.PodSpec) error {
+	if storage.CredentialMode == v1alpha1.CredentialModeToken {
+		return configureAzureTokenStorage(storage, pod)
+	}
+
 	var envVars []corev1.EnvVar = []corev1.EnvVar{
 		{
 			Name: "AZURE_ACCOUNT_NAME",
@@ -32,7 +49,7 @@ func configureAzureStorage(tempo *v1alpha1.TempoStack, pod *corev1.PodSpec) erro
 				SecretKeyRef: &corev1.SecretKeySelector{
 					Key: "account_name",
 					LocalObjectReference: corev1.LocalObjectReference{
-						Name: tempo.Spec.Storage.Secret.Name,
+						Name: storage.Secret.Name,
 					},
 				},
 			},
@@ -43,7 +60,7 @@ func configureAzureStorage(tempo *v1alpha1.TempoStack, pod *corev1.PodSpec) erro
 				SecretKeyRef: &corev1.SecretKeySelector{
 					Key: "account_key",
 					LocalObjectReference: corev1.LocalObjectReference{
-						Name: tempo.Spec.Storage.Secret.Name,
+						Name: storage.Secret.Name,
 					},
 				},
 			},
@@ -64,7 +81,69 @@ func configureAzureStorage(tempo *v1alpha1.TempoStack, pod *corev1.PodSpec) erro
 	return nil
 }
 
-func configureGCS(tempo *v1alpha1.TempoStack, pod *corev1.PodSpec) error {
+// configureAzureTokenStorage configures Azure Workload Identity, i.e. the Azure SDK exchanges
+// a projected service account token for an access token of the managed identity.
+func configureAzureTokenStorage(storage *v1alpha1.ObjectStorageSpec, pod *corev1.PodSpec) error {
+	secretKeyRef := func(key string) *corev1.EnvVarSource {
+		return &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				Key: key,
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: storage.Secret.Name,
+				},
+			},
+		}
+	}
+
+	var envVars []corev1.EnvVar = []corev1.EnvVar{
+		{
+			Name:      "AZURE_ACCOUNT_NAME",
+			ValueFrom: secretKeyRef("account_name"),
+		},
+		{
+			Name:      "AZURE_CLIENT_ID",
+			ValueFrom: secretKeyRef("client_id"),
+		},
+		{
+			Name:      "AZURE_TENANT_ID",
+			ValueFrom: secretKeyRef("tenant_id"),
+		},
+		{
+			Name:  "AZURE_FEDERATED_TOKEN_FILE",
+			Value: TempoStorageTokenPath(),
+		},
+	}
+	args := []string{
+		"--storage.trace.azure.storage_account_name=$(AZURE_ACCOUNT_NAME)",
+	}
+
+	ingesterContainer := pod.Containers[0].DeepCopy()
+	ingesterContainer.Env = append(ingesterContainer.Env, envVars...)
+	ingesterContainer.Args = append(ingesterContainer.Args, args...)
+	ingesterContainer.VolumeMounts = append(ingesterContainer.VolumeMounts, corev1.VolumeMount{
+		Name:      storageTokenVolumeName,
+		MountPath: storageTokenDir,
+		ReadOnly:  true,
+	})
+	pod.Volumes = append(pod.Volumes, storageTokenVolume(azureTokenExchangeAudience))
+
+	if err := mergo.Merge(&pod.Containers[0], ingesterContainer, mergo.WithOverride); err != nil {
+		return kverrors.Wrap(err, "failed to merge ingester container spec")
+	}
+	return nil
+}
+
+func configureGCS(storage *v1alpha1.ObjectStorageSpec, gcs *GCS, pod *corev1.PodSpec) error {
+	if storage.CredentialMode == v1alpha1.CredentialModeToken {
+		return configureGCSTokenStorage(storage, gcs, pod)
+	}
+
+	return configureGCSCredentials(storage, pod)
+}
+
+// configureGCSCredentials mounts the credentials file (key.json) of the storage secret
+// and configures the Google Cloud SDK to use it.
+func configureGCSCredentials(storage *v1alpha1.ObjectStorageSpec, pod *corev1.PodSpec) error {
 	secretDirectory := "/etc/storage/secrets/" // nolint #nosec
 	secretFile := path.Join(secretDirectory, "key.json")
 
@@ -77,7 +156,7 @@ func configureGCS(tempo *v1alpha1.TempoStack, pod *corev1.PodSpec) error {
 
 	var volumeMounts []corev1.VolumeMount = []corev1.VolumeMount{
 		{
-			Name:      tempo.Spec.Storage.Secret.Name,
+			Name:      storage.Secret.Name,
 			ReadOnly:  false,
 			MountPath: secretDirectory,
 		},
@@ -85,10 +164,10 @@ func configureGCS(tempo *v1alpha1.TempoStack, pod *corev1.PodSpec) error {
 
 	var volumes []corev1.Volume = []corev1.Volume{
 		{
-			Name: tempo.Spec.Storage.Secret.Name,
+			Name: storage.Secret.Name,
 			VolumeSource: corev1.VolumeSource{
 				Secret: &corev1.SecretVolumeSource{
-					SecretName: tempo.Spec.Storage.Secret.Name,
+					SecretName: storage.Secret.Name,
 				},
 			},
 		},
@@ -106,7 +185,58 @@ func configureGCS(tempo *v1alpha1.TempoStack, pod *corev1.PodSpec) error {
 	return nil
 }
 
-func configureS3Storage(tempo *v1alpha1.TempoStack, pod *corev1.PodSpec) error {
+// configureGCSTokenStorage configures GCP Workload Identity Federation, i.e. the Google Cloud SDK exchanges
+// a projected service account token for an access token using the credential configuration of the storage secret.
+// Without a credential configuration, GKE Workload Identity provides the credentials via the metadata server
+// and no further configuration of the pod is required.
+func configureGCSTokenStorage(storage *v1alpha1.ObjectStorageSpec, gcs *GCS, pod *corev1.PodSpec) error {
+	if gcs == nil || gcs.Audience == "" {
+		return nil
+	}
+
+	if err := configureGCSCredentials(storage, pod); err != nil {
+		return err
+	}
+
+	pod.Containers[0].VolumeMounts = append(pod.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      storageTokenVolumeName,
+		MountPath: storageTokenDir,
+		ReadOnly:  true,
+	})
+	pod.Volumes = append(pod.Volumes, storageTokenVolume(gcs.Audience))
+	return nil
+}
+
+// TempoStorageTokenPath returns the path of the projected service account token for the token credential mode.
+func TempoStorageTokenPath() string {
+	return path.Join(storageTokenDir, "token")
+}
+
+// storageTokenVolume returns a volume with a service account token projected for the given audience.
+func storageTokenVolume(audience string) corev1.Volume {
+	return corev1.Volume{
+		Name: storageTokenVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{
+					{
+						ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+							Audience:          audience,
+							ExpirationSeconds: ptr.To(int64(storageTokenExpirationSeconds)),
+							Path:              "token",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func configureS3Storage(storage *v1alpha1.ObjectStorageSpec, pod *corev1.PodSpec) error {
+	if storage.CredentialMode == v1alpha1.CredentialModeToken {
+		return configureS3TokenStorage(storage, pod)
+	}
+
 	var envVars []corev1.EnvVar = []corev1.EnvVar{
 		{
 			Name: "S3_SECRET_KEY",
@@ -114,7 +244,7 @@ func configureS3Storage(tempo *v1alpha1.TempoStack, pod *corev1.PodSpec) error {
 				SecretKeyRef: &corev1.SecretKeySelector{
 					Key: "access_key_secret",
 					LocalObjectReference: corev1.LocalObjectReference{
-						Name: tempo.Spec.Storage.Secret.Name,
+						Name: storage.Secret.Name,
 					},
 				},
 			},
@@ -125,7 +255,7 @@ func configureS3Storage(tempo *v1alpha1.TempoStack, pod *corev1.PodSpec) error {
 				SecretKeyRef: &corev1.SecretKeySelector{
 					Key: "access_key_id",
 					LocalObjectReference: corev1.LocalObjectReference{
-						Name: tempo.Spec.Storage.Secret.Name,
+						Name: storage.Secret.Name,
 					},
 				},
 			},
@@ -136,9 +266,65 @@ func configureS3Storage(tempo *v1alpha1.TempoStack, pod *corev1.PodSpec) error {
 		"--storage.trace.s3.access_key=$(S3_ACCESS_KEY)",
 	}
 
+	volumeMounts, volumes := storageCAVolumes(storage)
+
+	ingesterContainer := pod.Containers[0].DeepCopy()
+	ingesterContainer.Env = append(ingesterContainer.Env, envVars...)
+	ingesterContainer.Args = append(ingesterContainer.Args, args...)
+	ingesterContainer.VolumeMounts = append(ingesterContainer.VolumeMounts, volumeMounts...)
+	pod.Volumes = append(pod.Volumes, volumes...)
+
+	if err := mergo.Merge(&pod.Containers[0], ingesterContainer, mergo.WithOverride); err != nil {
+		return kverrors.Wrap(err, "failed to merge ingester container spec")
+	}
+	return nil
+}
+
+// configureS3TokenStorage configures the AWS SDK to assume the IAM role of the storage secret
+// with a projected service account token (AWS STS AssumeRoleWithWebIdentity).
+func configureS3TokenStorage(storage *v1alpha1.ObjectStorageSpec, pod *corev1.PodSpec) error {
+	var envVars []corev1.EnvVar = []corev1.EnvVar{
+		{
+			Name: "AWS_ROLE_ARN",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					Key: "role_arn",
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: storage.Secret.Name,
+					},
+				},
+			},
+		},
+		{
+			Name:  "AWS_WEB_IDENTITY_TOKEN_FILE",
+			Value: TempoStorageTokenPath(),
+		},
+	}
+
+	volumeMounts, volumes := storageCAVolumes(storage)
+	volumeMounts = append(volumeMounts, corev1.VolumeMount{
+		Name:      storageTokenVolumeName,
+		MountPath: storageTokenDir,
+		ReadOnly:  true,
+	})
+	volumes = append(volumes, storageTokenVolume(awsSTSAudience))
+
+	ingesterContainer := pod.Containers[0].DeepCopy()
+	ingesterContainer.Env = append(ingesterContainer.Env, envVars...)
+	ingesterContainer.VolumeMounts = append(ingesterContainer.VolumeMounts, volumeMounts...)
+	pod.Volumes = append(pod.Volumes, volumes...)
+
+	if err := mergo.Merge(&pod.Containers[0], ingesterContainer, mergo.WithOverride); err != nil {
+		return kverrors.Wrap(err, "failed to merge ingester container spec")
+	}
+	return nil
+}
+
+// storageCAVolumes returns the volume and volume mount of the CA ConfigMap of the object storage, if configured.
+func storageCAVolumes(storage *v1alpha1.ObjectStorageSpec) ([]corev1.VolumeMount, []corev1.Volume) {
 	volumeMounts := []corev1.VolumeMount{}
 	volumes := []corev1.Volume{}
-	if tempo.Spec.Storage.TLS.CA != "" {
+	if storage.TLS.CA != "" {
 		volumeMounts = append(volumeMounts, corev1.VolumeMount{
 			Name:      storageCAVolumeName,
 			MountPath: TempoStorageTLSDir(),
@@ -149,39 +335,60 @@ func configureS3Storage(tempo *v1alpha1.TempoStack, pod *corev1.PodSpec) error {
 			VolumeSource: corev1.VolumeSource{
 				ConfigMap: &corev1.ConfigMapVolumeSource{
 					LocalObjectReference: corev1.LocalObjectReference{
-						Name: tempo.Spec.Storage.TLS.CA,
+						Name: storage.TLS.CA,
 					},
 				},
 			},
 		})
 	}
-
-	ingesterContainer := pod.Containers[0].DeepCopy()
-	ingesterContainer.Env = append(ingesterContainer.Env, envVars...)
-	ingesterContainer.Args = append(ingesterContainer.Args, args...)
-	ingesterContainer.VolumeMounts = append(ingesterContainer.VolumeMounts, volumeMounts...)
-	pod.Volumes = append(pod.Volumes, volumes...)
-
-	if err := mergo.Merge(&pod.Containers[0], ingesterContainer, mergo.WithOverride); err != nil {
-		return kverrors.Wrap(err, "failed to merge ingester container spec")
-	}
-	return nil
+	return volumeMounts, volumes
 }
 
 // ConfigureStorage configures storage.
-func ConfigureStorage(tempo v1alpha1.TempoStack, pod *corev1.PodSpec) error {
-	if tempo.Spec.Storage.Secret.Name != "" {
-		var configure func(*v1alpha1.TempoStack, *corev1.PodSpec) error
-		switch tempo.Spec.Storage.Secret.Type {
+func ConfigureStorage(params Params, pod *corev1.PodSpec) error {
+	return ConfigureObjectStorage(params.Tempo.Spec.Storage, params.StorageParams, pod)
+}
+
+// ConfigureObjectStorage configures the first container of the pod to access the given object storage.
+func ConfigureObjectStorage(storage v1alpha1.ObjectStorageSpec, storageParams StorageParams, pod *corev1.PodSpec) error {
+	if storage.Secret.Name != "" {
+		var configure func(*v1alpha1.ObjectStorageSpec, *corev1.PodSpec) error
+		switch storage.Secret.Type {
 		case v1alpha1.ObjectStorageSecretAzure:
 			configure = configureAzureStorage
 		case v1alpha1.ObjectStorageSecretGCS:
-			configure = configureGCS
+			configure = func(storage *v1alpha1.ObjectStorageSpec, pod *corev1.PodSpec) error {
+				return configureGCS(storage, storageParams.GCS, pod)
+			}
 		case v1alpha1.ObjectStorageSecretS3:
 			configure = configureS3Storage
 		}
 
-		return configure(&tempo, pod)
+		if err := configure(&storage, pod); err != nil {
+			return err
+		}
+		if storage.Secret.Type != v1alpha1.ObjectStorageSecretS3 {
+			configureStorageCATrust(&storage, pod)
+		}
 	}
 	return nil
 }
+
+// configureStorageCATrust adds the CA of the object storage to the trusted certificates of the first container.
+// The Azure and GCS backends of Tempo do not support a custom CA file, therefore the directory of the CA
+// is added to the certificate directories of the Go runtime. The system certificates remain trusted.
+func configureStorageCATrust(storage *v1alpha1.ObjectStorageSpec, pod *corev1.PodSpec) {
+	if storage.TLS.CA == "" {
+		return
+	}
+
+	volumeMounts, volumes := storageCAVolumes(storage)
+	// Like the other storage options, this relies on the Tempo container being the first container of the pod.
+	container := &pod.Containers[0]
+	container.Env = append(container.Env, corev1.EnvVar{
+		Name:  "SSL_CERT_DIR",
+		Value: TempoStorageTLSDir(),
+	})
+	container.VolumeMounts = append(container.VolumeMounts, volumeMounts...)
+	pod.Volumes = append(pod.Volumes, volumes...)
+}
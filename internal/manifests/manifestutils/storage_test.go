@@ -5,7 +5,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
 
 	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
 )
@@ -39,7 +41,7 @@ func TestConfigureAzureStorage(t *testing.T) {
 		},
 	}
 
-	assert.NoError(t, configureAzureStorage(&tempo, &pod))
+	assert.NoError(t, configureAzureStorage(&tempo.Spec.Storage, &pod))
 	assert.Len(t, pod.Containers[0].Env, 2)
 	assert.NoError(t, findEnvVar("AZURE_ACCOUNT_NAME", &pod.Containers[0].Env))
 	assert.NoError(t, findEnvVar("AZURE_ACCOUNT_KEY", &pod.Containers[0].Env))
@@ -49,6 +51,49 @@ func TestConfigureAzureStorage(t *testing.T) {
 	assert.Contains(t, pod.Containers[0].Args, "--storage.trace.azure.storage_account_key=$(AZURE_ACCOUNT_KEY)")
 }
 
+func TestConfigureAzureStorageToken(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		Spec: v1alpha1.TempoStackSpec{
+			Storage: v1alpha1.ObjectStorageSpec{
+				Secret: v1alpha1.ObjectStorageSecretSpec{
+					Name: "test",
+					Type: v1alpha1.ObjectStorageSecretAzure,
+				},
+				CredentialMode: v1alpha1.CredentialModeToken,
+			},
+		},
+	}
+
+	pod := corev1.PodSpec{
+		Containers: []corev1.Container{
+			{
+				Name: "ingester",
+			},
+		},
+	}
+
+	assert.NoError(t, configureAzureStorage(&tempo.Spec.Storage, &pod))
+	assert.Len(t, pod.Containers[0].Env, 4)
+	assert.NoError(t, findEnvVar("AZURE_ACCOUNT_NAME", &pod.Containers[0].Env))
+	assert.NoError(t, findEnvVar("AZURE_CLIENT_ID", &pod.Containers[0].Env))
+	assert.NoError(t, findEnvVar("AZURE_TENANT_ID", &pod.Containers[0].Env))
+	assert.Contains(t, pod.Containers[0].Env, corev1.EnvVar{
+		Name:  "AZURE_FEDERATED_TOKEN_FILE",
+		Value: "/var/run/secrets/storage/serviceaccount/token",
+	})
+	assert.Equal(t, []string{"--storage.trace.azure.storage_account_name=$(AZURE_ACCOUNT_NAME)"}, pod.Containers[0].Args)
+
+	assert.Equal(t, []corev1.VolumeMount{
+		{
+			Name:      "storage-token",
+			MountPath: "/var/run/secrets/storage/serviceaccount",
+			ReadOnly:  true,
+		},
+	}, pod.Containers[0].VolumeMounts)
+	require.Len(t, pod.Volumes, 1)
+	assert.Equal(t, "api://AzureADTokenExchange", pod.Volumes[0].Projected.Sources[0].ServiceAccountToken.Audience)
+}
+
 func TestGetGCSStorage(t *testing.T) {
 	tempo := v1alpha1.TempoStack{
 		Spec: v1alpha1.TempoStackSpec{
@@ -69,13 +114,72 @@ func TestGetGCSStorage(t *testing.T) {
 		},
 	}
 
-	assert.NoError(t, configureGCS(&tempo, &pod))
+	assert.NoError(t, configureGCS(&tempo.Spec.Storage, nil, &pod))
 	assert.Len(t, pod.Containers[0].Env, 1)
 	assert.NoError(t, findEnvVar("GOOGLE_APPLICATION_CREDENTIALS", &pod.Containers[0].Env))
 
 	assert.Len(t, pod.Containers[0].VolumeMounts, 1)
 }
 
+func TestGetGCSStorageToken(t *testing.T) {
+	storage := v1alpha1.ObjectStorageSpec{
+		Secret: v1alpha1.ObjectStorageSecretSpec{
+			Name: "test",
+			Type: v1alpha1.ObjectStorageSecretGCS,
+		},
+		CredentialMode: v1alpha1.CredentialModeToken,
+	}
+	audience := "//iam.googleapis.com/projects/1/locations/global/workloadIdentityPools/pool/providers/provider"
+
+	t.Run("workload identity federation", func(t *testing.T) {
+		pod := corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "ingester",
+				},
+			},
+		}
+
+		require.NoError(t, configureGCS(&storage, &GCS{Bucket: "bucket", Audience: audience}, &pod))
+		assert.Equal(t, []corev1.EnvVar{
+			{
+				Name:  "GOOGLE_APPLICATION_CREDENTIALS",
+				Value: "/etc/storage/secrets/key.json",
+			},
+		}, pod.Containers[0].Env)
+		assert.Equal(t, []corev1.VolumeMount{
+			{
+				Name:      "test",
+				MountPath: "/etc/storage/secrets/",
+			},
+			{
+				Name:      "storage-token",
+				MountPath: "/var/run/secrets/storage/serviceaccount",
+				ReadOnly:  true,
+			},
+		}, pod.Containers[0].VolumeMounts)
+		require.Len(t, pod.Volumes, 2)
+		assert.Equal(t, "test", pod.Volumes[0].Secret.SecretName)
+		assert.Equal(t, audience, pod.Volumes[1].Projected.Sources[0].ServiceAccountToken.Audience)
+		assert.Equal(t, v1alpha1.GCSCredentialSourceFile, TempoStorageTokenPath())
+	})
+
+	t.Run("GKE workload identity", func(t *testing.T) {
+		pod := corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "ingester",
+				},
+			},
+		}
+
+		require.NoError(t, configureGCS(&storage, &GCS{Bucket: "bucket"}, &pod))
+		assert.Empty(t, pod.Containers[0].Env)
+		assert.Empty(t, pod.Containers[0].VolumeMounts)
+		assert.Empty(t, pod.Volumes)
+	})
+}
+
 func TestGetS3Storage(t *testing.T) {
 	tempo := v1alpha1.TempoStack{
 		Spec: v1alpha1.TempoStackSpec{
@@ -96,7 +200,7 @@ func TestGetS3Storage(t *testing.T) {
 		},
 	}
 
-	assert.NoError(t, configureS3Storage(&tempo, &pod))
+	assert.NoError(t, configureS3Storage(&tempo.Spec.Storage, &pod))
 	assert.Len(t, pod.Containers[0].Env, 2)
 	assert.NoError(t, findEnvVar("S3_SECRET_KEY", &pod.Containers[0].Env))
 	assert.NoError(t, findEnvVar("S3_ACCESS_KEY", &pod.Containers[0].Env))
@@ -106,6 +210,74 @@ func TestGetS3Storage(t *testing.T) {
 	assert.Contains(t, pod.Containers[0].Args, "--storage.trace.s3.access_key=$(S3_ACCESS_KEY)")
 }
 
+func TestGetS3StorageToken(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		Spec: v1alpha1.TempoStackSpec{
+			Storage: v1alpha1.ObjectStorageSpec{
+				Secret: v1alpha1.ObjectStorageSecretSpec{
+					Name: "test",
+					Type: v1alpha1.ObjectStorageSecretS3,
+				},
+				CredentialMode: v1alpha1.CredentialModeToken,
+			},
+		},
+	}
+
+	pod := corev1.PodSpec{
+		Containers: []corev1.Container{
+			{
+				Name: "ingester",
+			},
+		},
+	}
+
+	assert.NoError(t, configureS3Storage(&tempo.Spec.Storage, &pod))
+	assert.Equal(t, []corev1.EnvVar{
+		{
+			Name: "AWS_ROLE_ARN",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					Key: "role_arn",
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: "test",
+					},
+				},
+			},
+		},
+		{
+			Name:  "AWS_WEB_IDENTITY_TOKEN_FILE",
+			Value: "/var/run/secrets/storage/serviceaccount/token",
+		},
+	}, pod.Containers[0].Env)
+	assert.Empty(t, pod.Containers[0].Args)
+
+	assert.Equal(t, []corev1.VolumeMount{
+		{
+			Name:      "storage-token",
+			MountPath: "/var/run/secrets/storage/serviceaccount",
+			ReadOnly:  true,
+		},
+	}, pod.Containers[0].VolumeMounts)
+	assert.Equal(t, []corev1.Volume{
+		{
+			Name: "storage-token",
+			VolumeSource: corev1.VolumeSource{
+				Projected: &corev1.ProjectedVolumeSource{
+					Sources: []corev1.VolumeProjection{
+						{
+							ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+								Audience:          "sts.amazonaws.com",
+								ExpirationSeconds: ptr.To(int64(3600)),
+								Path:              "token",
+							},
+						},
+					},
+				},
+			},
+		},
+	}, pod.Volumes)
+}
+
 func TestGetS3StorageWithCA(t *testing.T) {
 	tempo := v1alpha1.TempoStack{
 		Spec: v1alpha1.TempoStackSpec{
@@ -129,7 +301,7 @@ func TestGetS3StorageWithCA(t *testing.T) {
 		},
 	}
 
-	assert.NoError(t, configureS3Storage(&tempo, &pod))
+	assert.NoError(t, configureS3Storage(&tempo.Spec.Storage, &pod))
 	assert.Equal(t, []corev1.Volume{
 		{
 			Name: storageCAVolumeName,
@@ -227,9 +399,53 @@ func TestConfigureStorage(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			assert.NoError(t, ConfigureStorage(test.tempo, &test.pod))
+			assert.NoError(t, ConfigureStorage(Params{Tempo: test.tempo}, &test.pod))
 			assert.NoError(t, findEnvVar(test.envName, &test.pod.Containers[0].Env))
 		})
 	}
 
 }
+
+func TestConfigureStorageCATrust(t *testing.T) {
+	for _, storageType := range []v1alpha1.ObjectStorageSecretType{v1alpha1.ObjectStorageSecretAzure, v1alpha1.ObjectStorageSecretGCS} {
+		t.Run(string(storageType), func(t *testing.T) {
+			storage := v1alpha1.ObjectStorageSpec{
+				Secret: v1alpha1.ObjectStorageSecretSpec{
+					Name: "test",
+					Type: storageType,
+				},
+				TLS: v1alpha1.ObjectStorageTLSSpec{
+					CA: "customca",
+				},
+			}
+			pod := corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name: "ingester",
+					},
+				},
+			}
+
+			assert.NoError(t, ConfigureObjectStorage(storage, StorageParams{}, &pod))
+			assert.Contains(t, pod.Containers[0].Env, corev1.EnvVar{
+				Name:  "SSL_CERT_DIR",
+				Value: "/var/run/tls/storage",
+			})
+			assert.Contains(t, pod.Containers[0].VolumeMounts, corev1.VolumeMount{
+				Name:      storageCAVolumeName,
+				MountPath: "/var/run/tls/storage",
+				ReadOnly:  true,
+			})
+			assert.Contains(t, pod.Volumes, corev1.Volume{
+				Name: storageCAVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: "customca",
+						},
+					},
+				},
+			})
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package manifestutils
+
+import (
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+const (
+	// ZoneLabel is the label of the ingester pods of an availability zone.
+	ZoneLabel = "tempo.grafana.com/zone"
+	// AvailabilityZoneEnvVar declares the environment variable containing the availability zone of an ingester.
+	// The variable is expanded in the Tempo configuration file.
+	AvailabilityZoneEnvVar = "TEMPO_AVAILABILITY_ZONE"
+)
+
+// ZoneReplicas distributes the replicas of a component evenly across the zones
+// and returns the number of replicas of the zone at index i.
+// The first zones get an additional replica if the replicas cannot be divided evenly.
+func ZoneReplicas(replicas *int32, zones []v1alpha1.ZoneSpec, i int) *int32 {
+	if replicas == nil || len(zones) == 0 {
+		return replicas
+	}
+
+	n := int32(len(zones))
+	zoneReplicas := *replicas / n
+	if int32(i) < *replicas%n {
+		zoneReplicas++
+	}
+	return &zoneReplicas
+}
@@ -0,0 +1,20 @@
+package manifestutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+func TestZoneReplicas(t *testing.T) {
+	zones := []v1alpha1.ZoneSpec{{Name: "a"}, {Name: "b"}, {Name: "c"}}
+
+	assert.Equal(t, ptr.To(int32(2)), ZoneReplicas(ptr.To(int32(5)), zones, 0))
+	assert.Equal(t, ptr.To(int32(2)), ZoneReplicas(ptr.To(int32(5)), zones, 1))
+	assert.Equal(t, ptr.To(int32(1)), ZoneReplicas(ptr.To(int32(5)), zones, 2))
+	assert.Equal(t, ptr.To(int32(1)), ZoneReplicas(ptr.To(int32(3)), zones, 2))
+	assert.Nil(t, ZoneReplicas(nil, zones, 0))
+}
@@ -11,10 +11,6 @@ import (
 	"github.com/grafana/tempo-operator/internal/manifests/naming"
 )
 
-const (
-	componentName = "gossip-ring"
-)
-
 var (
 	// GossipSelector declares the labels for each gossip member.
 	GossipSelector = map[string]string{"tempo-gossip-member": "true"}
@@ -22,12 +18,12 @@ var (
 
 // BuildGossip creates Kubernetes objects that are needed for memberlist.
 func BuildGossip(tempo v1alpha1.TempoStack) *corev1.Service {
-	labels := manifestutils.ComponentLabels(componentName, tempo.Name)
+	labels := manifestutils.ComponentLabels(manifestutils.GossipComponentName, tempo.Name)
 	selector := k8slabels.Merge(manifestutils.CommonLabels(tempo.Name), GossipSelector)
 
 	return &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      naming.Name(componentName, tempo.Name),
+			Name:      naming.Name(manifestutils.GossipComponentName, tempo.Name),
 			Namespace: tempo.Namespace,
 			Labels:    labels,
 		},
@@ -0,0 +1,138 @@
+package memcached
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+)
+
+// BuildMemcached creates the objects of the memcached instance managed by the operator.
+func BuildMemcached(params manifestutils.Params) []client.Object {
+	return []client.Object{statefulSet(params), service(params.Tempo)}
+}
+
+func statefulSet(params manifestutils.Params) *v1.StatefulSet {
+	tempo := params.Tempo
+	labels := manifestutils.ComponentLabels(manifestutils.MemcachedComponentName, tempo.Name)
+	cfg := tempo.Spec.Cache.Managed
+
+	image := tempo.Spec.Images.Memcached
+	if image == "" {
+		image = params.CtrlConfig.DefaultImages.Memcached
+	}
+	exporterImage := tempo.Spec.Images.MemcachedExporter
+	if exporterImage == "" {
+		exporterImage = params.CtrlConfig.DefaultImages.MemcachedExporter
+	}
+
+	return &v1.StatefulSet{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.Name(manifestutils.MemcachedComponentName, tempo.Name),
+			Namespace: tempo.Namespace,
+			Labels:    labels,
+		},
+		Spec: v1.StatefulSetSpec{
+			Replicas: cfg.Replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			ServiceName:         naming.Name(manifestutils.MemcachedComponentName, tempo.Name),
+			PodManagementPolicy: v1.ParallelPodManagement,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: tempo.Spec.ServiceAccount,
+					NodeSelector:       cfg.NodeSelector,
+					Tolerations:        cfg.Tolerations,
+					Containers: []corev1.Container{
+						{
+							Name:  "memcached",
+							Image: image,
+							Args: []string{
+								fmt.Sprintf("--memory-limit=%d", cfg.MemoryLimitMB),
+								"--max-item-size=1m",
+							},
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          manifestutils.MemcachedPortName,
+									ContainerPort: manifestutils.PortMemcached,
+									Protocol:      corev1.ProtocolTCP,
+								},
+							},
+							ReadinessProbe: &corev1.Probe{
+								ProbeHandler: corev1.ProbeHandler{
+									TCPSocket: &corev1.TCPSocketAction{
+										Port: intstr.FromString(manifestutils.MemcachedPortName),
+									},
+								},
+								InitialDelaySeconds: 5,
+								TimeoutSeconds:      1,
+							},
+							Resources:       manifestutils.Resources(tempo, manifestutils.MemcachedComponentName),
+							SecurityContext: manifestutils.TempoContainerSecurityContext(),
+						},
+						{
+							Name:  "exporter",
+							Image: exporterImage,
+							Args: []string{
+								fmt.Sprintf("--memcached.address=localhost:%d", manifestutils.PortMemcached),
+								fmt.Sprintf("--web.listen-address=0.0.0.0:%d", manifestutils.PortMemcachedMetrics),
+							},
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          manifestutils.MemcachedMetricsPortName,
+									ContainerPort: manifestutils.PortMemcachedMetrics,
+									Protocol:      corev1.ProtocolTCP,
+								},
+							},
+							SecurityContext: manifestutils.TempoContainerSecurityContext(),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func service(tempo v1alpha1.TempoStack) *corev1.Service {
+	labels := manifestutils.ComponentLabels(manifestutils.MemcachedComponentName, tempo.Name)
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.Name(manifestutils.MemcachedComponentName, tempo.Name),
+			Namespace: tempo.Namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			// Headless service, Tempo resolves the addresses of all memcached pods.
+			ClusterIP: "None",
+			Ports: []corev1.ServicePort{
+				{
+					Name:       manifestutils.MemcachedPortName,
+					Protocol:   corev1.ProtocolTCP,
+					Port:       manifestutils.PortMemcached,
+					TargetPort: intstr.FromString(manifestutils.MemcachedPortName),
+				},
+				{
+					Name:       manifestutils.MemcachedMetricsPortName,
+					Protocol:   corev1.ProtocolTCP,
+					Port:       manifestutils.PortMemcachedMetrics,
+					TargetPort: intstr.FromString(manifestutils.MemcachedMetricsPortName),
+				},
+			},
+			Selector: labels,
+		},
+	}
+}
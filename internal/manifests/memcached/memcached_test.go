@@ -0,0 +1,183 @@
+package memcached
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func TestBuildMemcached(t *testing.T) {
+	resources := corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("1200Mi"),
+		},
+	}
+	objects := BuildMemcached(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "project1",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				ServiceAccount: "tempo-test-serviceaccount",
+				Cache: v1alpha1.CacheSpec{
+					Backend: v1alpha1.CacheBackendMemcached,
+					Managed: v1alpha1.ManagedCacheSpec{
+						TempoComponentSpec: v1alpha1.TempoComponentSpec{
+							Replicas:     ptr.To(int32(3)),
+							NodeSelector: map[string]string{"a": "b"},
+						},
+						Enabled:       true,
+						MemoryLimitMB: 1024,
+						Resources:     resources,
+					},
+				},
+			},
+		},
+		CtrlConfig: configv1alpha1.ProjectConfig{
+			DefaultImages: configv1alpha1.ImagesSpec{
+				Memcached:         "docker.io/memcached:1.6.23-alpine",
+				MemcachedExporter: "quay.io/prometheus/memcached-exporter:v0.14.2",
+			},
+		},
+	})
+
+	labels := manifestutils.ComponentLabels("memcached", "test")
+	require.Len(t, objects, 2)
+
+	assert.Equal(t, &v1.StatefulSet{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tempo-test-memcached",
+			Namespace: "project1",
+			Labels:    labels,
+		},
+		Spec: v1.StatefulSetSpec{
+			Replicas: ptr.To(int32(3)),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			ServiceName:         "tempo-test-memcached",
+			PodManagementPolicy: v1.ParallelPodManagement,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: "tempo-test-serviceaccount",
+					NodeSelector:       map[string]string{"a": "b"},
+					Containers: []corev1.Container{
+						{
+							Name:  "memcached",
+							Image: "docker.io/memcached:1.6.23-alpine",
+							Args: []string{
+								"--memory-limit=1024",
+								"--max-item-size=1m",
+							},
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          "memcached",
+									ContainerPort: 11211,
+									Protocol:      corev1.ProtocolTCP,
+								},
+							},
+							ReadinessProbe: &corev1.Probe{
+								ProbeHandler: corev1.ProbeHandler{
+									TCPSocket: &corev1.TCPSocketAction{
+										Port: intstr.FromString("memcached"),
+									},
+								},
+								InitialDelaySeconds: 5,
+								TimeoutSeconds:      1,
+							},
+							Resources:       resources,
+							SecurityContext: manifestutils.TempoContainerSecurityContext(),
+						},
+						{
+							Name:  "exporter",
+							Image: "quay.io/prometheus/memcached-exporter:v0.14.2",
+							Args: []string{
+								"--memcached.address=localhost:11211",
+								"--web.listen-address=0.0.0.0:9150",
+							},
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          "http-metrics",
+									ContainerPort: 9150,
+									Protocol:      corev1.ProtocolTCP,
+								},
+							},
+							SecurityContext: manifestutils.TempoContainerSecurityContext(),
+						},
+					},
+				},
+			},
+		},
+	}, objects[0])
+
+	assert.Equal(t, &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tempo-test-memcached",
+			Namespace: "project1",
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			ClusterIP: "None",
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "memcached",
+					Protocol:   corev1.ProtocolTCP,
+					Port:       11211,
+					TargetPort: intstr.FromString("memcached"),
+				},
+				{
+					Name:       "http-metrics",
+					Protocol:   corev1.ProtocolTCP,
+					Port:       9150,
+					TargetPort: intstr.FromString("http-metrics"),
+				},
+			},
+			Selector: labels,
+		},
+	}, objects[1])
+}
+
+func TestBuildMemcachedImageOverride(t *testing.T) {
+	objects := BuildMemcached(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "project1",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Images: configv1alpha1.ImagesSpec{
+					Memcached:         "memcached:custom",
+					MemcachedExporter: "exporter:custom",
+				},
+			},
+		},
+		CtrlConfig: configv1alpha1.ProjectConfig{
+			DefaultImages: configv1alpha1.ImagesSpec{
+				Memcached:         "docker.io/memcached:1.6.23-alpine",
+				MemcachedExporter: "quay.io/prometheus/memcached-exporter:v0.14.2",
+			},
+		},
+	})
+
+	sts := objects[0].(*v1.StatefulSet)
+	assert.Equal(t, "memcached:custom", sts.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, "exporter:custom", sts.Spec.Template.Spec.Containers[1].Image)
+}
@@ -0,0 +1,221 @@
+package metricsgenerator
+
+import (
+	"fmt"
+
+	"github.com/operator-framework/operator-lib/proxy"
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/memberlist"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+)
+
+const remoteWriteVolumeName = "remote-write"
+
+// BuildMetricsGenerator creates metrics-generator objects.
+func BuildMetricsGenerator(params manifestutils.Params) ([]client.Object, error) {
+	d, err := deployment(params)
+	if err != nil {
+		return nil, err
+	}
+	d.Spec.Template, err = manifestutils.PatchTracingJaegerEnv(params.Tempo, d.Spec.Template)
+	if err != nil {
+		return nil, err
+	}
+	gates := params.CtrlConfig.Gates
+	tempo := params.Tempo
+	if gates.HTTPEncryption || gates.GRPCEncryption {
+		caBundleName := naming.SigningCABundleName(tempo.Name)
+		if err := manifestutils.ConfigureServiceCA(&d.Spec.Template.Spec, caBundleName); err != nil {
+			return nil, err
+		}
+
+		if err := manifestutils.ConfigureServicePKI(tempo.Name, manifestutils.MetricsGeneratorComponentName, &d.Spec.Template.Spec); err != nil {
+			return nil, err
+		}
+	}
+
+	if tempo.Spec.Template.MetricsGenerator.RemoteWrite.Secret != "" {
+		configureRemoteWriteSecret(tempo.Spec.Template.MetricsGenerator.RemoteWrite.Secret, params.RemoteWrite, &d.Spec.Template.Spec)
+	}
+
+	d.Spec.Template, err = manifestutils.PatchPodTemplate(tempo.Spec.Template.MetricsGenerator.PodTemplate, d.Spec.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	return []client.Object{d, service(tempo)}, nil
+}
+
+func deployment(params manifestutils.Params) (*v1.Deployment, error) {
+	tempo := params.Tempo
+	labels := manifestutils.ComponentLabels(manifestutils.MetricsGeneratorComponentName, tempo.Name)
+	annotations := manifestutils.StorageAnnotations(params.ConfigChecksum, params.StorageParams)
+	cfg := tempo.Spec.Template.MetricsGenerator
+	image := tempo.Spec.Images.Tempo
+	if image == "" {
+		image = params.CtrlConfig.DefaultImages.Tempo
+	}
+
+	d := &v1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.Name(manifestutils.MetricsGeneratorComponentName, tempo.Name),
+			Namespace: tempo.Namespace,
+			Labels:    labels,
+		},
+		Spec: v1.DeploymentSpec{
+			Replicas: cfg.Replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      k8slabels.Merge(labels, memberlist.GossipSelector),
+					Annotations: annotations,
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: tempo.Spec.ServiceAccount,
+					NodeSelector:       cfg.NodeSelector,
+					Tolerations:        cfg.Tolerations,
+					Containers: []corev1.Container{
+						{
+							Name:  "tempo",
+							Image: image,
+							Env:   proxy.ReadProxyVarsFromEnv(),
+							Args: []string{
+								"-target=metrics-generator",
+								"-config.file=/conf/tempo.yaml",
+								"-config.expand-env=true",
+								fmt.Sprintf("-log.level=%s", manifestutils.LogLevel(cfg.LogLevel)),
+							},
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          manifestutils.HttpPortName,
+									ContainerPort: manifestutils.PortHTTPServer,
+									Protocol:      corev1.ProtocolTCP,
+								},
+								{
+									Name:          manifestutils.GrpcPortName,
+									ContainerPort: manifestutils.PortGRPCServer,
+									Protocol:      corev1.ProtocolTCP,
+								},
+								{
+									Name:          manifestutils.HttpMemberlistPortName,
+									ContainerPort: manifestutils.PortMemberlist,
+									Protocol:      corev1.ProtocolTCP,
+								},
+							},
+							ReadinessProbe: manifestutils.TempoReadinessProbe(params.CtrlConfig.Gates.HTTPEncryption),
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      manifestutils.ConfigVolumeName,
+									MountPath: "/conf",
+									ReadOnly:  true,
+								},
+								{
+									Name:      manifestutils.TmpStorageVolumeName,
+									MountPath: manifestutils.TmpStoragePath,
+								},
+							},
+							Resources:       manifestutils.Resources(tempo, manifestutils.MetricsGeneratorComponentName),
+							SecurityContext: manifestutils.TempoContainerSecurityContext(),
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: manifestutils.ConfigVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: naming.Name("", tempo.Name),
+									},
+								},
+							},
+						},
+						{
+							Name: manifestutils.TmpStorageVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	err := manifestutils.ConfigureStorage(params, &d.Spec.Template.Spec)
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// configureRemoteWriteSecret mounts the Secret containing the CA certificate and credentials
+// of the Prometheus remote write endpoint. The basic auth username is passed as environment variable,
+// because Tempo does not support reading it from a file.
+func configureRemoteWriteSecret(secretName string, remoteWrite manifestutils.RemoteWriteParams, pod *corev1.PodSpec) {
+	pod.Volumes = append(pod.Volumes, corev1.Volume{
+		Name: remoteWriteVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: secretName,
+			},
+		},
+	})
+	pod.Containers[0].VolumeMounts = append(pod.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      remoteWriteVolumeName,
+		MountPath: manifestutils.RemoteWriteSecretDir,
+		ReadOnly:  true,
+	})
+
+	if remoteWrite.BasicAuth {
+		pod.Containers[0].Env = append(pod.Containers[0].Env, corev1.EnvVar{
+			Name: manifestutils.RemoteWriteUsernameEnvVar,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+					Key:                  manifestutils.RemoteWriteUsernameKey,
+				},
+			},
+		})
+	}
+}
+
+func service(tempo v1alpha1.TempoStack) *corev1.Service {
+	labels := manifestutils.ComponentLabels(manifestutils.MetricsGeneratorComponentName, tempo.Name)
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.Name(manifestutils.MetricsGeneratorComponentName, tempo.Name),
+			Namespace: tempo.Namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name:       manifestutils.HttpPortName,
+					Protocol:   corev1.ProtocolTCP,
+					Port:       manifestutils.PortHTTPServer,
+					TargetPort: intstr.FromString(manifestutils.HttpPortName),
+				},
+				{
+					Name:       manifestutils.GrpcPortName,
+					Protocol:   corev1.ProtocolTCP,
+					Port:       manifestutils.PortGRPCServer,
+					TargetPort: intstr.FromString(manifestutils.GrpcPortName),
+				},
+			},
+			Selector: labels,
+		},
+	}
+}
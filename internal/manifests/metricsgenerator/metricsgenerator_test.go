@@ -0,0 +1,242 @@
+package metricsgenerator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func TestBuildMetricsGenerator(t *testing.T) {
+	objects, err := BuildMetricsGenerator(manifestutils.Params{Tempo: v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Images: configv1alpha1.ImagesSpec{
+				Tempo: "docker.io/grafana/tempo:1.5.0",
+			},
+			ServiceAccount: "tempo-test-serviceaccount",
+			Template: v1alpha1.TempoTemplateSpec{
+				MetricsGenerator: v1alpha1.TempoMetricsGeneratorSpec{
+					TempoComponentSpec: v1alpha1.TempoComponentSpec{
+						Replicas:     ptr.To(int32(2)),
+						NodeSelector: map[string]string{"a": "b"},
+					},
+					Enabled: true,
+				},
+			},
+		},
+	}})
+	require.NoError(t, err)
+
+	labels := manifestutils.ComponentLabels("metrics-generator", "test")
+	annotations := manifestutils.CommonAnnotations("")
+	assert.Equal(t, 2, len(objects))
+
+	assert.Equal(t, &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tempo-test-metrics-generator",
+			Namespace: "project1",
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name:       manifestutils.HttpPortName,
+					Protocol:   corev1.ProtocolTCP,
+					Port:       manifestutils.PortHTTPServer,
+					TargetPort: intstr.FromString(manifestutils.HttpPortName),
+				},
+				{
+					Name:       manifestutils.GrpcPortName,
+					Protocol:   corev1.ProtocolTCP,
+					Port:       manifestutils.PortGRPCServer,
+					TargetPort: intstr.FromString(manifestutils.GrpcPortName),
+				},
+			},
+			Selector: labels,
+		},
+	}, objects[1])
+
+	assert.Equal(t, &v1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tempo-test-metrics-generator",
+			Namespace: "project1",
+			Labels:    labels,
+		},
+		Spec: v1.DeploymentSpec{
+			Replicas: ptr.To(int32(2)),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      k8slabels.Merge(labels, map[string]string{"tempo-gossip-member": "true"}),
+					Annotations: annotations,
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: "tempo-test-serviceaccount",
+					NodeSelector:       map[string]string{"a": "b"},
+					Containers: []corev1.Container{
+						{
+							Name:  "tempo",
+							Image: "docker.io/grafana/tempo:1.5.0",
+							Env:   []corev1.EnvVar{},
+							Args: []string{
+								"-target=metrics-generator",
+								"-config.file=/conf/tempo.yaml",
+								"-config.expand-env=true",
+								"-log.level=info",
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      manifestutils.ConfigVolumeName,
+									MountPath: "/conf",
+									ReadOnly:  true,
+								},
+								{
+									Name:      manifestutils.TmpStorageVolumeName,
+									MountPath: manifestutils.TmpStoragePath,
+								},
+							},
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          manifestutils.HttpPortName,
+									ContainerPort: manifestutils.PortHTTPServer,
+									Protocol:      corev1.ProtocolTCP,
+								},
+								{
+									Name:          manifestutils.GrpcPortName,
+									ContainerPort: manifestutils.PortGRPCServer,
+									Protocol:      corev1.ProtocolTCP,
+								},
+								{
+									Name:          manifestutils.HttpMemberlistPortName,
+									ContainerPort: manifestutils.PortMemberlist,
+									Protocol:      corev1.ProtocolTCP,
+								},
+							},
+							ReadinessProbe: &corev1.Probe{
+								ProbeHandler: corev1.ProbeHandler{
+									HTTPGet: &corev1.HTTPGetAction{
+										Scheme: corev1.URISchemeHTTP,
+										Path:   manifestutils.TempoReadinessPath,
+										Port:   intstr.FromString(manifestutils.HttpPortName),
+									},
+								},
+								InitialDelaySeconds: 15,
+								TimeoutSeconds:      1,
+							},
+							SecurityContext: manifestutils.TempoContainerSecurityContext(),
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: manifestutils.ConfigVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: "tempo-test",
+									},
+								},
+							},
+						},
+						{
+							Name: manifestutils.TmpStorageVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, objects[0])
+}
+
+func TestBuildMetricsGeneratorRemoteWriteSecret(t *testing.T) {
+	objects, err := BuildMetricsGenerator(manifestutils.Params{Tempo: v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Template: v1alpha1.TempoTemplateSpec{
+				MetricsGenerator: v1alpha1.TempoMetricsGeneratorSpec{
+					Enabled: true,
+					RemoteWrite: v1alpha1.MetricsGeneratorRemoteWriteSpec{
+						URL:    "https://prometheus:9090/api/v1/write",
+						Secret: "remote-write-credentials",
+					},
+				},
+			},
+		},
+	}})
+	require.NoError(t, err)
+
+	d := objects[0].(*v1.Deployment)
+	assert.Contains(t, d.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: "remote-write",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: "remote-write-credentials",
+			},
+		},
+	})
+	assert.Contains(t, d.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      "remote-write",
+		MountPath: "/var/run/tempo/remote-write",
+		ReadOnly:  true,
+	})
+}
+
+func TestBuildMetricsGeneratorRemoteWriteBasicAuth(t *testing.T) {
+	objects, err := BuildMetricsGenerator(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "project1",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Template: v1alpha1.TempoTemplateSpec{
+					MetricsGenerator: v1alpha1.TempoMetricsGeneratorSpec{
+						Enabled: true,
+						RemoteWrite: v1alpha1.MetricsGeneratorRemoteWriteSpec{
+							URL:    "https://prometheus:9090/api/v1/write",
+							Secret: "remote-write-credentials",
+						},
+					},
+				},
+			},
+		},
+		RemoteWrite: manifestutils.RemoteWriteParams{BasicAuth: true},
+	})
+	require.NoError(t, err)
+
+	container := objects[0].(*v1.Deployment).Spec.Template.Spec.Containers[0]
+	assert.Contains(t, container.Env, corev1.EnvVar{
+		Name: "REMOTE_WRITE_USERNAME",
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "remote-write-credentials"},
+				Key:                  "username",
+			},
+		},
+	})
+	assert.Contains(t, container.Args, "-config.expand-env=true")
+}
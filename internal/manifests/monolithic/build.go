@@ -0,0 +1,59 @@
+package monolithic
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/config"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+// Options defines calculated options required to generate all manifests of a TempoMonolithic instance.
+type Options struct {
+	CtrlConfig     configv1alpha1.ProjectConfig
+	Tempo          v1alpha1.TempoMonolithic
+	StorageParams  manifestutils.StorageParams
+	ConfigChecksum string
+}
+
+// BuildAll generates all manifests of a TempoMonolithic instance.
+func BuildAll(opts Options) ([]client.Object, error) {
+	tempo := opts.Tempo
+	manifests := []client.Object{}
+
+	configMap, configChecksum, err := config.BuildMonolithicConfigMap(tempo, opts.StorageParams)
+	if err != nil {
+		return nil, err
+	}
+	manifests = append(manifests, configMap)
+	opts.ConfigChecksum = configChecksum
+
+	statefulSet, err := BuildTempoStatefulset(opts)
+	if err != nil {
+		return nil, err
+	}
+	manifests = append(manifests, statefulSet)
+	manifests = append(manifests, BuildTempoService(opts))
+
+	if tempo.Spec.JaegerUI != nil && tempo.Spec.JaegerUI.Enabled {
+		manifests = append(manifests, BuildJaegerUIService(opts))
+
+		switch tempo.Spec.JaegerUI.Ingress.Type {
+		case v1alpha1.IngressTypeIngress:
+			manifests = append(manifests, BuildJaegerUIIngress(opts))
+		case v1alpha1.IngressTypeRoute:
+			route, err := BuildJaegerUIRoute(opts)
+			if err != nil {
+				return nil, err
+			}
+			manifests = append(manifests, route)
+		}
+	}
+
+	if tempo.Spec.Observability != nil && tempo.Spec.Observability.Metrics.CreateServiceMonitors {
+		manifests = append(manifests, BuildServiceMonitor(opts))
+	}
+
+	return manifests, nil
+}
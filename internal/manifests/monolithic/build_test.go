@@ -0,0 +1,89 @@
+package monolithic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+func TestBuildAll(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     v1alpha1.TempoMonolithicSpec
+		expected []string
+	}{
+		{
+			name: "memory storage",
+			spec: v1alpha1.TempoMonolithicSpec{
+				Storage: v1alpha1.MonolithicStorageSpec{
+					Traces: v1alpha1.MonolithicTracesStorageSpec{
+						Backend: v1alpha1.MonolithicTracesStorageBackendMemory,
+					},
+				},
+			},
+			expected: []string{
+				"ConfigMap/tempo-sample-config",
+				"StatefulSet/tempo-sample",
+				"Service/tempo-sample",
+			},
+		},
+		{
+			name: "Jaeger UI with route and ServiceMonitor",
+			spec: v1alpha1.TempoMonolithicSpec{
+				Storage: v1alpha1.MonolithicStorageSpec{
+					Traces: v1alpha1.MonolithicTracesStorageSpec{
+						Backend: v1alpha1.MonolithicTracesStorageBackendMemory,
+					},
+				},
+				JaegerUI: &v1alpha1.MonolithicJaegerUISpec{
+					Enabled: true,
+					Ingress: v1alpha1.IngressSpec{
+						Type: v1alpha1.IngressTypeRoute,
+						Route: v1alpha1.RouteSpec{
+							Termination: v1alpha1.TLSRouteTerminationTypeEdge,
+						},
+					},
+				},
+				Observability: &v1alpha1.MonolithicObservabilitySpec{
+					Metrics: v1alpha1.MonolithicObservabilityMetricsSpec{
+						CreateServiceMonitors: true,
+					},
+				},
+			},
+			expected: []string{
+				"ConfigMap/tempo-sample-config",
+				"StatefulSet/tempo-sample",
+				"Service/tempo-sample",
+				"Service/tempo-sample-jaegerui",
+				"Route/tempo-sample-jaegerui",
+				"ServiceMonitor/tempo-sample",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			opts := Options{
+				Tempo: v1alpha1.TempoMonolithic{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "sample",
+						Namespace: "default",
+					},
+					Spec: test.spec,
+				},
+			}
+			objects, err := BuildAll(opts)
+			require.NoError(t, err)
+
+			names := []string{}
+			for _, obj := range objects {
+				kind := obj.GetObjectKind().GroupVersionKind().Kind
+				names = append(names, kind+"/"+obj.GetName())
+			}
+			require.Equal(t, test.expected, names)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package monolithic
+
+import (
+	"testing"
+
+	routev1 "github.com/openshift/api/route/v1"
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func TestBuildJaegerUIIngress(t *testing.T) {
+	opts := Options{
+		Tempo: v1alpha1.TempoMonolithic{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "sample",
+				Namespace: "default",
+			},
+			Spec: v1alpha1.TempoMonolithicSpec{
+				JaegerUI: &v1alpha1.MonolithicJaegerUISpec{
+					Enabled: true,
+					Ingress: v1alpha1.IngressSpec{
+						Type:             v1alpha1.IngressTypeIngress,
+						Host:             "jaeger.example.com",
+						IngressClassName: ptr.To("nginx"),
+					},
+				},
+			},
+		},
+	}
+
+	pathType := networkingv1.PathTypePrefix
+	require.Equal(t, &networkingv1.Ingress{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "networking.k8s.io/v1",
+			Kind:       "Ingress",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tempo-sample-jaegerui",
+			Namespace: "default",
+			Labels:    manifestutils.MonolithicComponentLabels("jaegerui", "sample"),
+		},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: ptr.To("nginx"),
+			Rules: []networkingv1.IngressRule{
+				{
+					Host: "jaeger.example.com",
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{
+								{
+									Path:     "/",
+									PathType: &pathType,
+									Backend: networkingv1.IngressBackend{
+										Service: &networkingv1.IngressServiceBackend{
+											Name: "tempo-sample-jaegerui",
+											Port: networkingv1.ServiceBackendPort{
+												Name: "jaeger-ui",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, BuildJaegerUIIngress(opts))
+}
+
+func TestBuildJaegerUIRoute(t *testing.T) {
+	opts := Options{
+		Tempo: v1alpha1.TempoMonolithic{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "sample",
+				Namespace: "default",
+			},
+			Spec: v1alpha1.TempoMonolithicSpec{
+				JaegerUI: &v1alpha1.MonolithicJaegerUISpec{
+					Enabled: true,
+					Ingress: v1alpha1.IngressSpec{
+						Type: v1alpha1.IngressTypeRoute,
+						Route: v1alpha1.RouteSpec{
+							Termination: v1alpha1.TLSRouteTerminationTypeEdge,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	route, err := BuildJaegerUIRoute(opts)
+	require.NoError(t, err)
+	require.Equal(t, &routev1.Route{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "route.openshift.io/v1",
+			Kind:       "Route",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tempo-sample-jaegerui",
+			Namespace: "default",
+			Labels:    manifestutils.MonolithicComponentLabels("jaegerui", "sample"),
+		},
+		Spec: routev1.RouteSpec{
+			To: routev1.RouteTargetReference{
+				Kind: "Service",
+				Name: "tempo-sample-jaegerui",
+			},
+			Port: &routev1.RoutePort{
+				TargetPort: intstr.FromString("jaeger-ui"),
+			},
+			TLS: &routev1.TLSConfig{
+				Termination: routev1.TLSTerminationEdge,
+			},
+		},
+	}, route)
+}
+
+func TestBuildJaegerUIRouteInvalidTermination(t *testing.T) {
+	opts := Options{
+		Tempo: v1alpha1.TempoMonolithic{
+			Spec: v1alpha1.TempoMonolithicSpec{
+				JaegerUI: &v1alpha1.MonolithicJaegerUISpec{
+					Enabled: true,
+					Ingress: v1alpha1.IngressSpec{
+						Type: v1alpha1.IngressTypeRoute,
+						Route: v1alpha1.RouteSpec{
+							Termination: "invalid",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	_, err := BuildJaegerUIRoute(opts)
+	require.Error(t, err)
+}
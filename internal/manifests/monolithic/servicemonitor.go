@@ -0,0 +1,53 @@
+package monolithic
+
+import (
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+)
+
+// BuildServiceMonitor creates a ServiceMonitor object for the Tempo service.
+func BuildServiceMonitor(opts Options) *monitoringv1.ServiceMonitor {
+	tempo := opts.Tempo
+	labels := manifestutils.MonolithicComponentLabels(tempoComponent, tempo.Name)
+
+	return &monitoringv1.ServiceMonitor{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: monitoringv1.SchemeGroupVersion.String(),
+			Kind:       monitoringv1.ServiceMonitorsKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.Name("", tempo.Name),
+			Namespace: tempo.Namespace,
+			Labels:    labels,
+		},
+		Spec: monitoringv1.ServiceMonitorSpec{
+			Endpoints: []monitoringv1.Endpoint{{
+				Scheme: "http",
+				Port:   manifestutils.HttpPortName,
+				Path:   "/metrics",
+				// Custom relabel configs to be compatible with predefined Tempo dashboards:
+				// https://grafana.com/docs/tempo/latest/operations/monitoring/#dashboards
+				RelabelConfigs: []*monitoringv1.RelabelConfig{
+					{
+						SourceLabels: []monitoringv1.LabelName{"__meta_kubernetes_service_label_app_kubernetes_io_instance"},
+						TargetLabel:  "cluster",
+					},
+					{
+						SourceLabels: []monitoringv1.LabelName{"__meta_kubernetes_namespace", "__meta_kubernetes_service_label_app_kubernetes_io_component"},
+						Separator:    "/",
+						TargetLabel:  "job",
+					},
+				},
+			}},
+			NamespaceSelector: monitoringv1.NamespaceSelector{
+				MatchNames: []string{tempo.Namespace},
+			},
+			Selector: metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+		},
+	}
+}
@@ -0,0 +1,33 @@
+package monolithic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func TestBuildServiceMonitor(t *testing.T) {
+	opts := Options{
+		Tempo: v1alpha1.TempoMonolithic{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "sample",
+				Namespace: "default",
+			},
+		},
+	}
+
+	sm := BuildServiceMonitor(opts)
+	labels := manifestutils.MonolithicComponentLabels("tempo", "sample")
+	require.Equal(t, "tempo-sample", sm.Name)
+	require.Equal(t, "default", sm.Namespace)
+	require.Equal(t, map[string]string(labels), sm.Spec.Selector.MatchLabels)
+	require.Equal(t, []string{"default"}, sm.Spec.NamespaceSelector.MatchNames)
+	require.Len(t, sm.Spec.Endpoints, 1)
+	require.Equal(t, "http", sm.Spec.Endpoints[0].Scheme)
+	require.Equal(t, "http", sm.Spec.Endpoints[0].Port)
+	require.Equal(t, "/metrics", sm.Spec.Endpoints[0].Path)
+}
@@ -0,0 +1,108 @@
+package monolithic
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+)
+
+// BuildTempoService creates the service for a monolithic deployment.
+func BuildTempoService(opts Options) *corev1.Service {
+	tempo := opts.Tempo
+	labels := manifestutils.MonolithicComponentLabels(tempoComponent, tempo.Name)
+
+	ports := []corev1.ServicePort{
+		{
+			Name:       manifestutils.HttpPortName,
+			Protocol:   corev1.ProtocolTCP,
+			Port:       manifestutils.PortHTTPServer,
+			TargetPort: intstr.FromString(manifestutils.HttpPortName),
+		},
+		{
+			Name:       manifestutils.GrpcPortName,
+			Protocol:   corev1.ProtocolTCP,
+			Port:       manifestutils.PortGRPCServer,
+			TargetPort: intstr.FromString(manifestutils.GrpcPortName),
+		},
+	}
+
+	if tempo.Spec.Ingestion != nil && tempo.Spec.Ingestion.OTLP != nil {
+		if tempo.Spec.Ingestion.OTLP.GRPC != nil && tempo.Spec.Ingestion.OTLP.GRPC.Enabled {
+			ports = append(ports, corev1.ServicePort{
+				Name:       manifestutils.OtlpGrpcPortName,
+				Protocol:   corev1.ProtocolTCP,
+				Port:       manifestutils.PortOtlpGrpcServer,
+				TargetPort: intstr.FromString(manifestutils.OtlpGrpcPortName),
+			})
+		}
+		if tempo.Spec.Ingestion.OTLP.HTTP != nil && tempo.Spec.Ingestion.OTLP.HTTP.Enabled {
+			ports = append(ports, corev1.ServicePort{
+				Name:       manifestutils.PortOtlpHttpName,
+				Protocol:   corev1.ProtocolTCP,
+				Port:       manifestutils.PortOtlpHttp,
+				TargetPort: intstr.FromString(manifestutils.PortOtlpHttpName),
+			})
+		}
+	}
+
+	return &corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Service",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.Name("", tempo.Name),
+			Namespace: tempo.Namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Ports:    ports,
+			Selector: labels,
+		},
+	}
+}
+
+// BuildJaegerUIService creates the service of the Jaeger UI for a monolithic deployment.
+func BuildJaegerUIService(opts Options) *corev1.Service {
+	tempo := opts.Tempo
+	labels := manifestutils.MonolithicComponentLabels(jaegerUIComponent, tempo.Name)
+
+	return &corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Service",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.Name(jaegerUIComponent, tempo.Name),
+			Namespace: tempo.Namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name:       jaegerGRPCQueryPortName,
+					Protocol:   corev1.ProtocolTCP,
+					Port:       portJaegerGRPCQuery,
+					TargetPort: intstr.FromString(jaegerGRPCQueryPortName),
+				},
+				{
+					Name:       jaegerUIPortName,
+					Protocol:   corev1.ProtocolTCP,
+					Port:       portJaegerUI,
+					TargetPort: intstr.FromString(jaegerUIPortName),
+				},
+				{
+					Name:       jaegerMetricsPortName,
+					Protocol:   corev1.ProtocolTCP,
+					Port:       portJaegerMetrics,
+					TargetPort: intstr.FromString(jaegerMetricsPortName),
+				},
+			},
+			// The Jaeger UI runs as a sidecar of the Tempo pod.
+			Selector: manifestutils.MonolithicComponentLabels(tempoComponent, tempo.Name),
+		},
+	}
+}
@@ -0,0 +1,108 @@
+package monolithic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func TestBuildTempoService(t *testing.T) {
+	opts := Options{
+		Tempo: v1alpha1.TempoMonolithic{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "sample",
+				Namespace: "default",
+			},
+			Spec: v1alpha1.TempoMonolithicSpec{
+				Ingestion: &v1alpha1.MonolithicIngestionSpec{
+					OTLP: &v1alpha1.MonolithicIngestionOTLPSpec{
+						GRPC: &v1alpha1.MonolithicIngestionOTLPProtocolSpec{
+							Enabled: true,
+						},
+						HTTP: &v1alpha1.MonolithicIngestionOTLPProtocolSpec{
+							Enabled: false,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	labels := manifestutils.MonolithicComponentLabels("tempo", "sample")
+	require.Equal(t, &corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Service",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tempo-sample",
+			Namespace: "default",
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "http",
+					Protocol:   corev1.ProtocolTCP,
+					Port:       3200,
+					TargetPort: intstr.FromString("http"),
+				},
+				{
+					Name:       "grpc",
+					Protocol:   corev1.ProtocolTCP,
+					Port:       9095,
+					TargetPort: intstr.FromString("grpc"),
+				},
+				{
+					Name:       "otlp-grpc",
+					Protocol:   corev1.ProtocolTCP,
+					Port:       4317,
+					TargetPort: intstr.FromString("otlp-grpc"),
+				},
+			},
+			Selector: labels,
+		},
+	}, BuildTempoService(opts))
+}
+
+func TestBuildJaegerUIService(t *testing.T) {
+	opts := Options{
+		Tempo: v1alpha1.TempoMonolithic{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "sample",
+				Namespace: "default",
+			},
+		},
+	}
+
+	svc := BuildJaegerUIService(opts)
+	require.Equal(t, "tempo-sample-jaegerui", svc.Name)
+	require.Equal(t, map[string]string(manifestutils.MonolithicComponentLabels("jaegerui", "sample")), svc.Labels)
+	require.Equal(t, map[string]string(manifestutils.MonolithicComponentLabels("tempo", "sample")), svc.Spec.Selector)
+	require.Equal(t, []corev1.ServicePort{
+		{
+			Name:       "jaeger-grpc",
+			Protocol:   corev1.ProtocolTCP,
+			Port:       16685,
+			TargetPort: intstr.FromString("jaeger-grpc"),
+		},
+		{
+			Name:       "jaeger-ui",
+			Protocol:   corev1.ProtocolTCP,
+			Port:       16686,
+			TargetPort: intstr.FromString("jaeger-ui"),
+		},
+		{
+			Name:       "jaeger-metrics",
+			Protocol:   corev1.ProtocolTCP,
+			Port:       16687,
+			TargetPort: intstr.FromString("jaeger-metrics"),
+		},
+	}, svc.Spec.Ports)
+}
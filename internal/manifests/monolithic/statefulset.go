@@ -0,0 +1,281 @@
+package monolithic
+
+import (
+	"fmt"
+
+	"github.com/operator-framework/operator-lib/proxy"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+)
+
+const (
+	storageVolumeName = "tempo-storage"
+	jaegerUIComponent = "jaegerui"
+	configComponent   = "config"
+	tempoComponent    = "tempo"
+
+	jaegerGRPCQueryPortName = "jaeger-grpc"
+	jaegerUIPortName        = "jaeger-ui"
+	jaegerMetricsPortName   = "jaeger-metrics"
+	portJaegerGRPCQuery     = 16685
+	portJaegerUI            = 16686
+	portJaegerMetrics       = 16687
+)
+
+// defaultPVSize is the size of the persistent volume if the size is not set,
+// which happens if the defaulting webhook is disabled.
+var defaultPVSize = resource.MustParse("10Gi")
+
+// BuildTempoStatefulset creates the Tempo statefulset for a monolithic deployment.
+func BuildTempoStatefulset(opts Options) (*appsv1.StatefulSet, error) {
+	tempo := opts.Tempo
+	labels := manifestutils.MonolithicComponentLabels(tempoComponent, tempo.Name)
+	annotations := manifestutils.StorageAnnotations(opts.ConfigChecksum, opts.StorageParams)
+
+	sts := &appsv1.StatefulSet{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+			Kind:       "StatefulSet",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.Name("", tempo.Name),
+			Namespace: tempo.Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+
+			// Changes to a StatefulSet are not propagated to pods in a broken state (e.g. CrashLoopBackOff)
+			// See https://github.com/kubernetes/kubernetes/issues/67250
+			//
+			// This is a workaround for the above issue.
+			PodManagementPolicy: appsv1.ParallelPodManagement,
+
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      labels,
+					Annotations: annotations,
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "tempo",
+							Image: opts.CtrlConfig.DefaultImages.Tempo,
+							Env:   proxy.ReadProxyVarsFromEnv(),
+							Args: []string{
+								"-config.file=/conf/tempo.yaml",
+								"-target=all",
+								"-config.expand-env=true",
+								fmt.Sprintf("-log.level=%s", manifestutils.LogLevel(tempo.Spec.LogLevel)),
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      manifestutils.ConfigVolumeName,
+									MountPath: "/conf",
+									ReadOnly:  true,
+								},
+								{
+									Name:      storageVolumeName,
+									MountPath: manifestutils.TmpStoragePath,
+								},
+							},
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          manifestutils.HttpPortName,
+									ContainerPort: manifestutils.PortHTTPServer,
+									Protocol:      corev1.ProtocolTCP,
+								},
+								{
+									Name:          manifestutils.GrpcPortName,
+									ContainerPort: manifestutils.PortGRPCServer,
+									Protocol:      corev1.ProtocolTCP,
+								},
+							},
+							ReadinessProbe:  manifestutils.TempoReadinessProbe(false),
+							SecurityContext: manifestutils.TempoContainerSecurityContext(),
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: manifestutils.ConfigVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: naming.Name(configComponent, tempo.Name),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if tempo.Spec.Resources != nil {
+		sts.Spec.Template.Spec.Containers[0].Resources = *tempo.Spec.Resources
+	}
+
+	err := configureStorage(opts, sts)
+	if err != nil {
+		return nil, err
+	}
+
+	err = configureReceivers(opts, &sts.Spec.Template.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if tempo.Spec.JaegerUI != nil && tempo.Spec.JaegerUI.Enabled {
+		configureJaegerUI(opts, sts)
+	}
+
+	return sts, nil
+}
+
+func configureStorage(opts Options, sts *appsv1.StatefulSet) error {
+	tempo := opts.Tempo
+	switch tempo.Spec.Storage.Traces.Backend {
+	case v1alpha1.MonolithicTracesStorageBackendMemory:
+		sts.Spec.Template.Spec.Volumes = append(sts.Spec.Template.Spec.Volumes, corev1.Volume{
+			Name: storageVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{
+					Medium:    corev1.StorageMediumMemory,
+					SizeLimit: tempo.Spec.Storage.Traces.Size,
+				},
+			},
+		})
+
+	case v1alpha1.MonolithicTracesStorageBackendPV,
+		v1alpha1.MonolithicTracesStorageBackendS3,
+		v1alpha1.MonolithicTracesStorageBackendAzure,
+		v1alpha1.MonolithicTracesStorageBackendGCS:
+		// For object storage backends, the persistent volume stores the WAL.
+		size := defaultPVSize
+		if tempo.Spec.Storage.Traces.Size != nil {
+			size = *tempo.Spec.Storage.Traces.Size
+		}
+		sts.Spec.VolumeClaimTemplates = []corev1.PersistentVolumeClaim{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: storageVolumeName,
+				},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					AccessModes: []corev1.PersistentVolumeAccessMode{
+						corev1.ReadWriteOnce,
+					},
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceStorage: size,
+						},
+					},
+					VolumeMode: ptr.To(corev1.PersistentVolumeFilesystem),
+				},
+			},
+		}
+	}
+
+	objectStorage, ok := v1alpha1.MonolithicObjectStorage(tempo.Spec.Storage.Traces)
+	if ok {
+		err := manifestutils.ConfigureObjectStorage(objectStorage, opts.StorageParams, &sts.Spec.Template.Spec)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func configureReceivers(opts Options, pod *corev1.PodSpec) error {
+	tempo := opts.Tempo
+	if tempo.Spec.Ingestion == nil {
+		return nil
+	}
+
+	if tempo.Spec.Ingestion.OTLP != nil {
+		if tempo.Spec.Ingestion.OTLP.GRPC != nil && tempo.Spec.Ingestion.OTLP.GRPC.Enabled {
+			pod.Containers[0].Ports = append(pod.Containers[0].Ports, corev1.ContainerPort{
+				Name:          manifestutils.OtlpGrpcPortName,
+				ContainerPort: manifestutils.PortOtlpGrpcServer,
+				Protocol:      corev1.ProtocolTCP,
+			})
+		}
+		if tempo.Spec.Ingestion.OTLP.HTTP != nil && tempo.Spec.Ingestion.OTLP.HTTP.Enabled {
+			pod.Containers[0].Ports = append(pod.Containers[0].Ports, corev1.ContainerPort{
+				Name:          manifestutils.PortOtlpHttpName,
+				ContainerPort: manifestutils.PortOtlpHttp,
+				Protocol:      corev1.ProtocolTCP,
+			})
+		}
+	}
+
+	if tempo.Spec.Ingestion.TLS.Enabled {
+		err := manifestutils.ConfigureReceiversTLS(tempo.Spec.Ingestion.TLS, pod)
+		if err != nil {
+			return fmt.Errorf("failed to configure receivers TLS: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func configureJaegerUI(opts Options, sts *appsv1.StatefulSet) {
+	tempoQuery := corev1.Container{
+		Name:  "tempo-query",
+		Image: opts.CtrlConfig.DefaultImages.TempoQuery,
+		Env:   proxy.ReadProxyVarsFromEnv(),
+		Args: []string{
+			"--query.base-path=/",
+			"--grpc-storage-plugin.configuration-file=/conf/tempo-query.yaml",
+			"--query.bearer-token-propagation=true",
+			fmt.Sprintf("--log-level=%s", manifestutils.LogLevel(opts.Tempo.Spec.LogLevel)),
+		},
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          jaegerGRPCQueryPortName,
+				ContainerPort: portJaegerGRPCQuery,
+				Protocol:      corev1.ProtocolTCP,
+			},
+			{
+				Name:          jaegerUIPortName,
+				ContainerPort: portJaegerUI,
+				Protocol:      corev1.ProtocolTCP,
+			},
+			{
+				Name:          jaegerMetricsPortName,
+				ContainerPort: portJaegerMetrics,
+				Protocol:      corev1.ProtocolTCP,
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      manifestutils.ConfigVolumeName,
+				MountPath: "/conf",
+				ReadOnly:  true,
+			},
+			{
+				Name:      manifestutils.TmpStorageVolumeName + "-query",
+				MountPath: manifestutils.TmpStoragePath,
+			},
+		},
+		SecurityContext: manifestutils.TempoContainerSecurityContext(),
+	}
+
+	sts.Spec.Template.Spec.Containers = append(sts.Spec.Template.Spec.Containers, tempoQuery)
+	sts.Spec.Template.Spec.Volumes = append(sts.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: manifestutils.TmpStorageVolumeName + "-query",
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	})
+}
@@ -0,0 +1,355 @@
+package monolithic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func TestStatefulsetMemoryStorage(t *testing.T) {
+	opts := Options{
+		CtrlConfig: configv1alpha1.ProjectConfig{
+			DefaultImages: configv1alpha1.ImagesSpec{
+				Tempo: "docker.io/grafana/tempo:x.y.z",
+			},
+		},
+		Tempo: v1alpha1.TempoMonolithic{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "sample",
+				Namespace: "default",
+			},
+			Spec: v1alpha1.TempoMonolithicSpec{
+				Storage: v1alpha1.MonolithicStorageSpec{
+					Traces: v1alpha1.MonolithicTracesStorageSpec{
+						Backend: v1alpha1.MonolithicTracesStorageBackendMemory,
+						Size:    ptr.To(resource.MustParse("1Gi")),
+					},
+				},
+			},
+		},
+		ConfigChecksum: "abc",
+	}
+	sts, err := BuildTempoStatefulset(opts)
+	require.NoError(t, err)
+
+	labels := manifestutils.MonolithicComponentLabels("tempo", "sample")
+	require.Equal(t, &appsv1.StatefulSet{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apps/v1",
+			Kind:       "StatefulSet",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tempo-sample",
+			Namespace: "default",
+			Labels:    labels,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			PodManagementPolicy: appsv1.ParallelPodManagement,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+					Annotations: map[string]string{
+						"tempo.grafana.com/config.hash": "abc",
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "tempo",
+							Image: "docker.io/grafana/tempo:x.y.z",
+							Env:   []corev1.EnvVar{},
+							Args: []string{
+								"-config.file=/conf/tempo.yaml",
+								"-target=all",
+								"-config.expand-env=true",
+								"-log.level=info",
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      manifestutils.ConfigVolumeName,
+									MountPath: "/conf",
+									ReadOnly:  true,
+								},
+								{
+									Name:      "tempo-storage",
+									MountPath: "/var/tempo",
+								},
+							},
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          "http",
+									ContainerPort: 3200,
+									Protocol:      corev1.ProtocolTCP,
+								},
+								{
+									Name:          "grpc",
+									ContainerPort: 9095,
+									Protocol:      corev1.ProtocolTCP,
+								},
+							},
+							ReadinessProbe:  manifestutils.TempoReadinessProbe(false),
+							SecurityContext: manifestutils.TempoContainerSecurityContext(),
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: manifestutils.ConfigVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: "tempo-sample-config",
+									},
+								},
+							},
+						},
+						{
+							Name: "tempo-storage",
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{
+									Medium:    corev1.StorageMediumMemory,
+									SizeLimit: ptr.To(resource.MustParse("1Gi")),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, sts)
+}
+
+func TestStatefulsetPVStorage(t *testing.T) {
+	opts := Options{
+		Tempo: v1alpha1.TempoMonolithic{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "sample",
+				Namespace: "default",
+			},
+			Spec: v1alpha1.TempoMonolithicSpec{
+				Storage: v1alpha1.MonolithicStorageSpec{
+					Traces: v1alpha1.MonolithicTracesStorageSpec{
+						Backend: v1alpha1.MonolithicTracesStorageBackendPV,
+						Size:    ptr.To(resource.MustParse("5Gi")),
+					},
+				},
+			},
+		},
+	}
+	sts, err := BuildTempoStatefulset(opts)
+	require.NoError(t, err)
+
+	require.Len(t, sts.Spec.Template.Spec.Volumes, 1)
+	require.Equal(t, []corev1.PersistentVolumeClaim{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "tempo-storage",
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{
+					corev1.ReadWriteOnce,
+				},
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: resource.MustParse("5Gi"),
+					},
+				},
+				VolumeMode: ptr.To(corev1.PersistentVolumeFilesystem),
+			},
+		},
+	}, sts.Spec.VolumeClaimTemplates)
+
+	// The size is set by the defaulting webhook, fall back to the default size if the webhook is disabled
+	opts.Tempo.Spec.Storage.Traces.Size = nil
+	sts, err = BuildTempoStatefulset(opts)
+	require.NoError(t, err)
+	require.Len(t, sts.Spec.VolumeClaimTemplates, 1)
+	require.Equal(t, resource.MustParse("10Gi"), sts.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests[corev1.ResourceStorage])
+}
+
+func TestStatefulsetS3Storage(t *testing.T) {
+	opts := Options{
+		Tempo: v1alpha1.TempoMonolithic{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "sample",
+				Namespace: "default",
+			},
+			Spec: v1alpha1.TempoMonolithicSpec{
+				Storage: v1alpha1.MonolithicStorageSpec{
+					Traces: v1alpha1.MonolithicTracesStorageSpec{
+						Backend: v1alpha1.MonolithicTracesStorageBackendS3,
+						Size:    ptr.To(resource.MustParse("10Gi")),
+						S3: &v1alpha1.MonolithicTracesStorageS3Spec{
+							MonolithicTracesObjectStorageSpec: v1alpha1.MonolithicTracesObjectStorageSpec{
+								Secret: "storage-secret",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	sts, err := BuildTempoStatefulset(opts)
+	require.NoError(t, err)
+
+	require.Len(t, sts.Spec.VolumeClaimTemplates, 1)
+	require.Equal(t, []corev1.EnvVar{
+		{
+			Name: "S3_SECRET_KEY",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					Key: "access_key_secret",
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: "storage-secret",
+					},
+				},
+			},
+		},
+		{
+			Name: "S3_ACCESS_KEY",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					Key: "access_key_id",
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: "storage-secret",
+					},
+				},
+			},
+		},
+	}, sts.Spec.Template.Spec.Containers[0].Env)
+	require.Contains(t, sts.Spec.Template.Spec.Containers[0].Args, "--storage.trace.s3.secret_key=$(S3_SECRET_KEY)")
+	require.Contains(t, sts.Spec.Template.Spec.Containers[0].Args, "--storage.trace.s3.access_key=$(S3_ACCESS_KEY)")
+}
+
+func TestStatefulsetReceiverTLS(t *testing.T) {
+	opts := Options{
+		Tempo: v1alpha1.TempoMonolithic{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "sample",
+				Namespace: "default",
+			},
+			Spec: v1alpha1.TempoMonolithicSpec{
+				Storage: v1alpha1.MonolithicStorageSpec{
+					Traces: v1alpha1.MonolithicTracesStorageSpec{
+						Backend: v1alpha1.MonolithicTracesStorageBackendMemory,
+					},
+				},
+				Ingestion: &v1alpha1.MonolithicIngestionSpec{
+					OTLP: &v1alpha1.MonolithicIngestionOTLPSpec{
+						GRPC: &v1alpha1.MonolithicIngestionOTLPProtocolSpec{
+							Enabled: true,
+						},
+						HTTP: &v1alpha1.MonolithicIngestionOTLPProtocolSpec{
+							Enabled: true,
+						},
+					},
+					TLS: v1alpha1.ReceiversTLSSpec{
+						Enabled: true,
+						CA:      "ca",
+						Cert:    "cert",
+					},
+				},
+			},
+		},
+	}
+	sts, err := BuildTempoStatefulset(opts)
+	require.NoError(t, err)
+
+	require.Contains(t, sts.Spec.Template.Spec.Containers[0].Ports, corev1.ContainerPort{
+		Name:          "otlp-grpc",
+		ContainerPort: 4317,
+		Protocol:      corev1.ProtocolTCP,
+	})
+	require.Contains(t, sts.Spec.Template.Spec.Containers[0].Ports, corev1.ContainerPort{
+		Name:          "otlp-http",
+		ContainerPort: 4318,
+		Protocol:      corev1.ProtocolTCP,
+	})
+	require.Contains(t, sts.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      "ca",
+		MountPath: manifestutils.CAReceiver,
+		ReadOnly:  true,
+	})
+	require.Contains(t, sts.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      "cert",
+		MountPath: manifestutils.TempoReceiverTLSDir(),
+		ReadOnly:  true,
+	})
+}
+
+func TestStatefulsetJaegerUI(t *testing.T) {
+	opts := Options{
+		CtrlConfig: configv1alpha1.ProjectConfig{
+			DefaultImages: configv1alpha1.ImagesSpec{
+				TempoQuery: "docker.io/grafana/tempo-query:x.y.z",
+			},
+		},
+		Tempo: v1alpha1.TempoMonolithic{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "sample",
+				Namespace: "default",
+			},
+			Spec: v1alpha1.TempoMonolithicSpec{
+				Storage: v1alpha1.MonolithicStorageSpec{
+					Traces: v1alpha1.MonolithicTracesStorageSpec{
+						Backend: v1alpha1.MonolithicTracesStorageBackendMemory,
+					},
+				},
+				JaegerUI: &v1alpha1.MonolithicJaegerUISpec{
+					Enabled: true,
+				},
+			},
+		},
+	}
+	sts, err := BuildTempoStatefulset(opts)
+	require.NoError(t, err)
+
+	require.Len(t, sts.Spec.Template.Spec.Containers, 2)
+	require.Equal(t, "tempo-query", sts.Spec.Template.Spec.Containers[1].Name)
+	require.Equal(t, "docker.io/grafana/tempo-query:x.y.z", sts.Spec.Template.Spec.Containers[1].Image)
+	require.Equal(t, []string{
+		"--query.base-path=/",
+		"--grpc-storage-plugin.configuration-file=/conf/tempo-query.yaml",
+		"--query.bearer-token-propagation=true",
+		"--log-level=info",
+	}, sts.Spec.Template.Spec.Containers[1].Args)
+}
+
+func TestStatefulsetLogLevel(t *testing.T) {
+	opts := Options{
+		Tempo: v1alpha1.TempoMonolithic{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "sample",
+				Namespace: "default",
+			},
+			Spec: v1alpha1.TempoMonolithicSpec{
+				Storage: v1alpha1.MonolithicStorageSpec{
+					Traces: v1alpha1.MonolithicTracesStorageSpec{
+						Backend: v1alpha1.MonolithicTracesStorageBackendMemory,
+					},
+				},
+				JaegerUI: &v1alpha1.MonolithicJaegerUISpec{
+					Enabled: true,
+				},
+				LogLevel: v1alpha1.LogLevelDebug,
+			},
+		},
+	}
+	sts, err := BuildTempoStatefulset(opts)
+	require.NoError(t, err)
+
+	require.Len(t, sts.Spec.Template.Spec.Containers, 2)
+	require.Contains(t, sts.Spec.Template.Spec.Containers[0].Args, "-log.level=debug")
+	require.Contains(t, sts.Spec.Template.Spec.Containers[1].Args, "--log-level=debug")
+}
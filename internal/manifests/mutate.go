@@ -9,8 +9,10 @@ import (
 	routev1 "github.com/openshift/api/route/v1"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -23,6 +25,8 @@ import (
 // - Service
 // - Deployment
 // - StatefulSet
+// - HorizontalPodAutoscaler
+// - PodDisruptionBudget
 // - ServiceMonitor
 // - Secret.
 func MutateFuncFor(existing, desired client.Object) controllerutil.MutateFn {
@@ -89,6 +93,16 @@ func MutateFuncFor(existing, desired client.Object) controllerutil.MutateFn {
 			wantSts := desired.(*appsv1.StatefulSet)
 			return mutateStatefulSet(sts, wantSts)
 
+		case *autoscalingv2.HorizontalPodAutoscaler:
+			hpa := existing.(*autoscalingv2.HorizontalPodAutoscaler)
+			wantHpa := desired.(*autoscalingv2.HorizontalPodAutoscaler)
+			mutateHorizontalPodAutoscaler(hpa, wantHpa)
+
+		case *policyv1.PodDisruptionBudget:
+			pdb := existing.(*policyv1.PodDisruptionBudget)
+			wantPdb := desired.(*policyv1.PodDisruptionBudget)
+			mutatePodDisruptionBudget(pdb, wantPdb)
+
 		case *monitoringv1.ServiceMonitor:
 			svcMonitor := existing.(*monitoringv1.ServiceMonitor)
 			wantSvcMonitor := desired.(*monitoringv1.ServiceMonitor)
@@ -207,6 +221,18 @@ func mutateGrafanaDatasource(existing, desired *grafanav1.GrafanaDatasource) {
 	existing.Spec = desired.Spec
 }
 
+func mutateHorizontalPodAutoscaler(existing, desired *autoscalingv2.HorizontalPodAutoscaler) {
+	existing.Annotations = desired.Annotations
+	existing.Labels = desired.Labels
+	existing.Spec = desired.Spec
+}
+
+func mutatePodDisruptionBudget(existing, desired *policyv1.PodDisruptionBudget) {
+	existing.Annotations = desired.Annotations
+	existing.Labels = desired.Labels
+	existing.Spec = desired.Spec
+}
+
 func mutateService(existing, desired *corev1.Service) error {
 	existing.Spec.Ports = desired.Spec.Ports
 	if err := mergeWithOverride(&existing.Spec.Selector, desired.Spec.Selector); err != nil {
@@ -221,8 +247,11 @@ func mutateDeployment(existing, desired *appsv1.Deployment) error {
 	if existing.CreationTimestamp.IsZero() {
 		existing.Spec.Selector = desired.Spec.Selector
 	}
-	existing.Spec.Replicas = desired.Spec.Replicas
-	if err := mergeWithOverride(&existing.Spec.Template, desired.Spec.Template); err != nil {
+	// Replicas are not set if the deployment is scaled by a HorizontalPodAutoscaler
+	if desired.Spec.Replicas != nil {
+		existing.Spec.Replicas = desired.Spec.Replicas
+	}
+	if err := mutatePodTemplate(&existing.Spec.Template, desired.Spec.Template); err != nil {
 		return err
 	}
 	if err := mergeWithOverride(&existing.Spec.Strategy, desired.Spec.Strategy); err != nil {
@@ -238,14 +267,69 @@ func mutateStatefulSet(existing, desired *appsv1.StatefulSet) error {
 		existing.Spec.Selector = desired.Spec.Selector
 	}
 	existing.Spec.PodManagementPolicy = desired.Spec.PodManagementPolicy
-	existing.Spec.Replicas = desired.Spec.Replicas
+	// Replicas are not set if the statefulset is scaled by a HorizontalPodAutoscaler
+	if desired.Spec.Replicas != nil {
+		existing.Spec.Replicas = desired.Spec.Replicas
+	}
 	for i := range existing.Spec.VolumeClaimTemplates {
 		existing.Spec.VolumeClaimTemplates[i].TypeMeta = desired.Spec.VolumeClaimTemplates[i].TypeMeta
 		existing.Spec.VolumeClaimTemplates[i].ObjectMeta = desired.Spec.VolumeClaimTemplates[i].ObjectMeta
 		existing.Spec.VolumeClaimTemplates[i].Spec = desired.Spec.VolumeClaimTemplates[i].Spec
 	}
-	if err := mergeWithOverride(&existing.Spec.Template, desired.Spec.Template); err != nil {
+	if err := mutatePodTemplate(&existing.Spec.Template, desired.Spec.Template); err != nil {
+		return err
+	}
+	return nil
+}
+
+// mutatePodTemplate merges the desired pod template into the existing one.
+// The fields which can be set by the pod template overrides of a component are reset to the desired values afterwards,
+// because merging never removes a label, a container, a volume or an optional field from the existing pod template
+// when the override is removed. Containers and volumes are merged by name, to keep the values defaulted by the API server.
+func mutatePodTemplate(existing *corev1.PodTemplateSpec, desired corev1.PodTemplateSpec) error {
+	existingContainers := existing.Spec.Containers
+	existingVolumes := existing.Spec.Volumes
+	if err := mergeWithOverride(existing, desired); err != nil {
 		return err
 	}
+
+	existing.Labels = desired.Labels
+	existing.Spec.Affinity = desired.Spec.Affinity
+	existing.Spec.TopologySpreadConstraints = desired.Spec.TopologySpreadConstraints
+	existing.Spec.PriorityClassName = desired.Spec.PriorityClassName
+
+	var containers []corev1.Container
+	for _, container := range desired.Spec.Containers {
+		merged := *container.DeepCopy()
+		for _, existingContainer := range existingContainers {
+			if existingContainer.Name != container.Name {
+				continue
+			}
+			merged = *existingContainer.DeepCopy()
+			if err := mergeWithOverride(&merged, container); err != nil {
+				return err
+			}
+			merged.Env = container.Env
+			merged.VolumeMounts = container.VolumeMounts
+		}
+		containers = append(containers, merged)
+	}
+	existing.Spec.Containers = containers
+
+	var volumes []corev1.Volume
+	for _, volume := range desired.Spec.Volumes {
+		merged := *volume.DeepCopy()
+		for _, existingVolume := range existingVolumes {
+			if existingVolume.Name != volume.Name {
+				continue
+			}
+			merged = *existingVolume.DeepCopy()
+			if err := mergeWithOverride(&merged, volume); err != nil {
+				return err
+			}
+		}
+		volumes = append(volumes, merged)
+	}
+	existing.Spec.Volumes = volumes
 	return nil
 }
@@ -7,8 +7,10 @@ import (
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -638,6 +640,101 @@ func TestGeMutateFunc_MutateDeploymentSpec(t *testing.T) {
 	}
 }
 
+func TestGetMutateFunc_MutateDeploymentKeepsAutoscaledReplicas(t *testing.T) {
+	got := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.Now()},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(int32(4)),
+		},
+	}
+	want := &appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Replicas: nil,
+		},
+	}
+
+	f := manifests.MutateFuncFor(got, want)
+	err := f()
+	require.NoError(t, err)
+	require.Equal(t, ptr.To(int32(4)), got.Spec.Replicas)
+}
+
+func TestGetMutateFunc_MutateStatefulSetKeepsAutoscaledReplicas(t *testing.T) {
+	got := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.Now()},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: ptr.To(int32(4)),
+		},
+	}
+	want := &appsv1.StatefulSet{
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: nil,
+		},
+	}
+
+	f := manifests.MutateFuncFor(got, want)
+	err := f()
+	require.NoError(t, err)
+	require.Equal(t, ptr.To(int32(4)), got.Spec.Replicas)
+}
+
+func TestGetMutateFunc_MutateHorizontalPodAutoscaler(t *testing.T) {
+	got := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{"test": "test"},
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			MinReplicas: ptr.To(int32(1)),
+			MaxReplicas: 2,
+		},
+	}
+	want := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{"test": "test", "other": "label"},
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       "tempo-test-querier",
+			},
+			MinReplicas: ptr.To(int32(2)),
+			MaxReplicas: 5,
+		},
+	}
+
+	f := manifests.MutateFuncFor(got, want)
+	err := f()
+	require.NoError(t, err)
+	require.Exactly(t, want.Labels, got.Labels)
+	require.Exactly(t, want.Spec, got.Spec)
+}
+
+func TestGetMutateFunc_MutatePodDisruptionBudget(t *testing.T) {
+	got := &policyv1.PodDisruptionBudget{
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MaxUnavailable: ptr.To(intstr.FromInt32(1)),
+		},
+	}
+	want := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{"test": "test"},
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"test": "test"},
+			},
+			MinAvailable: ptr.To(intstr.FromString("50%")),
+		},
+	}
+
+	f := manifests.MutateFuncFor(got, want)
+	err := f()
+	require.NoError(t, err)
+	require.Exactly(t, want.Labels, got.Labels)
+	require.Exactly(t, want.Spec, got.Spec)
+}
+
 func TestGeMutateFunc_MutateStatefulSetSpec(t *testing.T) {
 	one := int32(1)
 	two := int32(2)
@@ -1080,3 +1177,122 @@ func TestGetMutateFunc_MutateRoute(t *testing.T) {
 	require.Exactly(t, got.Annotations, want.Annotations)
 	require.Exactly(t, got.Spec, want.Spec)
 }
+
+func TestGetMutateFunc_MutatePodTemplateRemovesOverrides(t *testing.T) {
+	got := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.Now()},
+		Spec: appsv1.StatefulSetSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"app": "tempo", "team": "a"},
+					Annotations: map[string]string{
+						"tempo.grafana.com/config.hash":     "old",
+						"example.com/injected":              "true",
+						"kubectl.kubernetes.io/restartedAt": "2024-01-01T00:00:00Z",
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "tempo", Env: []corev1.EnvVar{{Name: "OVERRIDE", Value: "true"}}},
+						{Name: "sidecar"},
+					},
+					Affinity: &corev1.Affinity{
+						NodeAffinity: &corev1.NodeAffinity{},
+					},
+					TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{MaxSkew: 1}},
+					PriorityClassName:         "high-priority",
+				},
+			},
+		},
+	}
+	want := &appsv1.StatefulSet{
+		Spec: appsv1.StatefulSetSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      map[string]string{"app": "tempo"},
+					Annotations: map[string]string{"tempo.grafana.com/config.hash": "new"},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "tempo"},
+					},
+				},
+			},
+		},
+	}
+
+	f := manifests.MutateFuncFor(got, want)
+	err := f()
+	require.NoError(t, err)
+
+	require.Equal(t, corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{"app": "tempo"},
+			Annotations: map[string]string{
+				"tempo.grafana.com/config.hash":     "new",
+				"example.com/injected":              "true",
+				"kubectl.kubernetes.io/restartedAt": "2024-01-01T00:00:00Z",
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "tempo"},
+			},
+		},
+	}, got.Spec.Template)
+}
+
+func TestGetMutateFunc_MutatePodTemplateUnchanged(t *testing.T) {
+	desired := &appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      map[string]string{"app": "tempo"},
+					Annotations: map[string]string{"tempo.grafana.com/config.hash": "abc"},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:         "tempo",
+							Image:        "tempo",
+							Env:          []corev1.EnvVar{{Name: "OVERRIDE", Value: "true"}},
+							VolumeMounts: []corev1.VolumeMount{{Name: "config", MountPath: "/conf"}},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "config",
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{Name: "tempo"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// the existing object contains the values defaulted by the API server and an annotation of a third party
+	defaultMode := int32(420)
+	existing := desired.DeepCopy()
+	existing.CreationTimestamp = metav1.Now()
+	existing.Spec.Template.Annotations["example.com/injected"] = "true"
+	existing.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyAlways
+	existing.Spec.Template.Spec.Containers[0].TerminationMessagePath = corev1.TerminationMessagePathDefault
+	existing.Spec.Template.Spec.Containers[0].ImagePullPolicy = corev1.PullIfNotPresent
+	existing.Spec.Template.Spec.Volumes[0].ConfigMap.DefaultMode = &defaultMode
+
+	got := existing.DeepCopy()
+	f := manifests.MutateFuncFor(got, desired.DeepCopy())
+	err := f()
+	require.NoError(t, err)
+	require.Equal(t, existing.Spec.Template, got.Spec.Template)
+
+	// a second mutate of the unchanged object is a no-op as well
+	f = manifests.MutateFuncFor(got, desired.DeepCopy())
+	err = f()
+	require.NoError(t, err)
+	require.Equal(t, existing.Spec.Template, got.Spec.Template)
+}
@@ -0,0 +1,88 @@
+package poddisruptionbudget
+
+import (
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+)
+
+// BuildPodDisruptionBudgets creates a PodDisruptionBudget for every enabled component.
+func BuildPodDisruptionBudgets(params manifestutils.Params) []client.Object {
+	tempo := params.Tempo
+	defaultMaxUnavailable := intstr.FromInt32(1)
+	ingesterMaxUnavailable := ingesterMaxUnavailable(tempo.Spec.ReplicationFactor)
+
+	objects := []client.Object{
+		podDisruptionBudget(tempo, manifestutils.DistributorComponentName, tempo.Spec.Template.Distributor.PodDisruptionBudget, defaultMaxUnavailable),
+		// With zone-aware replication, the PodDisruptionBudget selects the ingesters of all zones,
+		// otherwise an ingester of every zone could be evicted at the same time, removing all replicas of a trace.
+		podDisruptionBudget(tempo, manifestutils.IngesterComponentName, tempo.Spec.Template.Ingester.PodDisruptionBudget, ingesterMaxUnavailable),
+		podDisruptionBudget(tempo, manifestutils.QueryFrontendComponentName, tempo.Spec.Template.QueryFrontend.PodDisruptionBudget, defaultMaxUnavailable),
+		podDisruptionBudget(tempo, manifestutils.QuerierComponentName, tempo.Spec.Template.Querier.PodDisruptionBudget, defaultMaxUnavailable),
+		podDisruptionBudget(tempo, manifestutils.CompactorComponentName, tempo.Spec.Template.Compactor.PodDisruptionBudget, defaultMaxUnavailable),
+	}
+
+	if tempo.Spec.Template.Gateway.Enabled {
+		objects = append(objects, podDisruptionBudget(tempo, manifestutils.GatewayComponentName, tempo.Spec.Template.Gateway.PodDisruptionBudget, defaultMaxUnavailable))
+	}
+	if tempo.Spec.Template.MetricsGenerator.Enabled {
+		objects = append(objects, podDisruptionBudget(tempo, manifestutils.MetricsGeneratorComponentName, tempo.Spec.Template.MetricsGenerator.PodDisruptionBudget, defaultMaxUnavailable))
+	}
+	if tempo.Spec.Cache.Managed.Enabled {
+		objects = append(objects, podDisruptionBudget(tempo, manifestutils.MemcachedComponentName, tempo.Spec.Cache.Managed.PodDisruptionBudget, defaultMaxUnavailable))
+	}
+
+	return objects
+}
+
+// ingesterMaxUnavailable returns the number of ingesters which can be unavailable
+// without losing the write quorum of the ring, i.e. a trace is still written to
+// a majority of its replicas.
+//
+// With a replication factor of 1 or 2, the quorum requires all replicas of a trace.
+// One ingester can be evicted at a time nevertheless, otherwise node drains and cluster upgrades
+// would be blocked indefinitely. The evicted ingester replays its WAL when it is restarted.
+func ingesterMaxUnavailable(replicationFactor int) intstr.IntOrString {
+	quorum := replicationFactor/2 + 1
+	if replicationFactor-quorum < 1 {
+		return intstr.FromInt32(1)
+	}
+	return intstr.FromInt32(int32(replicationFactor - quorum))
+}
+
+func podDisruptionBudget(tempo v1alpha1.TempoStack, component string, spec v1alpha1.PodDisruptionBudgetSpec, defaultMaxUnavailable intstr.IntOrString) *policyv1.PodDisruptionBudget {
+	labels := manifestutils.ComponentLabels(component, tempo.Name)
+
+	pdbSpec := policyv1.PodDisruptionBudgetSpec{
+		Selector: &metav1.LabelSelector{
+			MatchLabels: labels,
+		},
+	}
+	switch {
+	case spec.MinAvailable != nil:
+		pdbSpec.MinAvailable = spec.MinAvailable
+	case spec.MaxUnavailable != nil:
+		pdbSpec.MaxUnavailable = spec.MaxUnavailable
+	default:
+		pdbSpec.MaxUnavailable = ptr.To(defaultMaxUnavailable)
+	}
+
+	return &policyv1.PodDisruptionBudget{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: policyv1.SchemeGroupVersion.String(),
+			Kind:       "PodDisruptionBudget",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.Name(component, tempo.Name),
+			Namespace: tempo.Namespace,
+			Labels:    labels,
+		},
+		Spec: pdbSpec,
+	}
+}
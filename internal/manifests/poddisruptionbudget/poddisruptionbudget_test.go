@@ -0,0 +1,199 @@
+package poddisruptionbudget
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func TestBuildPodDisruptionBudgets(t *testing.T) {
+	objects := BuildPodDisruptionBudgets(manifestutils.Params{Tempo: v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			ReplicationFactor: 3,
+		},
+	}})
+	require.Len(t, objects, 5)
+
+	labels := manifestutils.ComponentLabels("distributor", "test")
+	assert.Equal(t, &policyv1.PodDisruptionBudget{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "policy/v1",
+			Kind:       "PodDisruptionBudget",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tempo-test-distributor",
+			Namespace: "project1",
+			Labels:    labels,
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			MaxUnavailable: ptr.To(intstr.FromInt32(1)),
+		},
+	}, objects[0])
+
+	names := []string{}
+	for _, obj := range objects {
+		names = append(names, obj.GetName())
+	}
+	assert.Equal(t, []string{
+		"tempo-test-distributor",
+		"tempo-test-ingester",
+		"tempo-test-query-frontend",
+		"tempo-test-querier",
+		"tempo-test-compactor",
+	}, names)
+}
+
+func TestBuildPodDisruptionBudgetsOptionalComponents(t *testing.T) {
+	objects := BuildPodDisruptionBudgets(manifestutils.Params{Tempo: v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			ReplicationFactor: 1,
+			Template: v1alpha1.TempoTemplateSpec{
+				Gateway: v1alpha1.TempoGatewaySpec{
+					Enabled: true,
+				},
+				MetricsGenerator: v1alpha1.TempoMetricsGeneratorSpec{
+					Enabled: true,
+				},
+			},
+			Cache: v1alpha1.CacheSpec{
+				Managed: v1alpha1.ManagedCacheSpec{
+					Enabled: true,
+				},
+			},
+		},
+	}})
+	require.Len(t, objects, 8)
+	assert.Equal(t, "tempo-test-gateway", objects[5].GetName())
+	assert.Equal(t, "tempo-test-metrics-generator", objects[6].GetName())
+	assert.Equal(t, "tempo-test-memcached", objects[7].GetName())
+}
+
+func TestBuildPodDisruptionBudgetsIngester(t *testing.T) {
+	tests := []struct {
+		replicationFactor int
+		expected          intstr.IntOrString
+	}{
+		// The write quorum requires all replicas, but one ingester can be evicted to not block node drains
+		{replicationFactor: 1, expected: intstr.FromInt32(1)},
+		{replicationFactor: 2, expected: intstr.FromInt32(1)},
+		{replicationFactor: 3, expected: intstr.FromInt32(1)},
+		{replicationFactor: 5, expected: intstr.FromInt32(2)},
+	}
+
+	for _, test := range tests {
+		objects := BuildPodDisruptionBudgets(manifestutils.Params{Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				ReplicationFactor: test.replicationFactor,
+			},
+		}})
+		pdb := objects[1].(*policyv1.PodDisruptionBudget)
+		assert.Equal(t, "tempo-test-ingester", pdb.Name)
+		assert.Equal(t, &test.expected, pdb.Spec.MaxUnavailable, "replication factor %d", test.replicationFactor)
+		assert.Nil(t, pdb.Spec.MinAvailable)
+	}
+}
+
+func TestBuildPodDisruptionBudgetsDefaultStackCanBeDrained(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+	}
+	require.NoError(t, v1alpha1.NewDefaulter(configv1alpha1.ProjectConfig{}).Default(context.Background(), &tempo))
+
+	for _, obj := range BuildPodDisruptionBudgets(manifestutils.Params{Tempo: tempo}) {
+		pdb := obj.(*policyv1.PodDisruptionBudget)
+		require.NotNil(t, pdb.Spec.MaxUnavailable, pdb.Name)
+		assert.GreaterOrEqual(t, pdb.Spec.MaxUnavailable.IntValue(), 1, pdb.Name)
+	}
+}
+
+func TestBuildPodDisruptionBudgetsOverride(t *testing.T) {
+	objects := BuildPodDisruptionBudgets(manifestutils.Params{Tempo: v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			ReplicationFactor: 3,
+			Template: v1alpha1.TempoTemplateSpec{
+				Ingester: v1alpha1.TempoIngesterSpec{
+					TempoComponentSpec: v1alpha1.TempoComponentSpec{
+						PodDisruptionBudget: v1alpha1.PodDisruptionBudgetSpec{
+							MinAvailable: ptr.To(intstr.FromString("80%")),
+						},
+					},
+				},
+			},
+		},
+	}})
+
+	pdb := objects[1].(*policyv1.PodDisruptionBudget)
+	assert.Equal(t, ptr.To(intstr.FromString("80%")), pdb.Spec.MinAvailable)
+	assert.Nil(t, pdb.Spec.MaxUnavailable)
+}
+
+func TestBuildPodDisruptionBudgetsZoneAwareReplication(t *testing.T) {
+	objects := BuildPodDisruptionBudgets(manifestutils.Params{Tempo: v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			ReplicationFactor: 3,
+			Template: v1alpha1.TempoTemplateSpec{
+				Ingester: v1alpha1.TempoIngesterSpec{
+					ZoneAwareReplication: v1alpha1.ZoneAwareReplicationSpec{
+						Enabled: true,
+						Zones: []v1alpha1.ZoneSpec{
+							{Name: "zone-a"},
+							{Name: "zone-b"},
+							{Name: "zone-c"},
+						},
+					},
+				},
+			},
+		},
+	}})
+	require.Len(t, objects, 5)
+
+	// A single PodDisruptionBudget selects the ingesters of all zones, so that only one ingester
+	// of all zones can be evicted at a time.
+	pdb := objects[1].(*policyv1.PodDisruptionBudget)
+	assert.Equal(t, "tempo-test-ingester", pdb.Name)
+	assert.Equal(t, map[string]string(manifestutils.ComponentLabels("ingester", "test")), pdb.Spec.Selector.MatchLabels)
+	assert.NotContains(t, pdb.Spec.Selector.MatchLabels, manifestutils.ZoneLabel)
+	assert.Equal(t, ptr.To(intstr.FromInt32(1)), pdb.Spec.MaxUnavailable)
+
+	selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+	require.NoError(t, err)
+	for _, zone := range []string{"zone-a", "zone-b", "zone-c"} {
+		podLabels := manifestutils.ComponentLabels("ingester", "test")
+		podLabels[manifestutils.ZoneLabel] = zone
+		assert.True(t, selector.Matches(k8slabels.Set(podLabels)), zone)
+	}
+	assert.Equal(t, "tempo-test-query-frontend", objects[2].GetName())
+}
@@ -1,6 +1,8 @@
 package querier
 
 import (
+	"fmt"
+
 	"github.com/operator-framework/operator-lib/proxy"
 	v1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -41,13 +43,18 @@ func BuildQuerier(params manifestutils.Params) ([]client.Object, error) {
 		}
 	}
 
+	d.Spec.Template, err = manifestutils.PatchPodTemplate(tempo.Spec.Template.Querier.PodTemplate, d.Spec.Template)
+	if err != nil {
+		return nil, err
+	}
+
 	return []client.Object{d, service(tempo)}, nil
 }
 
 func deployment(params manifestutils.Params) (*v1.Deployment, error) {
 	tempo := params.Tempo
 	labels := manifestutils.ComponentLabels(manifestutils.QuerierComponentName, tempo.Name)
-	annotations := manifestutils.CommonAnnotations(params.ConfigChecksum)
+	annotations := manifestutils.StorageAnnotations(params.ConfigChecksum, params.StorageParams)
 	cfg := tempo.Spec.Template.Querier
 	image := tempo.Spec.Images.Tempo
 	if image == "" {
@@ -64,7 +71,7 @@ func deployment(params manifestutils.Params) (*v1.Deployment, error) {
 			Labels:    labels,
 		},
 		Spec: v1.DeploymentSpec{
-			Replicas: tempo.Spec.Template.Querier.Replicas,
+			Replicas: manifestutils.Replicas(tempo.Spec.Template.Querier),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: labels,
 			},
@@ -86,7 +93,8 @@ func deployment(params manifestutils.Params) (*v1.Deployment, error) {
 							Args: []string{
 								"-target=querier",
 								"-config.file=/conf/tempo.yaml",
-								"-log.level=info",
+								"-config.expand-env=true",
+								fmt.Sprintf("-log.level=%s", manifestutils.LogLevel(cfg.LogLevel)),
 							},
 							Ports: []corev1.ContainerPort{
 								{
@@ -139,7 +147,7 @@ func deployment(params manifestutils.Params) (*v1.Deployment, error) {
 		},
 	}
 
-	err := manifestutils.ConfigureStorage(tempo, &d.Spec.Template.Spec)
+	err := manifestutils.ConfigureStorage(params, &d.Spec.Template.Spec)
 	if err != nil {
 		return nil, err
 	}
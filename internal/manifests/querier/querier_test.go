@@ -123,6 +123,7 @@ func TestBuildQuerier(t *testing.T) {
 							Args: []string{
 								"-target=querier",
 								"-config.file=/conf/tempo.yaml",
+								"-config.expand-env=true",
 								"-log.level=info",
 							},
 							VolumeMounts: []corev1.VolumeMount{
@@ -195,3 +196,47 @@ func TestBuildQuerier(t *testing.T) {
 		},
 	}, objects[0])
 }
+
+func TestBuildQuerierLogLevel(t *testing.T) {
+	objects, err := BuildQuerier(manifestutils.Params{Tempo: v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Template: v1alpha1.TempoTemplateSpec{
+				Querier: v1alpha1.TempoComponentSpec{
+					LogLevel: v1alpha1.LogLevelDebug,
+				},
+			},
+		},
+	}})
+	require.NoError(t, err)
+
+	d, ok := objects[0].(*v1.Deployment)
+	require.True(t, ok)
+	assert.Contains(t, d.Spec.Template.Spec.Containers[0].Args, "-log.level=debug")
+}
+
+func TestBuildQuerierStorageHash(t *testing.T) {
+	objects, err := BuildQuerier(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "project1",
+			},
+		},
+		ConfigChecksum: "abc",
+		StorageParams: manifestutils.StorageParams{
+			CredentialsHash: "def",
+		},
+	})
+	require.NoError(t, err)
+
+	d, ok := objects[0].(*v1.Deployment)
+	require.True(t, ok)
+	assert.Equal(t, map[string]string{
+		"tempo.grafana.com/config.hash":  "abc",
+		"tempo.grafana.com/storage.hash": "def",
+	}, d.Spec.Template.Annotations)
+}
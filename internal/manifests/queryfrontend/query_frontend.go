@@ -63,6 +63,11 @@ func BuildQueryFrontend(params manifestutils.Params) ([]client.Object, error) {
 		}
 	}
 
+	d.Spec.Template, err = manifestutils.PatchPodTemplate(tempo.Spec.Template.QueryFrontend.PodTemplate, d.Spec.Template)
+	if err != nil {
+		return nil, err
+	}
+
 	manifests = append(manifests, d)
 
 	svcs := services(tempo)
@@ -96,7 +101,7 @@ func BuildQueryFrontend(params manifestutils.Params) ([]client.Object, error) {
 func deployment(params manifestutils.Params) (*appsv1.Deployment, error) {
 	tempo := params.Tempo
 	labels := manifestutils.ComponentLabels(manifestutils.QueryFrontendComponentName, tempo.Name)
-	annotations := manifestutils.CommonAnnotations(params.ConfigChecksum)
+	annotations := manifestutils.StorageAnnotations(params.ConfigChecksum, params.StorageParams)
 	cfg := tempo.Spec.Template.QueryFrontend
 	tempoImage := tempo.Spec.Images.Tempo
 	if tempoImage == "" {
@@ -117,7 +122,7 @@ func deployment(params manifestutils.Params) (*appsv1.Deployment, error) {
 			Labels:    labels,
 		},
 		Spec: appsv1.DeploymentSpec{
-			Replicas: tempo.Spec.Template.QueryFrontend.Replicas,
+			Replicas: manifestutils.Replicas(tempo.Spec.Template.QueryFrontend.TempoComponentSpec),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: labels,
 			},
@@ -139,8 +144,9 @@ func deployment(params manifestutils.Params) (*appsv1.Deployment, error) {
 							Args: []string{
 								"-target=query-frontend",
 								"-config.file=/conf/tempo-query-frontend.yaml",
+								"-config.expand-env=true",
 								"-mem-ballast-size-mbs=1024",
-								"-log.level=info",
+								fmt.Sprintf("-log.level=%s", manifestutils.LogLevel(cfg.LogLevel)),
 							},
 							Ports: []corev1.ContainerPort{
 								{
@@ -202,6 +208,7 @@ func deployment(params manifestutils.Params) (*appsv1.Deployment, error) {
 				"--query.base-path=/",
 				"--grpc-storage-plugin.configuration-file=/conf/tempo-query.yaml",
 				"--query.bearer-token-propagation=true",
+				fmt.Sprintf("--log-level=%s", manifestutils.LogLevel(cfg.LogLevel)),
 			},
 			Ports: []corev1.ContainerPort{
 				{
@@ -231,7 +238,7 @@ func deployment(params manifestutils.Params) (*appsv1.Deployment, error) {
 					MountPath: manifestutils.TmpStoragePath,
 				},
 			},
-			Resources: manifestutils.Resources(tempo, manifestutils.QueryFrontendComponentName),
+			Resources: manifestutils.JaegerQueryResources(tempo),
 		}
 		jaegerQueryVolume := corev1.Volume{
 			Name: manifestutils.TmpStorageVolumeName + "-query",
@@ -278,7 +285,7 @@ func deployment(params manifestutils.Params) (*appsv1.Deployment, error) {
 		d.Spec.Template.Spec.Volumes = append(d.Spec.Template.Spec.Volumes, jaegerQueryVolume)
 	}
 
-	err := manifestutils.ConfigureStorage(tempo, &d.Spec.Template.Spec)
+	err := manifestutils.ConfigureStorage(params, &d.Spec.Template.Spec)
 	if err != nil {
 		return nil, err
 	}
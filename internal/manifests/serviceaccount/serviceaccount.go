@@ -11,16 +11,59 @@ import (
 
 const (
 	componentName = "serviceaccount"
+
+	// awsRoleARNAnnotation is read by the EKS pod identity webhook.
+	awsRoleARNAnnotation = "eks.amazonaws.com/role-arn"
+
+	// Labels and annotations of the Azure Workload Identity webhook.
+	azureWorkloadIdentityLabel = "azure.workload.identity/use"
+	azureClientIDAnnotation    = "azure.workload.identity/client-id"
+	azureTenantIDAnnotation    = "azure.workload.identity/tenant-id"
+
+	// gcpServiceAccountAnnotation binds the service account to a GCP service account with GKE Workload Identity.
+	gcpServiceAccountAnnotation = "iam.gke.io/gcp-service-account"
 )
 
 // BuildDefaultServiceAccount creates a Kubernetes service account for tempo.
-func BuildDefaultServiceAccount(tempo v1alpha1.TempoStack) *corev1.ServiceAccount {
+func BuildDefaultServiceAccount(params manifestutils.Params) *corev1.ServiceAccount {
+	tempo := params.Tempo
 	labels := manifestutils.ComponentLabels(componentName, tempo.Name)
+	if useAzureWorkloadIdentity(tempo, params.StorageParams) {
+		labels[azureWorkloadIdentityLabel] = "true"
+	}
 	return &corev1.ServiceAccount{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      naming.DefaultServiceAccountName(tempo.Name),
-			Namespace: tempo.Namespace,
-			Labels:    labels,
+			Name:        naming.DefaultServiceAccountName(tempo.Name),
+			Namespace:   tempo.Namespace,
+			Labels:      labels,
+			Annotations: annotations(tempo, params.StorageParams),
 		},
 	}
 }
+
+// annotations returns the annotations of the service account required by the
+// token credential mode of the object storage.
+func annotations(tempo v1alpha1.TempoStack, storage manifestutils.StorageParams) map[string]string {
+	if tempo.Spec.Storage.CredentialMode != v1alpha1.CredentialModeToken {
+		return nil
+	}
+
+	if storage.S3 != nil && storage.S3.RoleARN != "" {
+		return map[string]string{awsRoleARNAnnotation: storage.S3.RoleARN}
+	}
+	if useAzureWorkloadIdentity(tempo, storage) {
+		return map[string]string{
+			azureClientIDAnnotation: storage.AzureStorage.ClientID,
+			azureTenantIDAnnotation: storage.AzureStorage.TenantID,
+		}
+	}
+	if storage.GCS != nil && storage.GCS.IAMServiceAccount != "" {
+		return map[string]string{gcpServiceAccountAnnotation: storage.GCS.IAMServiceAccount}
+	}
+	return nil
+}
+
+func useAzureWorkloadIdentity(tempo v1alpha1.TempoStack, storage manifestutils.StorageParams) bool {
+	return tempo.Spec.Storage.CredentialMode == v1alpha1.CredentialModeToken &&
+		storage.AzureStorage != nil && storage.AzureStorage.UseFederatedToken
+}
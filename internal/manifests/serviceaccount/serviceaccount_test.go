@@ -13,12 +13,12 @@ import (
 )
 
 func TestBuildDefaultServiceAccount(t *testing.T) {
-	serviceAccount := BuildDefaultServiceAccount(v1alpha1.TempoStack{
+	serviceAccount := BuildDefaultServiceAccount(manifestutils.Params{Tempo: v1alpha1.TempoStack{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "test",
 			Namespace: "ns1",
 		},
-	})
+	}})
 
 	labels := manifestutils.ComponentLabels("serviceaccount", "test")
 	require.NotNil(t, serviceAccount)
@@ -30,3 +30,95 @@ func TestBuildDefaultServiceAccount(t *testing.T) {
 		},
 	}, serviceAccount)
 }
+
+func TestBuildDefaultServiceAccountTokenCredentialMode(t *testing.T) {
+	serviceAccount := BuildDefaultServiceAccount(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "ns1",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Name: "storage",
+						Type: v1alpha1.ObjectStorageSecretS3,
+					},
+					CredentialMode: v1alpha1.CredentialModeToken,
+				},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			S3: &manifestutils.S3{
+				RoleARN: "arn:aws:iam::123456789012:role/tempo",
+			},
+		},
+	})
+
+	assert.Equal(t, map[string]string{
+		"eks.amazonaws.com/role-arn": "arn:aws:iam::123456789012:role/tempo",
+	}, serviceAccount.Annotations)
+}
+
+func TestBuildDefaultServiceAccountAzureWorkloadIdentity(t *testing.T) {
+	serviceAccount := BuildDefaultServiceAccount(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "ns1",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Name: "storage",
+						Type: v1alpha1.ObjectStorageSecretAzure,
+					},
+					CredentialMode: v1alpha1.CredentialModeToken,
+				},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			AzureStorage: &manifestutils.AzureStorage{
+				UseFederatedToken: true,
+				ClientID:          "client",
+				TenantID:          "tenant",
+			},
+		},
+	})
+
+	assert.Equal(t, "true", serviceAccount.Labels["azure.workload.identity/use"])
+	assert.Equal(t, map[string]string{
+		"azure.workload.identity/client-id": "client",
+		"azure.workload.identity/tenant-id": "tenant",
+	}, serviceAccount.Annotations)
+}
+
+func TestBuildDefaultServiceAccountGKEWorkloadIdentity(t *testing.T) {
+	serviceAccount := BuildDefaultServiceAccount(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "ns1",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Name: "storage",
+						Type: v1alpha1.ObjectStorageSecretGCS,
+					},
+					CredentialMode: v1alpha1.CredentialModeToken,
+				},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			GCS: &manifestutils.GCS{
+				Bucket:            "bucket",
+				IAMServiceAccount: "tempo@project.iam.gserviceaccount.com",
+			},
+		},
+	})
+
+	assert.Equal(t, map[string]string{
+		"iam.gke.io/gcp-service-account": "tempo@project.iam.gserviceaccount.com",
+	}, serviceAccount.Annotations)
+}
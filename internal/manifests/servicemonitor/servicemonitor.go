@@ -29,9 +29,26 @@ func BuildServiceMonitors(params manifestutils.Params) []client.Object {
 		monitors = append(monitors, buildServiceMonitor(params, manifestutils.GatewayComponentName, gateway.InternalPortName))
 	}
 
+	if params.Tempo.Spec.Template.MetricsGenerator.Enabled {
+		monitors = append(monitors, buildServiceMonitor(params, manifestutils.MetricsGeneratorComponentName, manifestutils.HttpPortName))
+	}
+
+	if params.Tempo.Spec.Cache.Managed.Enabled {
+		monitors = append(monitors, buildMemcachedServiceMonitor(params))
+	}
+
 	return monitors
 }
 
+// buildMemcachedServiceMonitor creates a ServiceMonitor for the memcached exporter.
+// The exporter does not support TLS, therefore the metrics are always scraped via HTTP.
+func buildMemcachedServiceMonitor(params manifestutils.Params) *monitoringv1.ServiceMonitor {
+	sm := buildServiceMonitor(params, manifestutils.MemcachedComponentName, manifestutils.MemcachedMetricsPortName)
+	sm.Spec.Endpoints[0].Scheme = "http"
+	sm.Spec.Endpoints[0].TLSConfig = nil
+	return sm
+}
+
 func buildServiceMonitor(params manifestutils.Params, component string, port string) *monitoringv1.ServiceMonitor {
 	tempo := params.Tempo
 	scheme := "http"
@@ -186,6 +186,114 @@ func TestBuildGatewayServiceMonitor(t *testing.T) {
 	}, objects[5])
 }
 
+func TestBuildMetricsGeneratorServiceMonitor(t *testing.T) {
+	objects := BuildServiceMonitors(manifestutils.Params{Tempo: v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Template: v1alpha1.TempoTemplateSpec{
+				MetricsGenerator: v1alpha1.TempoMetricsGeneratorSpec{
+					Enabled: true,
+				},
+			},
+		},
+	}})
+
+	labels := manifestutils.CommonLabels("test")
+	assert.Len(t, objects, 6)
+	assert.Equal(t, &monitoringv1.ServiceMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tempo-test-metrics-generator",
+			Namespace: "project1",
+			Labels:    labels,
+		},
+		Spec: monitoringv1.ServiceMonitorSpec{
+			Endpoints: []monitoringv1.Endpoint{{
+				Scheme: "http",
+				Port:   "http",
+				Path:   "/metrics",
+				RelabelConfigs: []*monitoringv1.RelabelConfig{
+					{
+						SourceLabels: []monitoringv1.LabelName{"__meta_kubernetes_service_label_app_kubernetes_io_instance"},
+						TargetLabel:  "cluster",
+					},
+					{
+						SourceLabels: []monitoringv1.LabelName{"__meta_kubernetes_namespace", "__meta_kubernetes_service_label_app_kubernetes_io_component"},
+						Separator:    "/",
+						TargetLabel:  "job",
+					},
+				},
+			}},
+			NamespaceSelector: monitoringv1.NamespaceSelector{
+				MatchNames: []string{"project1"},
+			},
+			Selector: metav1.LabelSelector{
+				MatchLabels: manifestutils.ComponentLabels(manifestutils.MetricsGeneratorComponentName, "test"),
+			},
+		},
+	}, objects[5])
+}
+
+func TestBuildMemcachedServiceMonitor(t *testing.T) {
+	objects := BuildServiceMonitors(manifestutils.Params{
+		CtrlConfig: configv1alpha1.ProjectConfig{
+			Gates: configv1alpha1.FeatureGates{
+				HTTPEncryption: true,
+			},
+		},
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "project1",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Cache: v1alpha1.CacheSpec{
+					Backend: v1alpha1.CacheBackendMemcached,
+					Managed: v1alpha1.ManagedCacheSpec{
+						Enabled: true,
+					},
+				},
+			},
+		},
+	})
+
+	labels := manifestutils.CommonLabels("test")
+	assert.Len(t, objects, 6)
+	assert.Equal(t, &monitoringv1.ServiceMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tempo-test-memcached",
+			Namespace: "project1",
+			Labels:    labels,
+		},
+		Spec: monitoringv1.ServiceMonitorSpec{
+			Endpoints: []monitoringv1.Endpoint{{
+				Scheme: "http",
+				Port:   "http-metrics",
+				Path:   "/metrics",
+				RelabelConfigs: []*monitoringv1.RelabelConfig{
+					{
+						SourceLabels: []monitoringv1.LabelName{"__meta_kubernetes_service_label_app_kubernetes_io_instance"},
+						TargetLabel:  "cluster",
+					},
+					{
+						SourceLabels: []monitoringv1.LabelName{"__meta_kubernetes_namespace", "__meta_kubernetes_service_label_app_kubernetes_io_component"},
+						Separator:    "/",
+						TargetLabel:  "job",
+					},
+				},
+			}},
+			NamespaceSelector: monitoringv1.NamespaceSelector{
+				MatchNames: []string{"project1"},
+			},
+			Selector: metav1.LabelSelector{
+				MatchLabels: manifestutils.ComponentLabels(manifestutils.MemcachedComponentName, "test"),
+			},
+		},
+	}, objects[5])
+}
+
 func TestBuildGatewayServiceMonitorsTLS(t *testing.T) {
 	objects := BuildServiceMonitors(manifestutils.Params{
 		CtrlConfig: configv1alpha1.ProjectConfig{
@@ -13,3 +13,9 @@ type StatusClient interface {
 	GetPodsComponent(ctx context.Context, componentName string, stack v1alpha1.TempoStack) (*corev1.PodList, error)
 	PatchStatus(ctx context.Context, changed, original *v1alpha1.TempoStack) error
 }
+
+// MonolithicStatusClient defines a interface for fetching status information of a TempoMonolithic instance.
+type MonolithicStatusClient interface {
+	GetMonolithicPods(ctx context.Context, tempo v1alpha1.TempoMonolithic) (*corev1.PodList, error)
+	PatchMonolithicStatus(ctx context.Context, changed, original *v1alpha1.TempoMonolithic) error
+}
@@ -334,3 +334,162 @@ func TestSetComponentsStatus_WhenAllReady(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, expected, components)
 }
+
+func TestSetComponentsStatus_WhenMetricsGeneratorPodFailed(t *testing.T) {
+	k := &statusClientStub{}
+
+	k.GetPodsComponentStub = func(ctx context.Context, componentName string, stack v1alpha1.TempoStack) (*corev1.PodList, error) {
+		phase := v1.PodRunning
+		if componentName == "metrics-generator" {
+			phase = v1.PodFailed
+		}
+		pods := v1.PodList{
+			Items: []v1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "pod-a",
+					},
+					Status: v1.PodStatus{
+						Phase: phase,
+					},
+				},
+			},
+		}
+		return &pods, nil
+	}
+
+	s := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Template: v1alpha1.TempoTemplateSpec{
+				MetricsGenerator: v1alpha1.TempoMetricsGeneratorSpec{
+					Enabled: true,
+				},
+			},
+		},
+	}
+
+	status, err := GetComponentsStatus(context.TODO(), k, s)
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.PodStatusMap{"Failed": []string{"pod-a"}}, status.Components.MetricsGenerator)
+	require.Len(t, status.Conditions, 1)
+	assert.Equal(t, string(v1alpha1.ConditionFailed), status.Conditions[0].Type)
+	assert.Equal(t, metav1.ConditionTrue, status.Conditions[0].Status)
+}
+
+func TestSetComponentsStatus_WhenMemcachedPodPending(t *testing.T) {
+	k := &statusClientStub{}
+
+	k.GetPodsComponentStub = func(ctx context.Context, componentName string, stack v1alpha1.TempoStack) (*corev1.PodList, error) {
+		phase := v1.PodRunning
+		if componentName == "memcached" {
+			phase = v1.PodPending
+		}
+		pods := v1.PodList{
+			Items: []v1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "pod-a",
+					},
+					Status: v1.PodStatus{
+						Phase: phase,
+					},
+				},
+			},
+		}
+		return &pods, nil
+	}
+
+	s := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Cache: v1alpha1.CacheSpec{
+				Backend: v1alpha1.CacheBackendMemcached,
+				Managed: v1alpha1.ManagedCacheSpec{
+					Enabled: true,
+				},
+			},
+		},
+	}
+
+	status, err := GetComponentsStatus(context.TODO(), k, s)
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.PodStatusMap{"Pending": []string{"pod-a"}}, status.Components.Memcached)
+	require.Len(t, status.Conditions, 1)
+	assert.Equal(t, string(v1alpha1.ConditionPending), status.Conditions[0].Type)
+}
+
+func TestSetComponentsStatus_WhenIngesterZonePending(t *testing.T) {
+	k := &statusClientStub{}
+
+	k.GetPodsComponentStub = func(ctx context.Context, componentName string, stack v1alpha1.TempoStack) (*corev1.PodList, error) {
+		pods := v1.PodList{
+			Items: []v1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "pod-a",
+					},
+					Status: v1.PodStatus{
+						Phase: v1.PodRunning,
+					},
+				},
+			},
+		}
+		if componentName == "ingester" {
+			pods.Items = []v1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "tempo-my-stack-ingester-zone-a-0",
+						Labels: map[string]string{"tempo.grafana.com/zone": "zone-a"},
+					},
+					Status: v1.PodStatus{
+						Phase: v1.PodRunning,
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "tempo-my-stack-ingester-zone-b-0",
+						Labels: map[string]string{"tempo.grafana.com/zone": "zone-b"},
+					},
+					Status: v1.PodStatus{
+						Phase: v1.PodPending,
+					},
+				},
+			}
+		}
+		return &pods, nil
+	}
+
+	s := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Template: v1alpha1.TempoTemplateSpec{
+				Ingester: v1alpha1.TempoIngesterSpec{
+					ZoneAwareReplication: v1alpha1.ZoneAwareReplicationSpec{
+						Enabled: true,
+						Zones:   []v1alpha1.ZoneSpec{{Name: "zone-a"}, {Name: "zone-b"}},
+					},
+				},
+			},
+		},
+	}
+
+	status, err := GetComponentsStatus(context.TODO(), k, s)
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.PodStatusMap{
+		"Running": []string{"tempo-my-stack-ingester-zone-a-0"},
+		"Pending": []string{"tempo-my-stack-ingester-zone-b-0"},
+	}, status.Components.Ingester)
+	require.Len(t, status.Conditions, 1)
+	assert.Equal(t, string(v1alpha1.ConditionPending), status.Conditions[0].Type)
+	assert.Equal(t, metav1.ConditionTrue, status.Conditions[0].Status)
+}
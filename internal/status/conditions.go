@@ -3,6 +3,7 @@ package status
 import (
 	"fmt"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
@@ -24,6 +25,28 @@ func (e *ConfigurationError) Error() string {
 	return fmt.Sprintf("invalid configuration: %s", e.Message)
 }
 
+// PendingError contains information about a reconciliation step which is still in progress,
+// for example the expansion of the ingester volumes.
+type PendingError struct {
+	Reason  v1alpha1.ConditionReason
+	Message string
+}
+
+func (e *PendingError) Error() string {
+	return fmt.Sprintf("pending: %s", e.Message)
+}
+
+// FailedError contains information about a reconciliation step which failed and is retried,
+// for example the shutdown of the ingesters removed by a scale-down.
+type FailedError struct {
+	Reason  v1alpha1.ConditionReason
+	Message string
+}
+
+func (e *FailedError) Error() string {
+	return fmt.Sprintf("failed: %s", e.Message)
+}
+
 // ReadyCondition updates or appends the condition Ready to the TempoStack status conditions.
 // In addition it resets all other Status conditions to false.
 func ReadyCondition(tempo v1alpha1.TempoStack) []metav1.Condition {
@@ -63,40 +86,64 @@ func PendingCondition(tempo v1alpha1.TempoStack) []metav1.Condition {
 // UpdateCondition updates or appends the condition to the TempoStack status conditions.
 // In addition it resets all other status conditions to false.
 func UpdateCondition(tempo v1alpha1.TempoStack, condition metav1.Condition) []metav1.Condition {
+	return updateConditions(tempo.Status.Conditions, condition)
+}
 
-	for _, c := range tempo.Status.Conditions {
+// updateConditions updates or appends the condition to a list of status conditions.
+// In addition it resets all other status conditions to false, except the StorageReachable condition.
+// The input slice is not modified.
+func updateConditions(conditions []metav1.Condition, condition metav1.Condition) []metav1.Condition {
+	for _, c := range conditions {
 		if c.Type == condition.Type &&
 			c.Reason == condition.Reason &&
 			c.Message == condition.Message &&
 			c.Status == metav1.ConditionTrue {
 			// resource already has desired condition
-			return tempo.Status.Conditions
+			return conditions
 		}
 	}
 
-	status := tempo.DeepCopy().Status
+	updated := make([]metav1.Condition, len(conditions))
+	copy(updated, conditions)
 
 	condition.Status = metav1.ConditionTrue
 	now := metav1.Now()
 	condition.LastTransitionTime = now
 
 	index := -1
-	for i := range status.Conditions {
+	for i := range updated {
+		// The StorageReachable condition is independent of the state of the components
+		if updated[i].Type == string(v1alpha1.ConditionStorageReachable) {
+			continue
+		}
+
 		// Reset all other conditions first
-		status.Conditions[i].Status = metav1.ConditionFalse
-		status.Conditions[i].LastTransitionTime = now
+		updated[i].Status = metav1.ConditionFalse
+		updated[i].LastTransitionTime = now
 
 		// Locate existing pending condition if any
-		if status.Conditions[i].Type == condition.Type {
+		if updated[i].Type == condition.Type {
 			index = i
 		}
 	}
 
 	if index == -1 {
-		status.Conditions = append(status.Conditions, condition)
+		updated = append(updated, condition)
 	} else {
-		status.Conditions[index] = condition
+		updated[index] = condition
 	}
 
-	return status.Conditions
+	return updated
+}
+
+// StorageReachableCondition updates or appends the StorageReachable condition to the status conditions.
+// In contrast to UpdateCondition, the other status conditions are not changed.
+// The input slice is not modified.
+func StorageReachableCondition(conditions []metav1.Condition, condition metav1.Condition) []metav1.Condition {
+	updated := make([]metav1.Condition, len(conditions))
+	copy(updated, conditions)
+
+	condition.Type = string(v1alpha1.ConditionStorageReachable)
+	meta.SetStatusCondition(&updated, condition)
+	return updated
 }
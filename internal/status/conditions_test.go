@@ -379,3 +379,39 @@ func TestConfigurationError(t *testing.T) {
 	}
 	assert.Equal(t, "invalid configuration: my message", err.Error())
 }
+
+func TestStorageReachableCondition(t *testing.T) {
+	storageReachable := metav1.Condition{
+		Type:    string(v1alpha1.ConditionStorageReachable),
+		Reason:  string(v1alpha1.ReasonStorageCheckSucceeded),
+		Message: "The object storage is reachable",
+		Status:  metav1.ConditionTrue,
+	}
+	stack := v1alpha1.TempoStack{
+		Status: v1alpha1.TempoStackStatus{
+			Conditions: []metav1.Condition{storageReachable},
+		},
+	}
+
+	// Updating the status condition does not reset the StorageReachable condition.
+	conditions := ReadyCondition(stack)
+	assert.Len(t, conditions, 2)
+	assert.Equal(t, storageReachable, conditions[0])
+	assert.Equal(t, string(v1alpha1.ConditionReady), conditions[1].Type)
+	assert.Equal(t, metav1.ConditionTrue, conditions[1].Status)
+
+	conditions = StorageReachableCondition(conditions, metav1.Condition{
+		Reason:  string(v1alpha1.ReasonStorageCheckFailed),
+		Message: "Access Denied",
+		Status:  metav1.ConditionFalse,
+	})
+	assert.Len(t, conditions, 2)
+	assert.Equal(t, string(v1alpha1.ConditionStorageReachable), conditions[0].Type)
+	assert.Equal(t, metav1.ConditionFalse, conditions[0].Status)
+	assert.Equal(t, "Access Denied", conditions[0].Message)
+	assert.False(t, conditions[0].LastTransitionTime.IsZero())
+	assert.Equal(t, metav1.ConditionTrue, conditions[1].Status)
+
+	// The input slice is not modified.
+	assert.Equal(t, metav1.ConditionTrue, stack.Status.Conditions[0].Status)
+}
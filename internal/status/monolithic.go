@@ -0,0 +1,92 @@
+package status
+
+import (
+	"context"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+const (
+	messageMonolithicFailed  = "Some TempoMonolithic pods failed"
+	messageMonolithicPending = "Some TempoMonolithic pods are pending"
+)
+
+var (
+	metricTempoMonolithicStatusCondition = promauto.With(metrics.Registry).NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "tempomonolithic",
+		Name:      "status_condition",
+		Help:      "The status condition of a TempoMonolithic instance.",
+	}, []string{"monolithic_namespace", "monolithic_name", "condition"})
+)
+
+// UpdateMonolithicCondition updates or appends the condition to the TempoMonolithic status conditions.
+// In addition it resets all other status conditions to false.
+func UpdateMonolithicCondition(tempo v1alpha1.TempoMonolithic, condition metav1.Condition) []metav1.Condition {
+	return updateConditions(tempo.Status.Conditions, condition)
+}
+
+// GetMonolithicStatus returns the status of a TempoMonolithic instance,
+// with the Ready, Pending or Failed condition set according to the status of the Tempo pods.
+func GetMonolithicStatus(ctx context.Context, k MonolithicStatusClient, tempo v1alpha1.TempoMonolithic) (v1alpha1.TempoMonolithicStatus, error) {
+	pods, err := k.GetMonolithicPods(ctx, tempo)
+	if err != nil {
+		return v1alpha1.TempoMonolithicStatus{}, kverrors.Wrap(err, "failed to list pods for TempoMonolithic", "name", tempo.Name)
+	}
+
+	psm := v1alpha1.PodStatusMap{}
+	for _, pod := range pods.Items {
+		phase := pod.Status.Phase
+		psm[phase] = append(psm[phase], pod.Name)
+	}
+
+	status := tempo.DeepCopy().Status
+	switch {
+	case len(psm[corev1.PodFailed]) != 0 || len(psm[corev1.PodUnknown]) != 0:
+		status.Conditions = UpdateMonolithicCondition(tempo, metav1.Condition{
+			Type:    string(v1alpha1.ConditionFailed),
+			Message: messageMonolithicFailed,
+			Reason:  string(v1alpha1.ReasonFailedComponents),
+		})
+	case len(psm[corev1.PodPending]) != 0 || len(pods.Items) == 0:
+		status.Conditions = UpdateMonolithicCondition(tempo, metav1.Condition{
+			Type:    string(v1alpha1.ConditionPending),
+			Message: messageMonolithicPending,
+			Reason:  string(v1alpha1.ReasonPendingComponents),
+		})
+	default:
+		status.Conditions = UpdateMonolithicCondition(tempo, metav1.Condition{
+			Type:    string(v1alpha1.ConditionReady),
+			Message: messageReady,
+			Reason:  string(v1alpha1.ReasonReady),
+		})
+	}
+
+	return status, nil
+}
+
+// RefreshMonolithic updates the status field of a TempoMonolithic instance and the tempomonolithic_status_condition metric.
+func RefreshMonolithic(ctx context.Context, k MonolithicStatusClient, tempo v1alpha1.TempoMonolithic, status *v1alpha1.TempoMonolithicStatus) error {
+	changed := tempo.DeepCopy()
+	changed.Status = *status
+
+	activeConditions := map[string]float64{}
+	for _, cond := range status.Conditions {
+		if cond.Status == metav1.ConditionTrue {
+			activeConditions[cond.Type] = 1
+		}
+	}
+	for _, cond := range v1alpha1.AllStatusConditions {
+		condStr := string(cond)
+		isActive := activeConditions[condStr] // isActive will be 0 if the condition is not found in the map
+		metricTempoMonolithicStatusCondition.WithLabelValues(tempo.Namespace, tempo.Name, condStr).Set(isActive)
+	}
+
+	return k.PatchMonolithicStatus(ctx, changed, &tempo)
+}
@@ -0,0 +1,110 @@
+package status
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+type monolithicStatusClientStub struct {
+	GetMonolithicPodsStub     func(ctx context.Context, tempo v1alpha1.TempoMonolithic) (*corev1.PodList, error)
+	PatchMonolithicStatusStub func(ctx context.Context, changed, original *v1alpha1.TempoMonolithic) error
+}
+
+func (c *monolithicStatusClientStub) GetMonolithicPods(ctx context.Context, tempo v1alpha1.TempoMonolithic) (*corev1.PodList, error) {
+	return c.GetMonolithicPodsStub(ctx, tempo)
+}
+
+func (c *monolithicStatusClientStub) PatchMonolithicStatus(ctx context.Context, changed, original *v1alpha1.TempoMonolithic) error {
+	return c.PatchMonolithicStatusStub(ctx, changed, original)
+}
+
+func podsWithPhases(phases ...corev1.PodPhase) *corev1.PodList {
+	pods := &corev1.PodList{}
+	for _, phase := range phases {
+		pods.Items = append(pods.Items, corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: string(phase)},
+			Status:     corev1.PodStatus{Phase: phase},
+		})
+	}
+	return pods
+}
+
+func TestGetMonolithicStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		pods     *corev1.PodList
+		expected v1alpha1.ConditionStatus
+	}{
+		{
+			name:     "no pods",
+			pods:     podsWithPhases(),
+			expected: v1alpha1.ConditionPending,
+		},
+		{
+			name:     "pending pod",
+			pods:     podsWithPhases(corev1.PodPending),
+			expected: v1alpha1.ConditionPending,
+		},
+		{
+			name:     "failed pod",
+			pods:     podsWithPhases(corev1.PodRunning, corev1.PodFailed),
+			expected: v1alpha1.ConditionFailed,
+		},
+		{
+			name:     "running pod",
+			pods:     podsWithPhases(corev1.PodRunning),
+			expected: v1alpha1.ConditionReady,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &monolithicStatusClientStub{
+				GetMonolithicPodsStub: func(ctx context.Context, tempo v1alpha1.TempoMonolithic) (*corev1.PodList, error) {
+					return test.pods, nil
+				},
+			}
+
+			status, err := GetMonolithicStatus(context.Background(), c, v1alpha1.TempoMonolithic{})
+			require.NoError(t, err)
+			require.Len(t, status.Conditions, 1)
+			require.Equal(t, string(test.expected), status.Conditions[0].Type)
+			require.Equal(t, metav1.ConditionTrue, status.Conditions[0].Status)
+		})
+	}
+}
+
+func TestRefreshMonolithic(t *testing.T) {
+	tempo := v1alpha1.TempoMonolithic{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "sample",
+			Namespace: "default",
+		},
+	}
+	status := v1alpha1.TempoMonolithicStatus{
+		Conditions: []metav1.Condition{{
+			Type:   string(v1alpha1.ConditionReady),
+			Status: metav1.ConditionTrue,
+		}},
+	}
+
+	patchCount := 0
+	c := &monolithicStatusClientStub{
+		PatchMonolithicStatusStub: func(ctx context.Context, changed, original *v1alpha1.TempoMonolithic) error {
+			patchCount++
+			require.Equal(t, status, changed.Status)
+			require.Equal(t, v1alpha1.TempoMonolithicStatus{}, original.Status)
+			return nil
+		},
+	}
+
+	err := RefreshMonolithic(context.Background(), c, tempo, &status)
+	require.NoError(t, err)
+	require.Equal(t, 1, patchCount)
+}
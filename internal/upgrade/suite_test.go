@@ -12,6 +12,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
 
 	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/apis/tempo/v1beta1"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -34,6 +35,11 @@ func TestMain(m *testing.M) {
 		os.Exit(1)
 	}
 
+	if err := v1beta1.AddToScheme(testScheme); err != nil {
+		fmt.Printf("failed to register scheme: %v", err)
+		os.Exit(1)
+	}
+
 	k8sClient, err = client.New(cfg, client.Options{Scheme: testScheme})
 	if err != nil {
 		fmt.Printf("failed to setup a Kubernetes client: %v", err)
@@ -13,6 +13,7 @@ import (
 
 	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
 	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/apis/tempo/v1beta1"
 	"github.com/grafana/tempo-operator/internal/version"
 )
 
@@ -79,7 +80,7 @@ func TestUpgradeToLatest(t *testing.T) {
 	assert.NoError(t, err)
 
 	// assert versions were updated
-	assert.Equal(t, currentV.OperatorVersion, upgradedTempo.Status.OperatorVersion)
+	assert.Equal(t, "0.7.0", upgradedTempo.Status.OperatorVersion)
 	assert.Equal(t, currentV.TempoVersion, upgradedTempo.Status.TempoVersion)
 }
 
@@ -142,3 +143,31 @@ func TestSkipUpgrade(t *testing.T) {
 		})
 	}
 }
+
+func TestUpgradeToV1beta1(t *testing.T) {
+	// The instance is created with the v1alpha1 API of operator version 0.6.0.
+	nsn := types.NamespacedName{Name: "upgrade-to-v1beta1-test", Namespace: "default"}
+	original := createTempoCR(t, nsn, "0.6.0", v1alpha1.ManagementStateManaged)
+
+	currentV := version.Get()
+	currentV.OperatorVersion = "0.7.0"
+
+	upgrade := &Upgrade{
+		Client:   k8sClient,
+		Recorder: record.NewFakeRecorder(1),
+		Version:  currentV,
+		Log:      logger,
+	}
+	err := upgrade.TempoStacks(context.Background())
+	require.NoError(t, err)
+
+	// the instance was written again, which stores it in the v1beta1 storage version
+	upgradedTempo := v1beta1.TempoStack{}
+	err = k8sClient.Get(context.Background(), nsn, &upgradedTempo)
+	require.NoError(t, err)
+	assert.NotEqual(t, original.ResourceVersion, upgradedTempo.ResourceVersion)
+	assert.Equal(t, "0.7.0", upgradedTempo.Status.OperatorVersion)
+	assert.Equal(t, v1beta1.ObjectStorageSecretS3, upgradedTempo.Spec.Storage.Secret.Type)
+	assert.Equal(t, "storage-secret", upgradedTempo.Spec.Storage.Secret.Name)
+	assert.Equal(t, v1beta1.MemberListSecurityLegacy, upgradedTempo.Spec.HashRing.MemberList.Security)
+}
@@ -0,0 +1,25 @@
+package upgrade
+
+import (
+	"context"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/apis/tempo/v1beta1"
+)
+
+// This upgrade migrates the TempoStack CR to the v1beta1 storage version.
+// The upgraded instance is patched at the end of the upgrade process, which stores the object as v1beta1.
+// Before that, the CR is converted to v1beta1 and back, to ensure the conversion succeeds.
+// The MaxSearchBytesPerTrace query limits, which don't exist in v1beta1, are kept by the conversion.
+func upgrade0_7_0(ctx context.Context, u Upgrade, tempo *v1alpha1.TempoStack) (*v1alpha1.TempoStack, error) {
+	hub := &v1beta1.TempoStack{}
+	if err := tempo.ConvertTo(hub); err != nil {
+		return nil, err
+	}
+
+	upgraded := &v1alpha1.TempoStack{TypeMeta: tempo.TypeMeta}
+	if err := upgraded.ConvertFrom(hub); err != nil {
+		return nil, err
+	}
+	return upgraded, nil
+}
@@ -0,0 +1,18 @@
+package upgrade
+
+import (
+	"context"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+// This upgrade keeps the memberlist configuration without cluster label and TLS of existing TempoStacks.
+// Members with and without cluster label verification or TLS can't gossip with each other,
+// therefore enabling them at once for a running TempoStack splits the ring until all components are restarted.
+// The switch-over to the secure memberlist configuration is done step by step with spec.hashRing.memberlist.security.
+func upgrade0_7_0MemberListSecurity(ctx context.Context, u Upgrade, tempo *v1alpha1.TempoStack) (*v1alpha1.TempoStack, error) {
+	if tempo.Spec.HashRing.MemberList.Security == "" {
+		tempo.Spec.HashRing.MemberList.Security = v1alpha1.MemberListSecurityLegacy
+	}
+	return tempo, nil
+}
@@ -0,0 +1,45 @@
+package upgrade
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/version"
+)
+
+func TestUpgradeMemberListSecurity(t *testing.T) {
+	tempo := v1alpha1.TempoStack{}
+	upgraded, err := upgrade0_7_0MemberListSecurity(context.Background(), Upgrade{}, tempo.DeepCopy())
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.MemberListSecurityLegacy, upgraded.Spec.HashRing.MemberList.Security)
+
+	// a step of the switch-over is kept
+	tempo.Spec.HashRing.MemberList.Security = v1alpha1.MemberListSecurityClusterLabel
+	upgraded, err = upgrade0_7_0MemberListSecurity(context.Background(), Upgrade{}, tempo.DeepCopy())
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.MemberListSecurityClusterLabel, upgraded.Spec.HashRing.MemberList.Security)
+}
+
+func TestUpgradeMemberListSecurityFrom0_6_0(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		Status: v1alpha1.TempoStackStatus{
+			OperatorVersion: "0.6.0",
+		},
+	}
+
+	upgrade := Upgrade{Version: version.Version{OperatorVersion: "0.7.0", TempoVersion: "2.3.0"}, Log: logger}
+	upgraded, err := upgrade.updateTempoStackCR(context.Background(), tempo)
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.MemberListSecurityLegacy, upgraded.Spec.HashRing.MemberList.Security)
+
+	// TempoStacks created by operator version 0.7.0 or later use the secure memberlist configuration
+	tempo.Status.OperatorVersion = "0.7.0"
+	upgrade.Version.OperatorVersion = "0.8.0"
+	upgraded, err = upgrade.updateTempoStackCR(context.Background(), tempo)
+	require.NoError(t, err)
+	assert.Empty(t, upgraded.Spec.HashRing.MemberList.Security)
+}
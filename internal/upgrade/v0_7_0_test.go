@@ -0,0 +1,83 @@
+package upgrade
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/version"
+)
+
+func TestMigrateToV1beta1(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "ns",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			LimitSpec: v1alpha1.LimitSpec{
+				Global: v1alpha1.RateLimitSpec{
+					Query: v1alpha1.QueryLimit{
+						MaxSearchBytesPerTrace: ptr.To(0),
+						MaxBytesPerTagValues:   ptr.To(100),
+					},
+				},
+			},
+			Observability: v1alpha1.ObservabilitySpec{
+				Tracing: v1alpha1.TracingConfigSpec{
+					SamplingFraction: "1",
+				},
+			},
+			Template: v1alpha1.TempoTemplateSpec{
+				Distributor: v1alpha1.TempoDistributorSpec{
+					TempoComponentSpec: v1alpha1.TempoComponentSpec{
+						Replicas: ptr.To(int32(2)),
+					},
+				},
+			},
+		},
+		Status: v1alpha1.TempoStackStatus{
+			OperatorVersion:   "0.6.0",
+			TempoQueryVersion: "1.5.0",
+		},
+	}
+
+	upgraded, err := upgrade0_7_0(context.Background(), Upgrade{}, &tempo)
+	require.NoError(t, err)
+	assert.Equal(t, tempo.ObjectMeta, upgraded.ObjectMeta)
+	assert.Equal(t, ptr.To(0), upgraded.Spec.LimitSpec.Global.Query.MaxSearchBytesPerTrace)
+	assert.Equal(t, ptr.To(100), upgraded.Spec.LimitSpec.Global.Query.MaxBytesPerTagValues)
+	assert.Equal(t, "1", upgraded.Spec.Observability.Tracing.SamplingFraction)
+	assert.Equal(t, ptr.To(int32(2)), upgraded.Spec.Template.Distributor.Replicas)
+	assert.Equal(t, "0.6.0", upgraded.Status.OperatorVersion)
+	assert.Empty(t, upgraded.Status.TempoQueryVersion)
+}
+
+func TestUpgradeFrom0_6_0(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		Spec: v1alpha1.TempoStackSpec{
+			LimitSpec: v1alpha1.LimitSpec{
+				Global: v1alpha1.RateLimitSpec{
+					Query: v1alpha1.QueryLimit{
+						MaxSearchBytesPerTrace: ptr.To(0),
+					},
+				},
+			},
+		},
+		Status: v1alpha1.TempoStackStatus{
+			OperatorVersion: "0.6.0",
+		},
+	}
+
+	upgrade := Upgrade{Version: version.Version{OperatorVersion: "0.7.0", TempoVersion: "2.3.0"}, Log: logger}
+	upgraded, err := upgrade.updateTempoStackCR(context.Background(), tempo)
+	require.NoError(t, err)
+	assert.Equal(t, ptr.To(0), upgraded.Spec.LimitSpec.Global.Query.MaxSearchBytesPerTrace)
+	assert.Equal(t, "0.7.0", upgraded.Status.OperatorVersion)
+	assert.Equal(t, "2.3.0", upgraded.Status.TempoVersion)
+}
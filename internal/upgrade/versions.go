@@ -35,5 +35,13 @@ var (
 			version: *semver.MustParse("0.6.0"),
 			upgrade: upgrade0_6_0,
 		},
+		{
+			version: *semver.MustParse("0.7.0"),
+			upgrade: upgrade0_7_0,
+		},
+		{
+			version: *semver.MustParse("0.7.0"),
+			upgrade: upgrade0_7_0MemberListSecurity,
+		},
 	}
 )